@@ -1,8 +1,10 @@
 package main
 
 import (
+	// Time zones are resolved without relying on the tzdata of the host or image.
+	_ "time/tzdata"
 	_ "tz/docs"
-	"tz/internal/app"
+	"tz/internal/cli"
 )
 
 // @title Subscription API
@@ -12,6 +14,10 @@ import (
 // @BasePath /
 // @schemes http
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+
 func main() {
-	app.Start()
+	cli.Execute()
 }
@@ -15,54 +15,37 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/subscriptions": {
+        "/admin/api-keys": {
             "get": {
-                "description": "Get paginated list of subscriptions with optional filters",
+                "description": "List issued API keys without their secrets",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
-                ],
-                "summary": "Get list of subscriptions",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID (UUID)",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Service name",
-                        "name": "service_name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "page_size",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "List API keys",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SubscriptionsOutput"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.APIKeyOutput"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -79,10 +62,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
             },
             "post": {
-                "description": "Create a subscription record for a user",
+                "description": "Create an API key with the given scopes. The plain key is returned only once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -90,25 +78,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "admin"
                 ],
-                "summary": "Create a new subscription",
+                "summary": "Issue an API key",
                 "parameters": [
                     {
-                        "description": "Subscription data",
-                        "name": "subscription",
+                        "description": "API key data",
+                        "name": "api_key",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateSubscriptionRequest"
+                            "$ref": "#/definitions/dto.CreateAPIKeyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.SubscriptionOutput"
+                            "$ref": "#/definitions/dto.IssuedAPIKeyOutput"
                         }
                     },
                     "400": {
@@ -120,6 +108,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -129,50 +135,196 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
             }
         },
-        "/subscriptions/cost": {
+        "/admin/api-keys/{id}": {
+            "delete": {
+                "description": "Revoke an API key by ID",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/admin/jobs": {
             "get": {
-                "description": "Calculate total cost of subscriptions for a given period and filters",
+                "description": "List the background jobs of this replica with their schedule and last run. Empty when the scheduler is disabled.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "admin"
                 ],
-                "summary": "Calculate total subscription cost",
+                "summary": "List scheduled jobs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.JobStatusOutput"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/budgets": {
+            "get": {
+                "description": "List budgets, optionally of one user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "List budgets",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "User ID (UUID)",
                         "name": "user_id",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BudgetOutput"
+                            }
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Service name",
-                        "name": "service_name",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Start date in MM-YYYY format",
-                        "name": "start_date",
-                        "in": "query"
-                    },
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Cap the monthly or yearly subscription spend of a user, optionally for some services only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "Create a budget",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "End date in MM-YYYY format",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "Budget",
+                        "name": "budget",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateBudgetRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/dto.BudgetOutput"
                         }
                     },
                     "400": {
@@ -193,23 +345,27 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
             }
         },
-        "/subscriptions/{id}": {
+        "/budgets/{id}": {
             "get": {
-                "description": "Retrieve a single subscription by its UUID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "budgets"
                 ],
-                "summary": "Get subscription by ID",
+                "summary": "Get a budget",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Subscription ID (UUID)",
+                        "description": "Budget ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -219,7 +375,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SubscriptionOutput"
+                            "$ref": "#/definitions/dto.BudgetOutput"
                         }
                     },
                     "400": {
@@ -249,18 +405,23 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
             },
             "delete": {
-                "description": "Delete a subscription by ID",
+                "description": "Delete a budget together with its alerts",
                 "tags": [
-                    "subscriptions"
+                    "budgets"
                 ],
-                "summary": "Delete a subscription",
+                "summary": "Delete a budget",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Subscription ID (UUID)",
+                        "description": "Budget ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -297,10 +458,15 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
             },
             "patch": {
-                "description": "Partially update a subscription by ID",
+                "description": "Partially update a budget. Alerts already raised are kept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -308,24 +474,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "budgets"
                 ],
-                "summary": "Update a subscription",
+                "summary": "Update a budget",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Subscription ID (UUID)",
+                        "description": "Budget ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "description": "Fields to update",
-                        "name": "subscription",
+                        "name": "budget",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateSubscriptionRequest"
+                            "$ref": "#/definitions/dto.UpdateBudgetRequest"
                         }
                     }
                 ],
@@ -333,7 +499,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SubscriptionOutput"
+                            "$ref": "#/definitions/dto.BudgetOutput"
                         }
                     },
                     "400": {
@@ -345,6 +511,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -354,41 +529,2700 @@ const docTemplate = `{
                             }
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
             }
-        }
-    },
-    "definitions": {
-        "dto.CreateSubscriptionRequest": {
+        },
+        "/budgets/{id}/alerts": {
+            "get": {
+                "description": "List the alerts raised when the actual or forecast spend of a budget crossed 80% or 100%, newest period first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "List budget alerts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Budget ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "day"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "Date format of the response",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BudgetAlertOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/graphql": {
+            "post": {
+                "description": "Query subscriptions, users with their subscriptions and costs in one request. Fields need the permissions of the matching REST endpoints; a denied field is null with a FORBIDDEN error. Queries deeper or more complex than the configured limits are rejected with QUERY_TOO_DEEP or QUERY_TOO_COMPLEX. Queries selecting cost fields also spend the rate limit budget of GET /subscriptions/cost. Errors of a query are returned with status 200, with their code in extensions.code. The schema can be read by introspection.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "Run a GraphQL query",
+                "parameters": [
+                    {
+                        "description": "Query",
+                        "name": "query",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GraphQLRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "day"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "Date format of the subscriptions",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GraphQLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/livez": {
+            "get": {
+                "description": "Reports that the process is running. Does not check dependencies.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Runs dependency checks (database, migrations, ...) and reports each of them.\nFails once graceful shutdown has started.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/services": {
+            "get": {
+                "description": "List the services of the catalog by name, optionally of one category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "List services",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category",
+                        "name": "category",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ServiceOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Add a service with its canonical name, aliases, category, URL and default price. New subscriptions whose service name matches the name or an alias are linked to it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Add a service to the catalog",
+                "parameters": [
+                    {
+                        "description": "Service",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateServiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/services/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Get a service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Delete a service with its aliases. Its subscriptions keep their service name and lose the link to the catalog.",
+                "tags": [
+                    "services"
+                ],
+                "summary": "Delete a service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Partially update a service. Aliases replace the current ones. Subscriptions keep the service name they were created with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "services"
+                ],
+                "summary": "Update a service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateServiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/subscriptions": {
+            "get": {
+                "description": "Get paginated list of subscriptions with optional filters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Get list of subscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service name",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tags the subscriptions all have",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "day"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "Date format of the response",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubscriptionsOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Create a subscription record for a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Create a new subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription data",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSubscriptionRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "day"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "Date format of the response",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubscriptionOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/subscriptions/cost": {
+            "get": {
+                "description": "Calculate total cost of subscriptions for a given period and filters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Calculate total subscription cost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service name",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From in MM-YYYY or YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To in MM-YYYY or YYYY-MM-DD format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Bill the end month, overrides cost.end_month_inclusive",
+                        "name": "end_month_inclusive",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "day"
+                        ],
+                        "type": "string",
+                        "description": "Billing unit, overrides cost.proration",
+                        "name": "proration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of the current month, overrides the user and cost.time_zone zones",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tags the subscriptions all have",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "category",
+                            "tag"
+                        ],
+                        "type": "string",
+                        "description": "Split the total by category or by tag",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CostOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/subscriptions/events": {
+            "get": {
+                "description": "Server-sent events of created, updated and deleted subscriptions. Each event carries the sequence number of the change as its id, the event type as its name and the event JSON, the same as in webhooks, as its data. A client that reconnects with the Last-Event-ID header first gets the changes it missed. A comment line is sent when the stream is idle.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Stream subscription changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service name",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubscriptionEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/subscriptions/forecast": {
+            "get": {
+                "description": "Project the charges of the matched subscriptions for each month, starting with the current one. Open subscriptions continue, end dates, billing periods and scheduled price changes are applied.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Forecast subscription spend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service name",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 60,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 12,
+                        "description": "Number of months",
+                        "name": "months",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of the current month, overrides the user and cost.time_zone zones",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ForecastOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/subscriptions/upcoming": {
+            "get": {
+                "description": "List the subscriptions that renew or reach their end date from today for the next months, with the charges expected in that window",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "List upcoming renewals and endings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service name",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 24,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Window length in months",
+                        "name": "within",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone of today, overrides the user and cost.time_zone zones",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "day"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "Date format of the response",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpcomingOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/subscriptions/{id}": {
+            "get": {
+                "description": "Retrieve a single subscription by its UUID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Get subscription by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "day"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "Date format of the response",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubscriptionOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Delete a subscription by ID",
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Delete a subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Partially update a subscription by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Update a subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateSubscriptionRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "day"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "Date format of the response",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubscriptionOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/subscriptions/{id}/price-changes": {
+            "get": {
+                "description": "List the scheduled price changes of a subscription by effective date",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "List price changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "day"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "Date format of the response",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PriceChangeOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Set a new price for the charges of a subscription from the month of effective_from on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Schedule a price change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price change",
+                        "name": "price_change",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatePriceChangeRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "month",
+                            "day"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "Date format of the response",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PriceChangeOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/subscriptions/{id}/price-changes/{change_id}": {
+            "delete": {
+                "description": "Cancel a scheduled price change",
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Delete a price change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price change ID (UUID)",
+                        "name": "change_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/{user_id}/settings": {
+            "get": {
+                "description": "Get the settings of a user, such as the default time zone of cost calculations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserSettingsOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Set the settings of a user. The time zone is an IANA name used by cost calculations without a tz parameter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User settings",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserSettingsOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookOutput"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Register a URL for subscription lifecycle events. Deliveries are signed with the secret, which is generated when omitted and returned only in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Delete a webhook together with its deliveries",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Partially update a webhook. The response carries the secret when the request sets a new one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "List the deliveries of a webhook, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of deliveries",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookDeliveryOutput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/retry": {
+            "post": {
+                "description": "Make a pending or dead delivery due now with a fresh set of attempts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retry a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID (UUID)",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDeliveryOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "dto.APIKeyOutput": {
+            "description": "APIKeyOutput",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-10-19T10:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0b7e6c2a-3f7e-4a55-9d0b-0c2f0a9b8f11"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2025-10-20T10:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "billing-batch"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "tzk_3f9a1c2b"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "finance"
+                    ]
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "subscriptions:read",
+                        "cost:read"
+                    ]
+                }
+            }
+        },
+        "dto.BudgetAlertOutput": {
+            "description": "BudgetAlertOutput",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 2000
+                },
+                "budget_id": {
+                    "type": "string",
+                    "example": "3f9c2a71-8d4e-4b6a-a1c5-0e7d9b2f4a68"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-11-05T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b7e1d2c3-4a5f-4e6d-8c9b-0a1f2e3d4c5b"
+                },
+                "kind": {
+                    "description": "Kind is actual for the spend so far, forecast for the spend projected for the period.",
+                    "type": "string",
+                    "example": "forecast"
+                },
+                "period_start": {
+                    "description": "PeriodStart is the first day of the budget period the alert belongs to.",
+                    "type": "string",
+                    "example": "11-2025"
+                },
+                "spend": {
+                    "type": "integer",
+                    "example": 1700
+                },
+                "threshold": {
+                    "description": "Threshold is the crossed share of the budget in percent, 80 or 100.",
+                    "type": "integer",
+                    "example": 80
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                }
+            }
+        },
+        "dto.BudgetOutput": {
+            "description": "BudgetOutput",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 2000
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-11-05T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f9c2a71-8d4e-4b6a-a1c5-0e7d9b2f4a68"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "month"
+                },
+                "service_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Yandex Plus",
+                        "Spotify Premium"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-11-05T10:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                }
+            }
+        },
+        "dto.CostGroupOutput": {
+            "description": "CostGroupOutput",
+            "type": "object",
+            "properties": {
+                "key": {
+                    "description": "Key is the category or tag, \"\" for the subscriptions without one.",
+                    "type": "string",
+                    "example": "streaming"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3600
+                }
+            }
+        },
+        "dto.CostOutput": {
+            "description": "CostOutput",
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "description": "Groups split Total by group_by, largest first. A subscription with several tags\ncounts in the group of each, so tag groups may add up to more than Total.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CostGroupOutput"
+                    }
+                },
+                "policy": {
+                    "$ref": "#/definitions/dto.CostPolicyOutput"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 4800
+                }
+            }
+        },
+        "dto.CostPolicyOutput": {
+            "description": "CostPolicyOutput",
+            "type": "object",
+            "properties": {
+                "end_month_inclusive": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "proration": {
+                    "type": "string",
+                    "enum": [
+                        "month",
+                        "day"
+                    ],
+                    "example": "month"
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Asia/Vladivostok"
+                }
+            }
+        },
+        "dto.CreateAPIKeyRequest": {
+            "description": "CreateAPIKeyRequest",
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "billing-batch"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "finance"
+                    ]
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "subscriptions:read",
+                        "cost:read"
+                    ]
+                }
+            }
+        },
+        "dto.CreateBudgetRequest": {
+            "description": "CreateBudgetRequest",
+            "type": "object",
+            "required": [
+                "amount",
+                "user_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2000
+                },
+                "period": {
+                    "description": "Period is month or year, month when empty.",
+                    "type": "string",
+                    "enum": [
+                        "month",
+                        "year"
+                    ],
+                    "example": "month"
+                },
+                "service_names": {
+                    "description": "ServiceNames limits the budget to these services. Empty covers all services.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Yandex Plus",
+                        "Spotify Premium"
+                    ]
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                }
+            }
+        },
+        "dto.CreatePriceChangeRequest": {
+            "description": "CreatePriceChangeRequest",
+            "type": "object",
+            "required": [
+                "effective_from",
+                "price"
+            ],
+            "properties": {
+                "effective_from": {
+                    "type": "string",
+                    "example": "2026-01-01"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 499
+                }
+            }
+        },
+        "dto.CreateServiceRequest": {
+            "description": "CreateServiceRequest",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "aliases": {
+                    "description": "Aliases are other spellings resolved to the service, compared without case and extra spaces.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Нетфликс",
+                        "Netflix Premium"
+                    ]
+                },
+                "category": {
+                    "description": "Category is given to new subscriptions of the service, lowercased.",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "streaming"
+                },
+                "default_price": {
+                    "description": "DefaultPrice is the price of subscriptions created without one, 0 for none.",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 799
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Netflix"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.netflix.com"
+                }
+            }
+        },
+        "dto.CreateSubscriptionRequest": {
             "description": "CreateSubscriptionRequest",
             "type": "object",
-            "required": [
-                "price",
-                "service_name",
-                "start_date",
-                "user_id"
-            ],
+            "required": [
+                "start_date",
+                "user_id"
+            ],
+            "properties": {
+                "billing_period_months": {
+                    "description": "BillingPeriodMonths defaults to 1, a monthly plan. Use 12 for yearly plans.",
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "category": {
+                    "description": "Category defaults to the category of the catalog service. Categories and tags are\ncompared without case and extra spaces and returned lowercased.",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "streaming"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "12-2025"
+                },
+                "price": {
+                    "description": "Price defaults to the default price of the catalog service when omitted.",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 400
+                },
+                "service_id": {
+                    "description": "ServiceID picks a service of the catalog. Without it ServiceName is resolved through\nthe names and aliases of the catalog, and kept trimmed when no service matches.",
+                    "type": "string",
+                    "example": "0b6f3c2e-9a4d-4f1e-8c7b-2d5a6e9f1c3b"
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Yandex Plus"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "07-2025"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "family",
+                        "work"
+                    ]
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                }
+            }
+        },
+        "dto.CreateWebhookRequest": {
+            "description": "CreateWebhookRequest",
+            "type": "object",
+            "required": [
+                "event_types",
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "description": "EventTypes are created, updated, deleted, expired, price_changed and budget.alert.",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "created",
+                        "deleted"
+                    ]
+                },
+                "secret": {
+                    "description": "Secret signs the deliveries. A random secret is generated when empty.",
+                    "type": "string",
+                    "minLength": 16,
+                    "example": "whsec_3b1f0c9e8a7d6c5b"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://billing.example.com/hooks/subscriptions"
+                }
+            }
+        },
+        "dto.ForecastMonth": {
+            "description": "ForecastMonth",
+            "type": "object",
+            "properties": {
+                "month": {
+                    "type": "string",
+                    "example": "11-2025"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1399
+                }
+            }
+        },
+        "dto.ForecastOutput": {
+            "description": "ForecastOutput",
+            "type": "object",
+            "properties": {
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ForecastMonth"
+                    }
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "UTC"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 16788
+                }
+            }
+        },
+        "dto.GraphQLError": {
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GraphQLLocation"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "subscription not found"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "dto.GraphQLLocation": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GraphQLRequest": {
+            "description": "GraphQLRequest",
+            "type": "object",
+            "required": [
+                "query"
+            ],
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "{ subscriptions(pageSize: 5) { total items { serviceName price } } }"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "dto.GraphQLResponse": {
+            "description": "GraphQLResponse",
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GraphQLError"
+                    }
+                }
+            }
+        },
+        "dto.IssuedAPIKeyOutput": {
+            "description": "IssuedAPIKeyOutput",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-10-19T10:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0b7e6c2a-3f7e-4a55-9d0b-0c2f0a9b8f11"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "tzk_3f9a1c2b5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f70"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2025-10-20T10:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "billing-batch"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "tzk_3f9a1c2b"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "finance"
+                    ]
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "subscriptions:read",
+                        "cost:read"
+                    ]
+                }
+            }
+        },
+        "dto.JobStatusOutput": {
+            "description": "JobStatusOutput",
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "last_duration_ms": {
+                    "description": "LastDurationMs is the duration of the last run in milliseconds.",
+                    "type": "integer",
+                    "example": 42
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string",
+                    "example": "2025-11-07T10:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "evaluate_budgets"
+                },
+                "next_run_at": {
+                    "type": "string",
+                    "example": "2025-11-07T10:15:00Z"
+                },
+                "running": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "runs": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "skipped": {
+                    "description": "Skipped counts the runs another replica performed while holding the job lock.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "spec": {
+                    "type": "string",
+                    "example": "*/15 * * * *"
+                }
+            }
+        },
+        "dto.PriceChangeOutput": {
+            "description": "PriceChangeOutput",
+            "type": "object",
             "properties": {
-                "end_date": {
+                "created_at": {
                     "type": "string",
-                    "example": "12-2025"
+                    "example": "2025-11-04T10:00:00Z"
+                },
+                "effective_from": {
+                    "type": "string",
+                    "example": "01-2026"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7d0f3c1e-5b8a-4f2e-9c6d-1a2b3c4d5e6f"
                 },
                 "price": {
                     "type": "integer",
-                    "minimum": 0,
-                    "example": 400
+                    "example": 499
                 },
-                "service_name": {
+                "subscription_id": {
                     "type": "string",
-                    "example": "Yandex Plus"
+                    "example": "a1b2c3d4-e5f6-7890-g1h2-i3j4k5l6m7n8"
+                }
+            }
+        },
+        "dto.ServiceOutput": {
+            "description": "ServiceOutput",
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Нетфликс",
+                        "Netflix Premium"
+                    ]
                 },
-                "start_date": {
+                "category": {
                     "type": "string",
-                    "example": "07-2025"
+                    "example": "streaming"
                 },
-                "user_id": {
+                "created_at": {
                     "type": "string",
-                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                    "example": "2025-11-10T10:00:00Z"
+                },
+                "default_price": {
+                    "type": "integer",
+                    "example": 799
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0b6f3c2e-9a4d-4f1e-8c7b-2d5a6e9f1c3b"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Netflix"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-11-10T10:00:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.netflix.com"
+                }
+            }
+        },
+        "dto.SubscriptionEvent": {
+            "description": "SubscriptionEvent",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "1f2e3d4c-5b6a-4978-8695-a4b3c2d1e0f9"
+                },
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2025-11-08T10:00:00Z"
+                },
+                "price_change": {
+                    "description": "PriceChange is set for price_changed events of a scheduled price change.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PriceChangeOutput"
+                        }
+                    ]
+                },
+                "subscription": {
+                    "$ref": "#/definitions/dto.SubscriptionOutput"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "price_changed"
                 }
             }
         },
@@ -396,6 +3230,16 @@ const docTemplate = `{
             "description": "SubscriptionOutput",
             "type": "object",
             "properties": {
+                "billing_period_months": {
+                    "description": "BillingPeriodMonths is the number of months between charges of Price.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "category": {
+                    "description": "Category is \"\" for subscriptions without one.",
+                    "type": "string",
+                    "example": "streaming"
+                },
                 "created_at": {
                     "type": "string",
                     "example": "2025-04-05T10:00:00Z"
@@ -404,6 +3248,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "12-2025"
                 },
+                "expired_at": {
+                    "description": "ExpiredAt is when the subscription was marked as expired after its end date, null before.",
+                    "type": "string",
+                    "example": "2026-01-01 00:05:00"
+                },
                 "id": {
                     "type": "string",
                     "example": "a1b2c3d4-e5f6-7890-g1h2-i3j4k5l6m7n8"
@@ -412,6 +3261,11 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 400
                 },
+                "service_id": {
+                    "description": "ServiceID is the catalog entry of the service, null for services not in the catalog.",
+                    "type": "string",
+                    "example": "0b6f3c2e-9a4d-4f1e-8c7b-2d5a6e9f1c3b"
+                },
                 "service_name": {
                     "type": "string",
                     "example": "Yandex Plus"
@@ -420,6 +3274,16 @@ const docTemplate = `{
                     "type": "string",
                     "example": "07-2025"
                 },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "family",
+                        "work"
+                    ]
+                },
                 "updated_at": {
                     "type": "string",
                     "example": "2025-04-05T10:00:00Z"
@@ -462,19 +3326,177 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UpcomingOutput": {
+            "description": "UpcomingOutput",
+            "type": "object",
+            "properties": {
+                "expected_total": {
+                    "type": "integer",
+                    "example": 1197
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-11-05"
+                },
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UpcomingSubscription"
+                    }
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "UTC"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-02-05"
+                }
+            }
+        },
+        "dto.UpcomingSubscription": {
+            "description": "UpcomingSubscription",
+            "type": "object",
+            "properties": {
+                "ends_on": {
+                    "description": "EndsOn is the end date when it falls in the window.",
+                    "type": "string",
+                    "example": "2026-01-01"
+                },
+                "expected_charge": {
+                    "description": "ExpectedCharge is the sum of the charges in the window.",
+                    "type": "integer",
+                    "example": 1197
+                },
+                "next_renewal": {
+                    "description": "NextRenewal is the first charge date in the window, null without one.",
+                    "type": "string",
+                    "example": "2025-11-15"
+                },
+                "renewals": {
+                    "description": "Renewals counts the charges in the window.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "subscription": {
+                    "$ref": "#/definitions/dto.SubscriptionOutput"
+                }
+            }
+        },
+        "dto.UpdateBudgetRequest": {
+            "description": "UpdateBudgetRequest",
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 24000
+                },
+                "period": {
+                    "type": "string",
+                    "enum": [
+                        "month",
+                        "year"
+                    ],
+                    "example": "year"
+                },
+                "service_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Yandex Plus"
+                    ]
+                }
+            }
+        },
+        "dto.UpdateServiceRequest": {
+            "description": "UpdateServiceRequest",
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Нетфликс"
+                    ]
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "streaming"
+                },
+                "default_price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 899
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Netflix"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.netflix.com"
+                }
+            }
+        },
         "dto.UpdateSubscriptionRequest": {
             "description": "UpdateSubscriptionRequest",
             "type": "object",
             "properties": {
+                "add_tags": {
+                    "description": "AddTags and RemoveTags change the tags, leaving the others as they are. A tag in\nboth is removed.",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "work"
+                    ]
+                },
+                "billing_period_months": {
+                    "description": "BillingPeriodMonths is the number of months between charges.",
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 1,
+                    "example": 12
+                },
+                "category": {
+                    "description": "Category replaces the category, \"\" removes it.",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "music"
+                },
                 "end_date": {
                     "type": "string",
-                    "example": "06-2026"
+                    "example": "2026-06-15"
                 },
                 "price": {
                     "type": "integer",
                     "minimum": 0,
                     "example": 599
                 },
+                "remove_tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "family"
+                    ]
+                },
+                "service_id": {
+                    "description": "ServiceID and ServiceName change the service the way they set it on creation.",
+                    "type": "string",
+                    "example": "0b6f3c2e-9a4d-4f1e-8c7b-2d5a6e9f1c3b"
+                },
                 "service_name": {
                     "type": "string",
                     "example": "Spotify Premium"
@@ -484,6 +3506,194 @@ const docTemplate = `{
                     "example": "01-2026"
                 }
             }
+        },
+        "dto.UpdateUserSettingsRequest": {
+            "description": "UpdateUserSettingsRequest",
+            "type": "object",
+            "required": [
+                "time_zone"
+            ],
+            "properties": {
+                "time_zone": {
+                    "type": "string",
+                    "example": "Asia/Vladivostok"
+                }
+            }
+        },
+        "dto.UpdateWebhookRequest": {
+            "description": "UpdateWebhookRequest",
+            "type": "object",
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "created",
+                        "updated",
+                        "deleted"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "minLength": 16,
+                    "example": "whsec_9e8a7d6c5b3b1f0c"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://billing.example.com/hooks/v2"
+                }
+            }
+        },
+        "dto.UserSettingsOutput": {
+            "description": "UserSettingsOutput",
+            "type": "object",
+            "properties": {
+                "time_zone": {
+                    "type": "string",
+                    "example": "Asia/Vladivostok"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-11-03T10:00:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+                }
+            }
+        },
+        "dto.WebhookDeliveryOutput": {
+            "description": "WebhookDeliveryOutput",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-11-08T10:00:00Z"
+                },
+                "delivered_at": {
+                    "type": "string",
+                    "example": "2025-11-08T10:04:00Z"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "1f2e3d4c-5b6a-4978-8695-a4b3c2d1e0f9"
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "created"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "unexpected status 503"
+                },
+                "last_status_code": {
+                    "type": "integer",
+                    "example": 503
+                },
+                "next_attempt_at": {
+                    "description": "NextAttemptAt is set for pending deliveries.",
+                    "type": "string",
+                    "example": "2025-11-08T10:02:00Z"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "description": "Status is pending, delivered or dead.",
+                    "type": "string",
+                    "example": "pending"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-11-08T10:02:00Z"
+                },
+                "webhook_id": {
+                    "type": "string",
+                    "example": "5c8e2f4a-1b3d-4e6f-8a9b-0c1d2e3f4a5b"
+                }
+            }
+        },
+        "dto.WebhookOutput": {
+            "description": "WebhookOutput",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-11-08T10:00:00Z"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "created",
+                        "deleted"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5c8e2f4a-1b3d-4e6f-8a9b-0c1d2e3f4a5b"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "whsec_3b1f0c9e8a7d6c5b"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-11-08T10:00:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://billing.example.com/hooks/subscriptions"
+                }
+            }
+        },
+        "health.CheckResult": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/health.CheckResult"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
         }
     }
 }`
@@ -2,17 +2,30 @@ package app
 
 import (
 	"context"
+	"fmt"
+	"maps"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
+	"tz/internal/authz"
 	"tz/internal/config"
 	"tz/internal/db"
+	"tz/internal/graphapi"
+	"tz/internal/grpcapi"
 	"tz/internal/handler"
-	"tz/internal/repository"
+	"tz/internal/health"
+	"tz/internal/metrics"
+	"tz/internal/ratelimit"
+	"tz/internal/scheduler"
 	"tz/internal/server"
 	"tz/internal/service"
+	"tz/internal/storage"
+	"tz/internal/stream"
+	"tz/internal/tracing"
+	"tz/internal/webhook"
 	"tz/pkg/logger"
 
 	"go.uber.org/zap"
@@ -31,27 +44,185 @@ func Start() {
 
 	log.Info("starting app")
 
-	db, err := db.New(cfg.DatabaseConfig, log)
+	shutdownTracing, err := tracing.New(context.Background(), cfg.Tracing)
 	if err != nil {
-		log.Fatal("failed to connect to database", zap.Error(err))
+		log.Fatal("failed to initialize tracing", zap.Error(err))
 		return
 	}
 	defer func() {
-		err := db.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Error("failed to flush traces", zap.Error(err))
+		}
+	}()
+
+	store, err := storage.Open(cfg.DatabaseConfig, log)
+	if err != nil {
+		log.Fatal("failed to open storage", zap.Error(err))
+		return
+	}
+	defer func() {
+		err := store.Close()
 		if err != nil {
 			log.Error("failed to close database connection", zap.Error(err))
 		}
 	}()
-	log.Info("connected to database")
+	log.Info("storage opened", zap.String("driver", store.Driver))
+
+	if cfg.DatabaseConfig.MigrateOnStart && store.Driver == config.DriverPostgres {
+		migrator, err := db.NewMigrator(store.DB, log)
+		if err != nil {
+			log.Fatal("failed to load migrations", zap.Error(err))
+			return
+		}
+		if err := migrator.Up(context.Background()); err != nil {
+			log.Fatal("failed to apply migrations", zap.Error(err))
+			return
+		}
+	}
 
-	repository := repository.NewSubscriptionRepository(db)
-	service := service.NewSubscriptionService(repository, log)
-	handler := handler.NewHandler(service, log)
+	if cfg.Auth.Enabled && cfg.Auth.AdminToken == "" {
+		log.Warn("auth is enabled without an admin token, api keys can only be issued directly in the database")
+	}
+
+	policy, err := authz.LoadRolePolicy(cfg.Auth.PolicyFile)
+	if err != nil {
+		log.Fatal("failed to load authorization policy", zap.Error(err))
+		return
+	}
+
+	var limiter *ratelimit.Limiter
+	if cfg.Server.RateLimit.Enabled {
+		limits := make(map[string]ratelimit.Limit, len(cfg.Server.RateLimit.Groups))
+		for group, l := range cfg.Server.RateLimit.Groups {
+			limits[group] = ratelimit.Limit{Requests: l.Requests, Period: l.Period, Burst: l.Burst}
+		}
+		limiter, err = ratelimit.NewLimiter(ratelimit.NewMemoryStore(), limits)
+		if err != nil {
+			log.Fatal("failed to configure rate limiter", zap.Error(err))
+			return
+		}
+	}
+
+	checker := health.New(cfg.Server.ReadinessTimeout)
+	checker.Register("database", store.Ping)
+	if store.Driver == config.DriverPostgres {
+		checker.Register("migrations", func(ctx context.Context) error {
+			return db.CheckMigrations(ctx, store.DB)
+		})
+	}
+
+	metrics := metrics.New(log)
+	if store.DB != nil {
+		dbName := cfg.DatabaseConfig.DsnConfig.DBName
+		if store.Driver == config.DriverSQLite {
+			dbName = cfg.DatabaseConfig.SQLite.Path
+		}
+		metrics.RegisterDB(store.DB.DB, dbName)
+	}
+
+	apiKeyService := service.NewAPIKeyService(store.APIKeys, log)
+	cost, err := costPolicy(cfg.Cost)
+	if err != nil {
+		log.Fatal("invalid cost configuration", zap.Error(err))
+		return
+	}
+	userSettingsService := service.NewUserSettingsService(store.UserSettings, log)
+	subscriptionService := service.NewSubscriptionService(store.Subscriptions, store.UserSettings, metrics, cost, log,
+		service.WithOutbox(store.Webhooks, store.Transactor), service.WithCatalog(store.Catalog))
+	metrics.RegisterStats(subscriptionService)
+	catalogService := service.NewCatalogService(store.Catalog, store.Transactor, log)
+	budgetService := service.NewBudgetService(store.Budgets, subscriptionService, store.Webhooks, store.Transactor, log)
+	webhookService := NewWebhookService(cfg.Webhooks, store, log)
+	broker := newBroker(cfg.Stream, store, log)
+	streamService := service.NewStreamService(store.Events, broker, store.Catalog, log)
+	graphQL, err := graphapi.NewServer(subscriptionService, graphapi.Limits{
+		MaxDepth:      cfg.GraphQL.MaxDepth,
+		MaxComplexity: cfg.GraphQL.MaxComplexity,
+	}, log)
+	if err != nil {
+		log.Fatal("failed to initialize graphql", zap.Error(err))
+		return
+	}
+
+	jobs, err := newScheduler(cfg.Scheduler, store, log, map[string]scheduler.Func{
+		jobExpireSubscriptions: func(ctx context.Context) error {
+			_, err := subscriptionService.MarkExpiredSubscriptions(ctx)
+			return err
+		},
+		jobEvaluateBudgets: func(ctx context.Context) error {
+			_, err := budgetService.EvaluateBudgets(ctx)
+			return err
+		},
+		jobDeliverWebhooks: func(ctx context.Context) error {
+			_, err := webhookService.DeliverWebhooks(ctx)
+			return err
+		},
+		jobPurgeWebhooks: func(ctx context.Context) error {
+			_, _, err := webhookService.PurgeHistory(ctx, cfg.Webhooks.Retention)
+			return err
+		},
+	})
+	if err != nil {
+		log.Fatal("invalid scheduler configuration", zap.Error(err))
+		return
+	}
+
+	deps := handler.Deps{
+		Service:  subscriptionService,
+		APIKeys:  apiKeyService,
+		Users:    userSettingsService,
+		Budgets:  budgetService,
+		Catalog:  catalogService,
+		Webhooks: webhookService,
+		Stream:   streamService,
+		GraphQL:  graphQL,
+		Policy:   policy,
+		Limiter:  limiter,
+		Metrics:  metrics,
+		Health:   checker,
+
+		StreamHeartbeat: cfg.Stream.Heartbeat,
+	}
+	if cfg.Server.AdminPort == "" {
+		deps.MetricsHandler = metrics.Handler()
+	}
+	if jobs != nil {
+		deps.Jobs = jobs
+	}
+	handler := handler.NewHandler(deps, cfg.Auth, log)
 	log.Info("dependencies initialized")
 
+	var adminServer *server.Server
+	if cfg.Server.AdminPort != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		adminServer = server.NewAdmin(cfg.Server, mux)
+		log.Info("admin server initialized", zap.String("port", cfg.Server.AdminPort))
+	}
+
+	var grpcServer *server.GRPCServer
+	if cfg.Server.GRPCPort != "" {
+		api := grpcapi.NewServer(grpcapi.Deps{
+			Service: subscriptionService,
+			APIKeys: apiKeyService,
+			Policy:  policy,
+			Limiter: limiter,
+		}, cfg.Auth, log)
+		grpcServer = server.NewGRPC(cfg.Server, api.Register, api.Interceptor())
+		log.Info("grpc server initialized", zap.String("port", cfg.Server.GRPCPort))
+	}
+
 	server := server.New(cfg.Server, handler.Init())
 	log.Info("server initialized")
 
+	streamCtx, stopStream := context.WithCancel(context.Background())
+	go broker.Run(streamCtx)
+	if store.Driver == config.DriverPostgres {
+		go listenEvents(streamCtx, cfg, broker, log)
+	}
+
 	go func() {
 		if err := server.Run(); err != nil && err != http.ErrServerClosed {
 			log.Error("server failed", zap.Error(err))
@@ -60,16 +231,157 @@ func Start() {
 		}
 	}()
 
+	if adminServer != nil {
+		go func() {
+			if err := adminServer.Run(); err != nil && err != http.ErrServerClosed {
+				log.Error("admin server failed", zap.Error(err))
+			}
+		}()
+	}
+
+	if grpcServer != nil {
+		go func() {
+			if err := grpcServer.Run(); err != nil {
+				log.Error("grpc server failed", zap.Error(err))
+			}
+		}()
+	}
+
+	if jobs != nil {
+		jobs.Start()
+	}
+
 	log.Info("application is running. Waiting for termination signal...")
 	quite := make(chan os.Signal, 1)
 	signal.Notify(quite, syscall.SIGINT, syscall.SIGTERM)
 	<-quite
 
 	log.Info("termination signal received. Shutting down gracefully...")
+	checker.SetShuttingDown()
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
+	// Closes the open event streams, which would hold the server shutdown.
+	stopStream()
+
+	if grpcServer != nil {
+		if err := grpcServer.Shutdown(ctx); err != nil {
+			log.Error("grpc server shutdown error", zap.Error(err))
+		}
+	}
+
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			log.Error("admin server shutdown error", zap.Error(err))
+		}
+	}
+
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatal("server shutdown error", zap.Error(err))
 	}
+
+	// Jobs such as webhook deliveries outlast the server budget, so they get their own.
+	if jobs != nil {
+		jobsCtx, cancelJobs := context.WithTimeout(context.Background(), cfg.Scheduler.ShutdownTimeout)
+		defer cancelJobs()
+		if err := jobs.Stop(jobsCtx); err != nil {
+			log.Error("scheduler shutdown error", zap.Error(err))
+		}
+	}
+}
+
+// Names of the built-in jobs, used as keys of scheduler.jobs.
+const (
+	jobExpireSubscriptions = "expire_subscriptions"
+	jobEvaluateBudgets     = "evaluate_budgets"
+	jobDeliverWebhooks     = "deliver_webhooks"
+	jobPurgeWebhooks       = "purge_webhooks"
+)
+
+// NewWebhookService builds the webhook service with the configured client and retry
+// policy, for the server and the CLI commands alike.
+func NewWebhookService(cfg config.WebhookConfig, store *storage.Storage, log *zap.Logger) *service.WebhookService {
+	return service.NewWebhookService(store.Webhooks, store.Transactor, webhook.NewClient(cfg.Timeout), service.RetryPolicy{
+		MaxAttempts: cfg.MaxAttempts,
+		BaseDelay:   cfg.BackoffBase,
+		MaxDelay:    cfg.BackoffMax,
+		BatchSize:   cfg.BatchSize,
+	}, log)
+}
+
+// newScheduler registers the configured jobs, or returns nil when the scheduler is
+// disabled. Replicas sharing a PostgreSQL database claim each run in job_runs.
+func newScheduler(cfg config.SchedulerConfig, store *storage.Storage, log *zap.Logger, jobs map[string]scheduler.Func) (*scheduler.Scheduler, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	var locker scheduler.Locker = scheduler.NewLocalLocker()
+	if store.Driver == config.DriverPostgres {
+		locker = db.NewAdvisoryLocker(store.DB, log)
+	}
+
+	s := scheduler.New(locker, log)
+	for _, name := range slices.Sorted(maps.Keys(cfg.Jobs)) {
+		run, ok := jobs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown job %q", name)
+		}
+		if cfg.Jobs[name] == "" {
+			continue
+		}
+		if err := s.Add(name, cfg.Jobs[name], run); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// newBroker returns the broker of the event streams. PostgreSQL announces new events
+// with NOTIFY, see db.Listen; the other drivers are polled.
+func newBroker(cfg config.StreamConfig, store *storage.Storage, log *zap.Logger) *stream.Broker {
+	opts := stream.Options{
+		PollInterval: cfg.PollInterval,
+		Buffer:       cfg.Buffer,
+		// A batch fits into the buffer of a subscriber that is keeping up.
+		BatchSize:  cfg.Buffer,
+		GapTimeout: cfg.GapTimeout,
+	}
+	if store.Driver == config.DriverPostgres {
+		opts.PollInterval = 0
+	}
+	return stream.NewBroker(store.Events, opts, log)
+}
+
+// listenEvents wakes the broker on the notifications of new events until ctx is done.
+// When the listener cannot be set up, the broker is woken every poll interval instead.
+func listenEvents(ctx context.Context, cfg *config.Config, broker *stream.Broker, log *zap.Logger) {
+	err := db.Listen(ctx, db.DSN(cfg.DatabaseConfig), db.EventsChannel, broker.Notify, log)
+	if err == nil {
+		return
+	}
+	log.Error("event listener failed, polling for events instead", zap.Error(err))
+
+	ticker := time.NewTicker(cfg.Stream.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			broker.Notify()
+		}
+	}
+}
+
+func costPolicy(cfg config.CostConfig) (service.CostPolicy, error) {
+	loc, err := service.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		return service.CostPolicy{}, err
+	}
+	return service.CostPolicy{
+		EndMonthInclusive: cfg.EndMonthInclusive,
+		Proration:         service.Proration(cfg.Proration),
+		Location:          loc,
+	}, nil
 }
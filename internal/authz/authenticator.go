@@ -0,0 +1,67 @@
+package authz
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"tz/internal/domain"
+)
+
+// ErrInsufficientScope is returned by Authorize when the principal lacks the scope
+// backing the permission.
+var ErrInsufficientScope = fmt.Errorf("%w: insufficient scope", domain.ErrForbidden)
+
+// KeyResolver returns the API key of a raw key, domain.ErrUnauthorized when it is
+// unknown, revoked or expired.
+type KeyResolver interface {
+	Authenticate(ctx context.Context, rawKey string) (domain.APIKey, error)
+}
+
+// Authenticator resolves the principal of a request from its credentials and checks its
+// permissions. The REST and gRPC transports share it.
+type Authenticator struct {
+	adminToken string
+	keys       KeyResolver
+	policy     Policy
+}
+
+// NewAuthenticator accepts adminToken, when not empty, as a principal holding every scope
+// and looks up any other credentials in keys.
+func NewAuthenticator(adminToken string, keys KeyResolver, policy Policy) *Authenticator {
+	return &Authenticator{adminToken: adminToken, keys: keys, policy: policy}
+}
+
+// Authenticate returns the principal of rawKey. Unknown credentials return an error
+// wrapping domain.ErrUnauthorized.
+func (a *Authenticator) Authenticate(ctx context.Context, rawKey string) (domain.Principal, error) {
+	if a.adminToken != "" && subtle.ConstantTimeCompare([]byte(rawKey), []byte(a.adminToken)) == 1 {
+		return domain.Principal{
+			ID:     domain.PrincipalAdminToken,
+			Kind:   domain.PrincipalAdminToken,
+			Name:   domain.PrincipalAdminToken,
+			Scopes: domain.AllScopes,
+			Roles:  []string{domain.RoleAdmin},
+		}, nil
+	}
+
+	key, err := a.keys.Authenticate(ctx, rawKey)
+	if err != nil {
+		return domain.Principal{}, err
+	}
+	return key.Principal(), nil
+}
+
+// Authorize checks that principal holds the scope backing permission and is granted it
+// by the policy. A missing scope returns ErrInsufficientScope, a policy denial
+// domain.ErrForbidden; both carry the reason.
+func (a *Authenticator) Authorize(ctx context.Context, principal domain.Principal, permission domain.Permission) error {
+	if scope := permission.Scope(); !principal.HasScope(scope) {
+		return fmt.Errorf("%w: missing scope %s", ErrInsufficientScope, scope)
+	}
+
+	if decision := a.policy.Authorize(ctx, principal, permission); !decision.Allowed {
+		return fmt.Errorf("%w: %s", domain.ErrForbidden, decision.Reason)
+	}
+
+	return nil
+}
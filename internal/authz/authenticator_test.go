@@ -0,0 +1,52 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"tz/internal/domain"
+)
+
+type keyResolverFunc func(ctx context.Context, rawKey string) (domain.APIKey, error)
+
+func (f keyResolverFunc) Authenticate(ctx context.Context, rawKey string) (domain.APIKey, error) {
+	return f(ctx, rawKey)
+}
+
+func TestAuthenticator(t *testing.T) {
+	keys := keyResolverFunc(func(_ context.Context, rawKey string) (domain.APIKey, error) {
+		if rawKey != "tzk_reader" {
+			return domain.APIKey{}, domain.ErrUnauthorized
+		}
+		return domain.APIKey{Name: "reader", Scopes: []domain.Scope{domain.ScopeSubscriptionsRead}, Roles: []string{"support"}}, nil
+	})
+	policy := NewRolePolicy(RoleConfig{Roles: map[string][]string{
+		"admin":   {"*"},
+		"support": {"subscriptions:update"},
+	}})
+	a := NewAuthenticator("admin-secret", keys, policy)
+	ctx := context.Background()
+
+	admin, err := a.Authenticate(ctx, "admin-secret")
+	if err != nil || admin.Kind != domain.PrincipalAdminToken {
+		t.Fatalf("Authenticate(admin token) = %+v, %v", admin, err)
+	}
+	if err := a.Authorize(ctx, admin, domain.PermSubscriptionsDelete); err != nil {
+		t.Errorf("Authorize(admin token) error = %v", err)
+	}
+
+	if _, err := a.Authenticate(ctx, "tzk_unknown"); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("Authenticate(unknown) error = %v, want ErrUnauthorized", err)
+	}
+
+	reader, err := a.Authenticate(ctx, "tzk_reader")
+	if err != nil || reader.Name != "reader" {
+		t.Fatalf("Authenticate(key) = %+v, %v", reader, err)
+	}
+	if err := a.Authorize(ctx, reader, domain.PermSubscriptionsDelete); !errors.Is(err, ErrInsufficientScope) {
+		t.Errorf("Authorize(missing scope) error = %v, want ErrInsufficientScope", err)
+	}
+	if err := a.Authorize(ctx, reader, domain.PermSubscriptionsRead); !errors.Is(err, domain.ErrForbidden) || errors.Is(err, ErrInsufficientScope) {
+		t.Errorf("Authorize(denied by policy) error = %v, want ErrForbidden", err)
+	}
+}
@@ -0,0 +1,97 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"tz/internal/domain"
+
+	"github.com/spf13/viper"
+)
+
+const wildcard = "*"
+
+// Decision is the outcome of a policy evaluation. Reason explains denials.
+type Decision struct {
+	Allowed bool
+	Reason  string
+}
+
+func Allow() Decision {
+	return Decision{Allowed: true}
+}
+
+func Deny(reason string) Decision {
+	return Decision{Reason: reason}
+}
+
+// Policy decides whether a principal may perform an action.
+type Policy interface {
+	Authorize(ctx context.Context, principal domain.Principal, permission domain.Permission) Decision
+}
+
+type RoleConfig struct {
+	DefaultRoles []string            `mapstructure:"default_roles"`
+	Roles        map[string][]string `mapstructure:"roles"`
+}
+
+// RolePolicy grants permissions through role-to-permission mappings.
+// Principals without roles are evaluated with DefaultRoles.
+type RolePolicy struct {
+	defaultRoles []string
+	roles        map[string][]domain.Permission
+}
+
+func NewRolePolicy(cfg RoleConfig) *RolePolicy {
+	roles := make(map[string][]domain.Permission, len(cfg.Roles))
+	for role, permissions := range cfg.Roles {
+		for _, p := range permissions {
+			roles[role] = append(roles[role], domain.Permission(p))
+		}
+	}
+	return &RolePolicy{defaultRoles: cfg.DefaultRoles, roles: roles}
+}
+
+// LoadRolePolicy reads a RoleConfig from a YAML file.
+func LoadRolePolicy(path string) (*RolePolicy, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var cfg RoleConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policy file: %w", err)
+	}
+
+	for _, role := range cfg.DefaultRoles {
+		if _, ok := cfg.Roles[role]; !ok {
+			return nil, fmt.Errorf("default role %q is not defined", role)
+		}
+	}
+
+	return NewRolePolicy(cfg), nil
+}
+
+func (p *RolePolicy) Authorize(_ context.Context, principal domain.Principal, permission domain.Permission) Decision {
+	roles := principal.Roles
+	if len(roles) == 0 {
+		roles = p.defaultRoles
+	}
+	if len(roles) == 0 {
+		return Deny("principal has no roles")
+	}
+
+	for _, role := range roles {
+		permissions, ok := p.roles[role]
+		if !ok {
+			continue
+		}
+		if slices.Contains(permissions, permission) || slices.Contains(permissions, wildcard) {
+			return Allow()
+		}
+	}
+
+	return Deny(fmt.Sprintf("roles %v do not grant %s", roles, permission))
+}
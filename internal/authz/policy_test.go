@@ -0,0 +1,58 @@
+package authz
+
+import (
+	"context"
+	"testing"
+	"tz/internal/domain"
+)
+
+func TestRolePolicy_Authorize(t *testing.T) {
+	policy := NewRolePolicy(RoleConfig{
+		DefaultRoles: []string{"integration"},
+		Roles: map[string][]string{
+			"admin":       {"*"},
+			"integration": {"subscriptions:read", "cost:read"},
+			"support":     {"subscriptions:read", "subscriptions:update"},
+			"finance":     {"cost:read"},
+		},
+	})
+
+	tests := []struct {
+		name       string
+		roles      []string
+		permission domain.Permission
+		want       bool
+	}{
+		{name: "admin wildcard", roles: []string{"admin"}, permission: domain.PermSubscriptionsDelete, want: true},
+		{name: "support reads", roles: []string{"support"}, permission: domain.PermSubscriptionsRead, want: true},
+		{name: "support cannot delete", roles: []string{"support"}, permission: domain.PermSubscriptionsDelete, want: false},
+		{name: "finance reads cost", roles: []string{"finance"}, permission: domain.PermCostRead, want: true},
+		{name: "finance cannot list", roles: []string{"finance"}, permission: domain.PermSubscriptionsRead, want: false},
+		{name: "any role grants", roles: []string{"finance", "support"}, permission: domain.PermSubscriptionsUpdate, want: true},
+		{name: "unknown role", roles: []string{"guest"}, permission: domain.PermSubscriptionsRead, want: false},
+		{name: "default roles", roles: nil, permission: domain.PermCostRead, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := policy.Authorize(context.Background(), domain.Principal{Roles: tt.roles}, tt.permission)
+			if got.Allowed != tt.want {
+				t.Errorf("Authorize() = %v (%s), want %v", got.Allowed, got.Reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRolePolicy(t *testing.T) {
+	policy, err := LoadRolePolicy("../../configs/rbac.yaml")
+	if err != nil {
+		t.Fatalf("LoadRolePolicy() error = %v", err)
+	}
+
+	finance := domain.Principal{Roles: []string{"finance"}}
+	if !policy.Authorize(context.Background(), finance, domain.PermCostRead).Allowed {
+		t.Errorf("finance should read cost")
+	}
+	if policy.Authorize(context.Background(), finance, domain.PermSubscriptionsDelete).Allowed {
+		t.Errorf("finance should not delete subscriptions")
+	}
+}
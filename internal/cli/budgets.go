@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"tz/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+func newBudgetsCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "budgets",
+		Short: "Budget maintenance",
+	}
+	cmd.AddCommand(newBudgetsEvaluateCommand(opts))
+	return cmd
+}
+
+func newBudgetsEvaluateCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "evaluate",
+		Short: "Evaluate all budgets once and record the alerts of crossed thresholds",
+		Long:  "Does what the background evaluator of the server does on each tick. New alerts are sent as budget.alert webhook events.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			e, err := newEnv()
+			if err != nil {
+				return err
+			}
+			defer e.Close()
+
+			budgets := service.NewBudgetService(e.store.Budgets, e.service, e.store.Webhooks, e.store.Transactor, e.log)
+			created, err := budgets.EvaluateBudgets(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			return printSummary(cmd.OutOrStdout(), opts.output, map[string]int{"alerts": created})
+		},
+	}
+}
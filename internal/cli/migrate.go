@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"tz/internal/config"
+	"tz/internal/db"
+
+	"github.com/spf13/cobra"
+)
+
+func newMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage database migrations",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply all pending migrations",
+			Args:  cobra.NoArgs,
+			RunE: withMigrator(func(cmd *cobra.Command, migrator *db.Migrator, args []string) error {
+				return migrator.Up(cmd.Context())
+			}),
+		},
+		&cobra.Command{
+			Use:   "down [N]",
+			Short: "Revert the last N migrations (default 1)",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: withMigrator(func(cmd *cobra.Command, migrator *db.Migrator, args []string) error {
+				steps := 1
+				if len(args) == 1 {
+					n, err := strconv.Atoi(args[0])
+					if err != nil || n < 1 {
+						return fmt.Errorf("invalid number of steps %q", args[0])
+					}
+					steps = n
+				}
+				return migrator.Down(cmd.Context(), steps)
+			}),
+		},
+		&cobra.Command{
+			Use:   "goto V",
+			Short: "Migrate up or down to version V (0 reverts everything)",
+			Args:  cobra.ExactArgs(1),
+			RunE: withMigrator(func(cmd *cobra.Command, migrator *db.Migrator, args []string) error {
+				version, err := strconv.ParseUint(args[0], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid version %q", args[0])
+				}
+				return migrator.Goto(cmd.Context(), version)
+			}),
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "Print the applied and pending versions",
+			Args:  cobra.NoArgs,
+			RunE: withMigrator(func(cmd *cobra.Command, migrator *db.Migrator, args []string) error {
+				status, err := migrator.Status(cmd.Context())
+				if err != nil {
+					return err
+				}
+				out := cmd.OutOrStdout()
+				fmt.Fprintf(out, "current: %d (dirty: %t)\nlatest:  %d\n", status.Current, status.Dirty, status.Latest)
+				for _, m := range status.Pending {
+					fmt.Fprintf(out, "pending: %d_%s\n", m.Version, m.Name)
+				}
+				return nil
+			}),
+		},
+	)
+
+	return cmd
+}
+
+func withMigrator(run func(cmd *cobra.Command, migrator *db.Migrator, args []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		e, err := newEnv()
+		if err != nil {
+			return err
+		}
+		defer e.Close()
+
+		if e.store.Driver != config.DriverPostgres {
+			return fmt.Errorf("migrations apply to the %s driver only, %s creates its schema on open", config.DriverPostgres, e.store.Driver)
+		}
+
+		migrator, err := db.NewMigrator(e.store.DB, e.log)
+		if err != nil {
+			return err
+		}
+		return run(cmd, migrator, args)
+	}
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"tz/internal/dto"
+)
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func printTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func printSubscriptions(w io.Writer, format string, subs []dto.SubscriptionOutput) error {
+	if format == outputJSON {
+		return printJSON(w, subs)
+	}
+
+	rows := make([][]string, len(subs))
+	for i, s := range subs {
+		end := "-"
+		if s.EndDate != nil {
+			end = *s.EndDate
+		}
+		rows[i] = []string{s.ID, s.UserID, s.ServiceName, strconv.Itoa(s.Price), strconv.Itoa(s.BillingPeriodMonths), s.StartDate, end}
+	}
+	return printTable(w, []string{"ID", "USER", "SERVICE", "PRICE", "PERIOD", "START", "END"}, rows)
+}
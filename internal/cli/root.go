@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"tz/internal/app"
+	"tz/internal/config"
+	"tz/internal/service"
+	"tz/internal/storage"
+	"tz/pkg/logger"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+type options struct {
+	output string
+}
+
+// Execute runs the command line interface and exits with a non-zero code on failure.
+func Execute() {
+	if err := newRootCommand().ExecuteContext(context.Background()); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &options{}
+
+	root := &cobra.Command{
+		Use:   "main",
+		Short: "Subscription service and admin tools",
+		Long:  "Without a subcommand the HTTP server is started, same as `serve`.",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.output != outputTable && opts.output != outputJSON {
+				return fmt.Errorf("invalid --output %q, expected %s or %s", opts.output, outputTable, outputJSON)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app.Start()
+			return nil
+		},
+		SilenceUsage: true,
+	}
+	root.PersistentFlags().StringVarP(&opts.output, "output", "o", outputTable, "output format: table or json")
+
+	root.AddCommand(
+		newServeCommand(),
+		newMigrateCommand(),
+		newImportCommand(opts),
+		newExportCommand(opts),
+		newCostCommand(opts),
+		newForecastCommand(opts),
+		newSeedCommand(opts),
+		newPurgeCommand(opts),
+		newBudgetsCommand(opts),
+		newWebhooksCommand(opts),
+	)
+
+	return root
+}
+
+func newServeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Start the HTTP server",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app.Start()
+			return nil
+		},
+	}
+}
+
+// env holds the dependencies shared by the admin commands.
+type env struct {
+	cfg     *config.Config
+	log     *zap.Logger
+	store   *storage.Storage
+	service *service.SubscriptionService
+}
+
+func newEnv() (*env, error) {
+	cfg, err := config.New()
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := logger.New(cfg.LoggerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := storage.Open(cfg.DatabaseConfig, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open storage: %w", err)
+	}
+
+	loc, err := service.LoadLocation(cfg.Cost.TimeZone)
+	if err != nil {
+		_ = store.Close()
+		return nil, fmt.Errorf("invalid cost configuration: %w", err)
+	}
+
+	return &env{
+		cfg:   cfg,
+		log:   log,
+		store: store,
+		service: service.NewSubscriptionService(store.Subscriptions, store.UserSettings, nil, service.CostPolicy{
+			EndMonthInclusive: cfg.Cost.EndMonthInclusive,
+			Proration:         service.Proration(cfg.Cost.Proration),
+			Location:          loc,
+		}, log, service.WithOutbox(store.Webhooks, store.Transactor), service.WithCatalog(store.Catalog)),
+	}, nil
+}
+
+func (e *env) Close() {
+	if err := e.store.Close(); err != nil {
+		e.log.Error("failed to close database connection", zap.Error(err))
+	}
+	_ = e.log.Sync()
+}
@@ -0,0 +1,409 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	contextkeys "tz/internal/contextkey"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/pkg/valid"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+const exportPageSize = 100
+
+func newImportCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "import <file>",
+		Short: "Create subscriptions from a JSON array or a CSV file",
+		Long: `Create subscriptions from a file. Files ending in .csv must have the header
+service_name,price,user_id,start_date,end_date, optionally followed by
+billing_period_months; anything else is read as a JSON
+array of subscription objects as accepted by POST /subscriptions.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			requests, err := readImportFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			e, err := newEnv()
+			if err != nil {
+				return err
+			}
+			defer e.Close()
+
+			var created, failed int
+			for i, req := range requests {
+				if err := valid.ValidateStruct(req); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "record %d: %v\n", i+1, err)
+					failed++
+					continue
+				}
+				if _, err := e.service.CreateSubscription(cmd.Context(), req); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "record %d: %v\n", i+1, err)
+					failed++
+					continue
+				}
+				created++
+			}
+
+			if err := printSummary(cmd.OutOrStdout(), opts.output, map[string]int{"created": created, "failed": failed}); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d records failed", failed, len(requests))
+			}
+			return nil
+		},
+	}
+}
+
+func newExportCommand(opts *options) *cobra.Command {
+	var userID, serviceName, dateFormat string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Print all subscriptions, optionally filtered",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := domain.ParseDateFormat(dateFormat)
+			if err != nil {
+				return err
+			}
+
+			e, err := newEnv()
+			if err != nil {
+				return err
+			}
+			defer e.Close()
+
+			filter := dto.SubscriptionFilter{
+				UserID:      optional(userID),
+				ServiceName: optional(serviceName),
+				Page:        1,
+				PageSize:    exportPageSize,
+			}
+
+			ctx := contextkeys.WithDateFormat(cmd.Context(), format)
+			var subs []dto.SubscriptionOutput
+			for {
+				page, err := e.service.Subscriptions(ctx, filter)
+				if err != nil {
+					return err
+				}
+				subs = append(subs, page.Subscriptions...)
+				if !page.HasNextPage {
+					break
+				}
+				filter.Page++
+			}
+
+			return printSubscriptions(cmd.OutOrStdout(), opts.output, subs)
+		},
+	}
+	cmd.Flags().StringVar(&userID, "user", "", "user ID (UUID)")
+	cmd.Flags().StringVar(&serviceName, "service", "", "service name")
+	cmd.Flags().StringVar(&dateFormat, "date-format", string(domain.DateFormatMonth), "date format: month (MM-YYYY) or day (YYYY-MM-DD)")
+
+	return cmd
+}
+
+func newCostCommand(opts *options) *cobra.Command {
+	var userID, serviceName, from, to, proration, tz string
+	var endMonthInclusive bool
+
+	cmd := &cobra.Command{
+		Use:   "cost",
+		Short: "Calculate the total cost of subscriptions for a period",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			e, err := newEnv()
+			if err != nil {
+				return err
+			}
+			defer e.Close()
+
+			req := dto.CostRequest{
+				UserID:      optional(userID),
+				ServiceName: optional(serviceName),
+				From:        optional(from),
+				To:          optional(to),
+				Proration:   optional(proration),
+				TimeZone:    optional(tz),
+			}
+			if cmd.Flags().Changed("end-month-inclusive") {
+				req.EndMonthInclusive = &endMonthInclusive
+			}
+
+			cost, err := e.service.SubscriptionsCost(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			if opts.output == outputJSON {
+				return printJSON(cmd.OutOrStdout(), cost)
+			}
+			return printTable(cmd.OutOrStdout(), []string{"TOTAL", "END_MONTH_INCLUSIVE", "PRORATION", "TIME_ZONE"}, [][]string{{
+				strconv.Itoa(cost.Total), strconv.FormatBool(cost.Policy.EndMonthInclusive), cost.Policy.Proration, cost.Policy.TimeZone,
+			}})
+		},
+	}
+	cmd.Flags().StringVar(&userID, "user", "", "user ID (UUID)")
+	cmd.Flags().StringVar(&serviceName, "service", "", "service name")
+	cmd.Flags().StringVar(&from, "from", "", "period start in MM-YYYY or YYYY-MM-DD format")
+	cmd.Flags().StringVar(&to, "to", "", "period end in MM-YYYY or YYYY-MM-DD format")
+	cmd.Flags().BoolVar(&endMonthInclusive, "end-month-inclusive", false, "bill the end month (default from config)")
+	cmd.Flags().StringVar(&proration, "proration", "", "billing unit: month or day (default from config)")
+	cmd.Flags().StringVar(&tz, "tz", "", "IANA time zone of the current month (default: the user's zone, then config)")
+
+	return cmd
+}
+
+func newForecastCommand(opts *options) *cobra.Command {
+	var userID, serviceName, tz string
+	var months int
+
+	cmd := &cobra.Command{
+		Use:   "forecast",
+		Short: "Project subscription spend for each of the coming months",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			e, err := newEnv()
+			if err != nil {
+				return err
+			}
+			defer e.Close()
+
+			forecast, err := e.service.Forecast(cmd.Context(), dto.ForecastRequest{
+				UserID:      optional(userID),
+				ServiceName: optional(serviceName),
+				Months:      months,
+				TimeZone:    optional(tz),
+			})
+			if err != nil {
+				return err
+			}
+
+			if opts.output == outputJSON {
+				return printJSON(cmd.OutOrStdout(), forecast)
+			}
+			rows := make([][]string, 0, len(forecast.Months)+1)
+			for _, m := range forecast.Months {
+				rows = append(rows, []string{m.Month, strconv.Itoa(m.Total)})
+			}
+			rows = append(rows, []string{"TOTAL", strconv.Itoa(forecast.Total)})
+			return printTable(cmd.OutOrStdout(), []string{"MONTH", "SPEND"}, rows)
+		},
+	}
+	cmd.Flags().StringVar(&userID, "user", "", "user ID (UUID)")
+	cmd.Flags().StringVar(&serviceName, "service", "", "service name")
+	cmd.Flags().IntVar(&months, "months", 12, "number of months, starting with the current one")
+	cmd.Flags().StringVar(&tz, "tz", "", "IANA time zone of the current month (default: the user's zone, then config)")
+
+	return cmd
+}
+
+var seedServices = []struct {
+	name  string
+	price int
+}{
+	{"Yandex Plus", 400},
+	{"Netflix", 999},
+	{"Spotify Premium", 599},
+	{"YouTube Premium", 499},
+	{"iCloud+", 149},
+	{"Kinopoisk", 299},
+	{"GitHub Copilot", 1000},
+}
+
+func newSeedCommand(opts *options) *cobra.Command {
+	var count, users int
+
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Create random subscriptions for development",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if count < 1 || users < 1 {
+				return errors.New("--count and --users must be positive")
+			}
+
+			e, err := newEnv()
+			if err != nil {
+				return err
+			}
+			defer e.Close()
+
+			userIDs := make([]string, users)
+			for i := range userIDs {
+				userIDs[i] = uuid.NewString()
+			}
+
+			now := time.Now()
+			created := 0
+			for range count {
+				svc := seedServices[rand.IntN(len(seedServices))]
+				start := now.AddDate(0, -rand.IntN(24), 0)
+				req := dto.CreateSubscriptionRequest{
+					ServiceName: svc.name,
+					Price:       svc.price,
+					UserID:      userIDs[rand.IntN(len(userIDs))],
+					StartDate:   fmt.Sprintf("%02d-%d", start.Month(), start.Year()),
+				}
+				if rand.IntN(3) == 0 {
+					end := start.AddDate(0, 1+rand.IntN(12), 0)
+					endDate := fmt.Sprintf("%02d-%d", end.Month(), end.Year())
+					req.EndDate = &endDate
+				}
+
+				if _, err := e.service.CreateSubscription(cmd.Context(), req); err != nil {
+					return fmt.Errorf("created %d subscriptions before failing: %w", created, err)
+				}
+				created++
+			}
+
+			return printSummary(cmd.OutOrStdout(), opts.output, map[string]int{"created": created})
+		},
+	}
+	cmd.Flags().IntVar(&count, "count", 100, "number of subscriptions to create")
+	cmd.Flags().IntVar(&users, "users", 10, "number of distinct users")
+
+	return cmd
+}
+
+func newPurgeCommand(opts *options) *cobra.Command {
+	var userID, serviceName string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Delete subscriptions, all of them unless filtered",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !yes {
+				return errors.New("purge deletes data irreversibly, pass --yes to confirm")
+			}
+
+			e, err := newEnv()
+			if err != nil {
+				return err
+			}
+			defer e.Close()
+
+			deleted, err := e.service.PurgeSubscriptions(cmd.Context(), dto.PurgeRequest{
+				UserID:      optional(userID),
+				ServiceName: optional(serviceName),
+			})
+			if err != nil {
+				return err
+			}
+
+			return printSummary(cmd.OutOrStdout(), opts.output, map[string]int{"deleted": deleted})
+		},
+	}
+	cmd.Flags().StringVar(&userID, "user", "", "only delete subscriptions of this user (UUID)")
+	cmd.Flags().StringVar(&serviceName, "service", "", "only delete subscriptions of this service")
+	cmd.Flags().BoolVar(&yes, "yes", false, "confirm the deletion")
+
+	return cmd
+}
+
+func readImportFile(path string) ([]dto.CreateSubscriptionRequest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return readImportCSV(f)
+	}
+
+	var requests []dto.CreateSubscriptionRequest
+	if err := json.NewDecoder(f).Decode(&requests); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return requests, nil
+}
+
+func readImportCSV(r io.Reader) ([]dto.CreateSubscriptionRequest, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"service_name", "price", "user_id", "start_date"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header is missing column %s", name)
+		}
+	}
+
+	requests := make([]dto.CreateSubscriptionRequest, 0, len(records)-1)
+	for line, record := range records[1:] {
+		price, err := strconv.Atoi(record[columns["price"]])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid price %q", line+2, record[columns["price"]])
+		}
+		req := dto.CreateSubscriptionRequest{
+			ServiceName: record[columns["service_name"]],
+			Price:       price,
+			UserID:      record[columns["user_id"]],
+			StartDate:   record[columns["start_date"]],
+		}
+		if i, ok := columns["end_date"]; ok && record[i] != "" {
+			endDate := record[i]
+			req.EndDate = &endDate
+		}
+		if i, ok := columns["billing_period_months"]; ok && record[i] != "" {
+			period, err := strconv.Atoi(record[i])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid billing_period_months %q", line+2, record[i])
+			}
+			req.BillingPeriodMonths = &period
+		}
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
+
+func printSummary(w io.Writer, format string, summary map[string]int) error {
+	if format == outputJSON {
+		return printJSON(w, summary)
+	}
+
+	header := make([]string, 0, len(summary))
+	row := make([]string, 0, len(summary))
+	for _, key := range []string{"created", "failed", "deleted", "alerts", "delivered", "deliveries", "events"} {
+		if v, ok := summary[key]; ok {
+			header = append(header, strings.ToUpper(key))
+			row = append(row, strconv.Itoa(v))
+		}
+	}
+	return printTable(w, header, [][]string{row})
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
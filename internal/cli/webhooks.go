@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+	"tz/internal/app"
+	"tz/internal/webhook"
+
+	"github.com/spf13/cobra"
+)
+
+func newWebhooksCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhooks",
+		Short: "Webhook maintenance",
+	}
+	cmd.AddCommand(newWebhooksDeliverCommand(opts), newWebhooksPurgeCommand(opts), newWebhooksListenCommand())
+	return cmd
+}
+
+func newWebhooksDeliverCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deliver",
+		Short: "Dispatch pending events and attempt the due webhook deliveries once",
+		Long:  "Does what the deliver_webhooks job of the server does on each tick.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			e, err := newEnv()
+			if err != nil {
+				return err
+			}
+			defer e.Close()
+
+			delivered, err := app.NewWebhookService(e.cfg.Webhooks, e.store, e.log).DeliverWebhooks(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			return printSummary(cmd.OutOrStdout(), opts.output, map[string]int{"delivered": delivered})
+		},
+	}
+}
+
+func newWebhooksPurgeCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "purge",
+		Short: "Delete finished webhook deliveries and dispatched events older than the retention",
+		Long:  "Does what the purge_webhooks job of the server does on each run.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			e, err := newEnv()
+			if err != nil {
+				return err
+			}
+			defer e.Close()
+
+			deliveries, events, err := app.NewWebhookService(e.cfg.Webhooks, e.store, e.log).PurgeHistory(cmd.Context(), e.cfg.Webhooks.Retention)
+			if err != nil {
+				return err
+			}
+
+			return printSummary(cmd.OutOrStdout(), opts.output, map[string]int{"deliveries": deliveries, "events": events})
+		},
+	}
+}
+
+func newWebhooksListenCommand() *cobra.Command {
+	var (
+		addr      string
+		secret    string
+		tolerance time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "listen",
+		Short: "Receive webhook deliveries locally and print the verified ones",
+		Long:  "Starts an HTTP receiver that checks the signature of each delivery, answers 204 and prints it. Requests with a bad signature get 401.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if secret == "" {
+				return errors.New("--secret is required")
+			}
+
+			out := cmd.OutOrStdout()
+			srv := &http.Server{
+				Addr:              addr,
+				ReadHeaderTimeout: 10 * time.Second,
+				Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					body, err := webhook.VerifyRequest(r, secret, tolerance)
+					if err != nil {
+						fmt.Fprintf(cmd.ErrOrStderr(), "rejected delivery %s: %v\n", r.Header.Get(webhook.HeaderDelivery), err)
+						http.Error(w, err.Error(), http.StatusUnauthorized)
+						return
+					}
+					fmt.Fprintf(out, "%s %s %s\n", r.Header.Get(webhook.HeaderEvent), r.Header.Get(webhook.HeaderDelivery), body)
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				_ = srv.Close()
+			}()
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "listening on %s\n", addr)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&addr, "addr", ":9090", "address to listen on")
+	cmd.Flags().StringVar(&secret, "secret", "", "webhook secret the deliveries are signed with")
+	cmd.Flags().DurationVar(&tolerance, "tolerance", 5*time.Minute, "accepted age of the delivery timestamp")
+	return cmd
+}
@@ -23,9 +23,22 @@ type ConnectionConfig struct {
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime" validate:"required"`
 }
 
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
+type SQLiteConfig struct {
+	Path string `mapstructure:"path" validate:"required"`
+}
+
 type DatabaseConfig struct {
-	DsnConfig        DsnConfig        `mapstructure:"dsn"`
+	Driver           string           `mapstructure:"driver" validate:"oneof=postgres sqlite memory"`
+	DsnConfig        DsnConfig        `mapstructure:"dsn" validate:"-"`
+	SQLite           SQLiteConfig     `mapstructure:"sqlite"`
 	ConnectionConfig ConnectionConfig `mapstructure:"connection"`
+	MigrateOnStart   bool             `mapstructure:"migrate_on_start"`
 }
 
 type LoggerConfig struct {
@@ -38,18 +51,115 @@ type LoggerConfig struct {
 	ErrorOutputPaths  []string `mapstructure:"error_output_paths"`
 }
 
+type RateLimitGroupCfg struct {
+	Requests int           `mapstructure:"requests" validate:"required,min=1"`
+	Period   time.Duration `mapstructure:"period" validate:"required"`
+	Burst    int           `mapstructure:"burst" validate:"min=0"`
+}
+
+type RateLimitCfg struct {
+	Enabled bool                         `mapstructure:"enabled"`
+	Groups  map[string]RateLimitGroupCfg `mapstructure:"groups" validate:"dive"`
+}
+
 type ServerCfg struct {
-	Port           string        `mapstructure:"port" validate:"required"`
-	ReadTimeout    time.Duration `mapstructure:"read_timeout" validate:"required"`
-	WriteTimeout   time.Duration `mapstructure:"write_timeout" validate:"required"`
-	IdleTimeout    time.Duration `mapstructure:"idle_timeout" validate:"required"`
-	MaxHeaderBytes int           `mapstructure:"max_header_bytes" validate:"required"`
+	Port             string        `mapstructure:"port" validate:"required"`
+	AdminPort        string        `mapstructure:"admin_port"`
+	GRPCPort         string        `mapstructure:"grpc_port"`
+	ReadTimeout      time.Duration `mapstructure:"read_timeout" validate:"required"`
+	WriteTimeout     time.Duration `mapstructure:"write_timeout" validate:"required"`
+	IdleTimeout      time.Duration `mapstructure:"idle_timeout" validate:"required"`
+	MaxHeaderBytes   int           `mapstructure:"max_header_bytes" validate:"required"`
+	ReadinessTimeout time.Duration `mapstructure:"readiness_timeout" validate:"required"`
+	RateLimit        RateLimitCfg  `mapstructure:"rate_limit"`
+}
+
+// PlaceholderAdminToken is the admin token shipped in .env.example. The service
+// refuses to start with it, so a copied example never exposes the admin API.
+const PlaceholderAdminToken = "change-me-admin-token"
+
+type AuthConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	AdminToken string `mapstructure:"admin_token"`
+	PolicyFile string `mapstructure:"policy_file"`
+}
+
+type TracingConfig struct {
+	Exporter    string  `mapstructure:"exporter" validate:"omitempty,oneof=none stdout otlp"`
+	Endpoint    string  `mapstructure:"endpoint" validate:"required_if=Exporter otlp"`
+	Insecure    bool    `mapstructure:"insecure"`
+	ServiceName string  `mapstructure:"service_name"`
+	SampleRatio float64 `mapstructure:"sample_ratio" validate:"min=0,max=1"`
+}
+
+// CostConfig sets the default cost calculation policy.
+type CostConfig struct {
+	EndMonthInclusive bool   `mapstructure:"end_month_inclusive"`
+	Proration         string `mapstructure:"proration" validate:"omitempty,oneof=month day"`
+	// TimeZone is the IANA zone used when neither the request nor the user sets one.
+	TimeZone string `mapstructure:"time_zone"`
+}
+
+// SchedulerConfig controls the in-process job scheduler.
+type SchedulerConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// ShutdownTimeout bounds the wait for running jobs on shutdown, after the servers
+	// have drained.
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout" validate:"required_if=Enabled true"`
+	// Jobs maps job names to cron specs. Jobs without a spec are not scheduled.
+	Jobs map[string]string `mapstructure:"jobs"`
+}
+
+// WebhookConfig controls the delivery of webhooks.
+type WebhookConfig struct {
+	// Timeout bounds one delivery attempt.
+	Timeout time.Duration `mapstructure:"timeout" validate:"required"`
+	// MaxAttempts is the number of attempts after which a delivery is dead.
+	MaxAttempts int `mapstructure:"max_attempts" validate:"required,min=1"`
+	// BackoffBase is the delay after the first failed attempt. It doubles after each
+	// further failure up to BackoffMax.
+	BackoffBase time.Duration `mapstructure:"backoff_base" validate:"required"`
+	BackoffMax  time.Duration `mapstructure:"backoff_max" validate:"required,gtefield=BackoffBase"`
+	// BatchSize limits the events dispatched and the deliveries attempted per statement.
+	BatchSize int `mapstructure:"batch_size" validate:"required,min=1"`
+	// Retention is how long delivered and dead deliveries and dispatched events are kept
+	// before the purge_webhooks job deletes them.
+	Retention time.Duration `mapstructure:"retention" validate:"required"`
+}
+
+// StreamConfig controls the server-sent events stream of subscription changes.
+type StreamConfig struct {
+	// Heartbeat is the interval of the comments that keep idle streams open through proxies.
+	Heartbeat time.Duration `mapstructure:"heartbeat" validate:"required"`
+	// PollInterval is how often SQLite and memory storage are checked for new events.
+	// PostgreSQL announces them with LISTEN/NOTIFY instead.
+	PollInterval time.Duration `mapstructure:"poll_interval" validate:"required"`
+	// Buffer is the number of events a client may lag behind before its stream is closed.
+	Buffer int `mapstructure:"buffer" validate:"required,min=1"`
+	// GapTimeout is how long an event that is not committed yet holds back the later ones.
+	GapTimeout time.Duration `mapstructure:"gap_timeout" validate:"required"`
+}
+
+// GraphQLConfig limits the queries of the /graphql endpoint. Zero disables a limit.
+type GraphQLConfig struct {
+	// MaxDepth is the deepest nesting of fields a query may select.
+	MaxDepth int `mapstructure:"max_depth" validate:"min=0"`
+	// MaxComplexity bounds the estimated number of fields a query resolves, with the
+	// fields under lists counted once per item and cost fields counted ten times.
+	MaxComplexity int `mapstructure:"max_complexity" validate:"min=0"`
 }
 
 type Config struct {
-	DatabaseConfig DatabaseConfig `mapstructure:"database"`
-	LoggerConfig   LoggerConfig   `mapstructure:"logger"`
-	Server         ServerCfg      `mapstructure:"server"`
+	DatabaseConfig DatabaseConfig  `mapstructure:"database"`
+	LoggerConfig   LoggerConfig    `mapstructure:"logger"`
+	Server         ServerCfg       `mapstructure:"server"`
+	Auth           AuthConfig      `mapstructure:"auth"`
+	Tracing        TracingConfig   `mapstructure:"tracing"`
+	Cost           CostConfig      `mapstructure:"cost"`
+	Scheduler      SchedulerConfig `mapstructure:"scheduler"`
+	Webhooks       WebhookConfig   `mapstructure:"webhooks"`
+	Stream         StreamConfig    `mapstructure:"stream"`
+	GraphQL        GraphQLConfig   `mapstructure:"graphql"`
 }
 
 func New() (*Config, error) {
@@ -66,13 +176,37 @@ func New() (*Config, error) {
 	v.SetConfigName(name)
 
 	bindings := map[string]string{
-		"database.dsn.host":     "DB_HOST",
-		"database.dsn.port":     "DB_PORT",
-		"database.dsn.username": "DB_USERNAME",
-		"database.dsn.password": "DB_PASSWORD",
-		"database.dsn.db_name":  "DB_NAME",
-		"database.dsn.ssl_mode": "DB_SSL",
-		"server.port":           "SERVER_PORT",
+		"database.driver":                     "DB_DRIVER",
+		"database.sqlite.path":                "DB_SQLITE_PATH",
+		"database.dsn.host":                   "DB_HOST",
+		"database.dsn.port":                   "DB_PORT",
+		"database.dsn.username":               "DB_USERNAME",
+		"database.dsn.password":               "DB_PASSWORD",
+		"database.dsn.db_name":                "DB_NAME",
+		"database.dsn.ssl_mode":               "DB_SSL",
+		"database.migrate_on_start":           "DB_MIGRATE_ON_START",
+		"server.port":                         "SERVER_PORT",
+		"server.admin_port":                   "SERVER_ADMIN_PORT",
+		"server.grpc_port":                    "SERVER_GRPC_PORT",
+		"auth.enabled":                        "AUTH_ENABLED",
+		"auth.admin_token":                    "AUTH_ADMIN_TOKEN",
+		"tracing.exporter":                    "TRACING_EXPORTER",
+		"tracing.endpoint":                    "TRACING_ENDPOINT",
+		"cost.end_month_inclusive":            "COST_END_MONTH_INCLUSIVE",
+		"cost.proration":                      "COST_PRORATION",
+		"cost.time_zone":                      "COST_TIME_ZONE",
+		"scheduler.enabled":                   "SCHEDULER_ENABLED",
+		"scheduler.shutdown_timeout":          "SCHEDULER_SHUTDOWN_TIMEOUT",
+		"scheduler.jobs.expire_subscriptions": "SCHEDULER_EXPIRE_SUBSCRIPTIONS",
+		"scheduler.jobs.evaluate_budgets":     "SCHEDULER_EVALUATE_BUDGETS",
+		"scheduler.jobs.deliver_webhooks":     "SCHEDULER_DELIVER_WEBHOOKS",
+		"scheduler.jobs.purge_webhooks":       "SCHEDULER_PURGE_WEBHOOKS",
+		"webhooks.timeout":                    "WEBHOOKS_TIMEOUT",
+		"webhooks.max_attempts":               "WEBHOOKS_MAX_ATTEMPTS",
+		"webhooks.retention":                  "WEBHOOKS_RETENTION",
+		"stream.heartbeat":                    "STREAM_HEARTBEAT",
+		"graphql.max_depth":                   "GRAPHQL_MAX_DEPTH",
+		"graphql.max_complexity":              "GRAPHQL_MAX_COMPLEXITY",
 	}
 
 	for key, env := range bindings {
@@ -95,5 +229,16 @@ func New() (*Config, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	// Connection settings are only required by the driver that uses them.
+	if cfg.DatabaseConfig.Driver == DriverPostgres {
+		if err := valid.ValidateStruct(cfg.DatabaseConfig.DsnConfig); err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
+	if cfg.Auth.AdminToken == PlaceholderAdminToken {
+		return nil, fmt.Errorf("invalid config: auth.admin_token is the .env.example placeholder, set AUTH_ADMIN_TOKEN to a secret")
+	}
+
 	return &cfg, nil
 }
@@ -1,5 +1,36 @@
 package contextkeys
 
+import (
+	"context"
+	"tz/internal/domain"
+)
+
 type contextKey string
 
-const RequestIDKey contextKey = "request_id"
+const (
+	RequestIDKey  contextKey = "request_id"
+	DateFormatKey contextKey = "date_format"
+)
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
+
+// RequestID returns the request ID stored in ctx. It reports false when ctx
+// does not come from an HTTP request, e.g. in background jobs.
+func RequestID(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(RequestIDKey).(string)
+	return requestID, ok && requestID != ""
+}
+
+func WithDateFormat(ctx context.Context, format domain.DateFormat) context.Context {
+	return context.WithValue(ctx, DateFormatKey, format)
+}
+
+// DateFormat returns the date format requested by the caller, DateFormatMonth if none.
+func DateFormat(ctx context.Context) domain.DateFormat {
+	if format, ok := ctx.Value(DateFormatKey).(domain.DateFormat); ok {
+		return format
+	}
+	return domain.DateFormatMonth
+}
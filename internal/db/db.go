@@ -11,11 +11,14 @@ import (
 	"go.uber.org/zap"
 )
 
-func New(cfg config.DatabaseConfig, log *zap.Logger) (*sqlx.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+// DSN returns the connection string of the PostgreSQL database in cfg.
+func DSN(cfg config.DatabaseConfig) string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.DsnConfig.Host, cfg.DsnConfig.Port, cfg.DsnConfig.Username, cfg.DsnConfig.Password, cfg.DsnConfig.DBName, cfg.DsnConfig.SSLMode)
+}
 
-	db, err := sqlx.Open("postgres", dsn)
+func New(cfg config.DatabaseConfig, log *zap.Logger) (*sqlx.DB, error) {
+	db, err := sqlx.Open("postgres", DSN(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
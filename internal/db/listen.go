@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// EventsChannel is notified with the sequence number of each new outbox event, see the
+// notify_outbox_events migration.
+const EventsChannel = "subscription_events"
+
+// listenPingInterval is how often an idle listener checks its connection.
+const listenPingInterval = time.Minute
+
+// Listen calls notify for each notification on channel until ctx is done. The listener
+// holds its own connection and reconnects when it drops; notify is also called after a
+// reconnect, since notifications sent in between are lost.
+func Listen(ctx context.Context, dsn, channel string, notify func(), log *zap.Logger) error {
+	log = log.With(zap.String("channel", channel))
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventDisconnected:
+			log.Warn("Listener disconnected", zap.Error(err))
+		case pq.ListenerEventConnectionAttemptFailed:
+			log.Warn("Listener failed to reconnect", zap.Error(err))
+		case pq.ListenerEventReconnected:
+			log.Info("Listener reconnected")
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(channel); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", channel, err)
+	}
+
+	ping := time.NewTicker(listenPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-listener.Notify:
+			notify()
+		case <-ping.C:
+			_ = listener.Ping()
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// jobRunsRetention is how long claimed runs are kept in job_runs. A replica whose timer
+// fires this much later than the others would run the tick again.
+const jobRunsRetention = 24 * time.Hour
+
+// AdvisoryLocker grants each scheduled run of a job to one replica. A run is claimed by
+// inserting its job_runs row, so replicas firing after the winner has finished skip the
+// same tick. pg_try_advisory_lock, held by a dedicated connection until unlock, keeps
+// runs of a job from overlapping.
+type AdvisoryLocker struct {
+	db  *sqlx.DB
+	log *zap.Logger
+}
+
+func NewAdvisoryLocker(db *sqlx.DB, log *zap.Logger) *AdvisoryLocker {
+	return &AdvisoryLocker{db: db, log: log}
+}
+
+func (l *AdvisoryLocker) TryLock(ctx context.Context, name string, scheduledAt time.Time) (func(), bool, error) {
+	conn, err := l.db.Connx(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	key := advisoryKey(name)
+	var acquired bool
+	if err := conn.GetContext(ctx, &acquired, `SELECT pg_try_advisory_lock($1)`, key); err != nil {
+		_ = conn.Close()
+		return nil, false, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+	if !acquired {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, key); err != nil {
+			l.log.Error("failed to release lock", zap.String("lock", name), zap.Error(err))
+		}
+		_ = conn.Close()
+	}
+
+	result, err := conn.ExecContext(ctx,
+		`INSERT INTO job_runs (name, scheduled_at) VALUES ($1, $2) ON CONFLICT DO NOTHING`, name, scheduledAt.UTC())
+	if err != nil {
+		unlock()
+		return nil, false, fmt.Errorf("failed to claim run of %s: %w", name, err)
+	}
+	if claimed, err := result.RowsAffected(); err != nil || claimed == 0 {
+		unlock()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to claim run of %s: %w", name, err)
+		}
+		return nil, false, nil
+	}
+
+	if _, err := conn.ExecContext(ctx, `DELETE FROM job_runs WHERE name = $1 AND scheduled_at < $2`,
+		name, scheduledAt.Add(-jobRunsRetention).UTC()); err != nil {
+		l.log.Warn("failed to delete old job runs", zap.String("job", name), zap.Error(err))
+	}
+
+	return unlock, true, nil
+}
+
+// advisoryKey maps a lock name to a pg_advisory_lock key. The prefix keeps job keys
+// apart from migrationLockID.
+func advisoryKey(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("tz_job:" + name))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,201 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// migrationLockID is the pg_advisory_lock key serializing migrations across replicas.
+const migrationLockID int64 = 0x747a5f6d6967 // "tz_mig"
+
+var ErrDirtyMigration = errors.New("database is in a dirty migration state")
+
+type MigrationStatus struct {
+	Current uint64
+	Dirty   bool
+	Latest  uint64
+	Pending []Migration
+}
+
+// Migrator applies the embedded migrations. Every migration runs in its own
+// transaction together with the version update, under an advisory lock.
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []Migration
+	log        *zap.Logger
+}
+
+func NewMigrator(db *sqlx.DB, log *zap.Logger) (*Migrator, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations, log: log}, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) (MigrationStatus, error) {
+	current, dirty, err := MigrationVersion(ctx, m.db)
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+
+	status := MigrationStatus{Current: current, Dirty: dirty}
+	for _, migration := range m.migrations {
+		status.Latest = migration.Version
+		if migration.Version > current {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	if len(m.migrations) == 0 {
+		return nil
+	}
+	return m.Goto(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down rolls back the given number of applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		current, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		idx := m.index(current)
+		if idx < 0 && current != 0 {
+			return fmt.Errorf("applied version %d is unknown to this binary", current)
+		}
+
+		target := uint64(0)
+		if idx-steps >= 0 {
+			target = m.migrations[idx-steps].Version
+		}
+		return m.migrate(ctx, conn, current, target)
+	})
+}
+
+// Goto migrates up or down until version is applied. Version 0 reverts everything.
+func (m *Migrator) Goto(ctx context.Context, version uint64) error {
+	if version != 0 && m.index(version) < 0 {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		current, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		return m.migrate(ctx, conn, current, version)
+	})
+}
+
+func (m *Migrator) migrate(ctx context.Context, conn *sqlx.Conn, current, target uint64) error {
+	if current == target {
+		m.log.Info("database schema is up to date", zap.Uint64("version", current))
+		return nil
+	}
+
+	if target > current {
+		for _, migration := range m.migrations {
+			if migration.Version <= current || migration.Version > target {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration.Up, migration.Version); err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			m.log.Info("migration applied", zap.Uint64("version", migration.Version), zap.String("name", migration.Name))
+		}
+		return nil
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version > current || migration.Version <= target {
+			continue
+		}
+		previous := uint64(0)
+		if i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+		if err := m.apply(ctx, conn, migration.Down, previous); err != nil {
+			return fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		m.log.Info("migration reverted", zap.Uint64("version", migration.Version), zap.String("name", migration.Name))
+	}
+	return nil
+}
+
+// apply runs script and records version in one transaction. Version 0 clears the table.
+func (m *Migrator) apply(ctx context.Context, conn *sqlx.Conn, script string, version uint64) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if script != "" {
+		if _, err := tx.ExecContext(ctx, script); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return fmt.Errorf("failed to clear migration version: %w", err)
+	}
+	if version != 0 {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, version); err != nil {
+			return fmt.Errorf("failed to record migration version: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) cleanVersion(ctx context.Context, conn *sqlx.Conn) (uint64, error) {
+	current, dirty, err := MigrationVersion(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w at version %d, fix it manually", ErrDirtyMigration, current)
+	}
+	return current, nil
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			m.log.Error("failed to release migration lock", zap.Error(err))
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`); err != nil {
+		return fmt.Errorf("failed to create migration table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) index(version uint64) int {
+	return slices.IndexFunc(m.migrations, func(migration Migration) bool {
+		return migration.Version == version
+	})
+}
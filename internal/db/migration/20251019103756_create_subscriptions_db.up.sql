@@ -6,7 +6,7 @@ CREATE TABLE subscriptions (
     start_date DATE NOT NULL,
     end_date DATE,
     created_at TIMESTAMPTZ DEFAULT NOW(),
-    updated_at TIMESTAMPTZ DEFAULT NOW()
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
 
     CONSTRAINT end_date_not_before_start CHECK (end_date IS NULL OR end_date >= start_date)
-);
\ No newline at end of file
+);
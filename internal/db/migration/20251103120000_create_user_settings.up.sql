@@ -0,0 +1,5 @@
+CREATE TABLE user_settings (
+    user_id UUID PRIMARY KEY,
+    time_zone TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,12 @@
+ALTER TABLE subscriptions
+    ADD COLUMN billing_period_months INTEGER NOT NULL DEFAULT 1 CHECK (billing_period_months > 0);
+
+CREATE TABLE price_changes (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    price INTEGER NOT NULL CHECK (price > 0),
+    effective_from DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX price_changes_subscription_id_idx ON price_changes (subscription_id, effective_from);
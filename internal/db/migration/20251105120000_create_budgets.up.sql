@@ -0,0 +1,25 @@
+CREATE TABLE budgets (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    period TEXT NOT NULL CHECK (period IN ('month', 'year')),
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    service_names TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX budgets_user_id_idx ON budgets (user_id);
+
+CREATE TABLE budget_alerts (
+    id UUID PRIMARY KEY,
+    budget_id UUID NOT NULL REFERENCES budgets (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    period_start DATE NOT NULL,
+    threshold INTEGER NOT NULL CHECK (threshold > 0),
+    kind TEXT NOT NULL CHECK (kind IN ('actual', 'forecast')),
+    spend INTEGER NOT NULL,
+    amount INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT budget_alerts_once UNIQUE (budget_id, period_start, threshold, kind)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS subscriptions_end_date_idx;
+DROP INDEX IF EXISTS subscriptions_start_date_idx;
@@ -0,0 +1,2 @@
+CREATE INDEX subscriptions_start_date_idx ON subscriptions (start_date);
+CREATE INDEX subscriptions_end_date_idx ON subscriptions (end_date);
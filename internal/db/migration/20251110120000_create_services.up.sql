@@ -0,0 +1,42 @@
+CREATE TABLE services (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    name_key TEXT NOT NULL UNIQUE,
+    category TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    default_price INTEGER NOT NULL DEFAULT 0 CHECK (default_price >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE service_aliases (
+    alias_key TEXT PRIMARY KEY,
+    service_id UUID NOT NULL REFERENCES services (id) ON DELETE CASCADE,
+    alias TEXT NOT NULL
+);
+
+CREATE INDEX service_aliases_service_id_idx ON service_aliases (service_id);
+
+ALTER TABLE subscriptions ADD COLUMN service_id UUID REFERENCES services (id) ON DELETE SET NULL;
+
+CREATE INDEX subscriptions_service_id_idx ON subscriptions (service_id);
+
+-- One service per normalized name, named after its most used spelling.
+INSERT INTO services (id, name, name_key)
+SELECT gen_random_uuid(), name, name_key
+FROM (
+    SELECT DISTINCT ON (name_key) name, name_key
+    FROM (
+        SELECT btrim(service_name) AS name,
+               lower(regexp_replace(btrim(service_name), '\s+', ' ', 'g')) AS name_key,
+               COUNT(*) AS uses
+        FROM subscriptions
+        GROUP BY 1, 2
+    ) spellings
+    ORDER BY name_key, uses DESC, name
+) names;
+
+UPDATE subscriptions s
+SET service_id = services.id, service_name = services.name
+FROM services
+WHERE services.name_key = lower(regexp_replace(btrim(s.service_name), '\s+', ' ', 'g'));
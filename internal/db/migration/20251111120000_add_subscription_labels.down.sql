@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS subscription_tags;
+DROP INDEX IF EXISTS subscriptions_category_idx;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS category;
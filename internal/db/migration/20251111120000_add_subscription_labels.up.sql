@@ -0,0 +1,19 @@
+ALTER TABLE subscriptions ADD COLUMN category TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX subscriptions_category_idx ON subscriptions (category);
+
+CREATE TABLE subscription_tags (
+    subscription_id UUID NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (subscription_id, tag)
+);
+
+CREATE INDEX subscription_tags_tag_idx ON subscription_tags (tag, subscription_id);
+
+-- Categories are compared by their normalized form, like tags.
+UPDATE services SET category = lower(regexp_replace(btrim(category), '\s+', ' ', 'g'));
+
+UPDATE subscriptions s
+SET category = services.category
+FROM services
+WHERE services.id = s.service_id;
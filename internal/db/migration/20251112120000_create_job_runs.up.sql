@@ -0,0 +1,7 @@
+-- One row per run of a scheduled job, claimed by the replica that runs it.
+CREATE TABLE job_runs (
+    name TEXT NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    claimed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (name, scheduled_at)
+);
@@ -0,0 +1,3 @@
+-- Used by the purge_webhooks job and by the cascade from outbox_events.
+CREATE INDEX webhook_deliveries_finished_idx ON webhook_deliveries (updated_at) WHERE status <> 'pending';
+CREATE INDEX webhook_deliveries_event_idx ON webhook_deliveries (event_id);
@@ -0,0 +1,30 @@
+package db
+
+import "testing"
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("Migrations() error = %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("Migrations() returned no migrations")
+	}
+
+	for i, m := range migrations {
+		if m.Up == "" || m.Down == "" {
+			t.Errorf("migration %d_%s must have both up and down scripts", m.Version, m.Name)
+		}
+		if i > 0 && migrations[i-1].Version >= m.Version {
+			t.Errorf("migrations are not ordered: %d before %d", migrations[i-1].Version, m.Version)
+		}
+	}
+
+	latest, err := LatestMigrationVersion()
+	if err != nil {
+		t.Fatalf("LatestMigrationVersion() error = %v", err)
+	}
+	if latest != migrations[len(migrations)-1].Version {
+		t.Errorf("LatestMigrationVersion() = %d, want %d", latest, migrations[len(migrations)-1].Version)
+	}
+}
@@ -0,0 +1,175 @@
+package db
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	"tz/internal/config"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema mirrors the PostgreSQL migrations. Timestamps are stored as text in a
+// sortable layout and arrays in PostgreSQL literal form, so the repositories run unchanged.
+//
+//go:embed sqlite/schema.sql
+var sqliteSchema string
+
+// sqliteColumns lists columns added to tables after their creation. The schema only
+// creates missing tables, so files created by older versions get these columns here.
+var sqliteColumns = []struct{ table, column, definition string }{
+	{"subscriptions", "billing_period_months", "INTEGER NOT NULL DEFAULT 1 CHECK (billing_period_months > 0)"},
+	{"subscriptions", "expired_at", "TIMESTAMP"},
+	{"subscriptions", "service_id", "TEXT REFERENCES services (id) ON DELETE SET NULL"},
+	{"subscriptions", "category", "TEXT NOT NULL DEFAULT ''"},
+}
+
+// sqliteBackfills fill the columns of sqliteColumns, keyed by table.column, right after
+// they are added to an older file.
+var sqliteBackfills = map[string]func(ctx context.Context, db *sqlx.DB) error{
+	"subscriptions.service_id": backfillServices,
+	"subscriptions.category":   backfillCategories,
+}
+
+// sqliteIndexes index the columns of sqliteColumns, which the schema cannot do before
+// they are added.
+const sqliteIndexes = `CREATE INDEX IF NOT EXISTS subscriptions_service_id_idx ON subscriptions (service_id);
+CREATE INDEX IF NOT EXISTS subscriptions_category_idx ON subscriptions (category);`
+
+// NewSQLite opens the database file at cfg.SQLite.Path and creates the schema if needed.
+// The path ":memory:" gives a private in-memory database.
+func NewSQLite(cfg config.DatabaseConfig, log *zap.Logger) (*sqlx.DB, error) {
+	path := cfg.SQLite.Path
+	if path != ":memory:" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %w", err)
+		}
+	}
+
+	dsn := fmt.Sprintf("file:%s?_time_format=sqlite&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)", path)
+	db, err := sqlx.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// SQLite allows a single writer; one connection also keeps ":memory:" databases shared.
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := db.ExecContext(ctx, sqliteSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+	for _, c := range sqliteColumns {
+		var exists bool
+		query := `SELECT COUNT(*) > 0 FROM pragma_table_info($1) WHERE name = $2`
+		if err := db.GetContext(ctx, &exists, query, c.table, c.column); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("failed to inspect %s: %w", c.table, err)
+		}
+		if exists {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, c.table, c.column, c.definition)); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("failed to add %s.%s: %w", c.table, c.column, err)
+		}
+		if backfill, ok := sqliteBackfills[c.table+"."+c.column]; ok {
+			if err := backfill(ctx, db); err != nil {
+				_ = db.Close()
+				return nil, fmt.Errorf("failed to backfill %s.%s: %w", c.table, c.column, err)
+			}
+		}
+	}
+	if _, err := db.ExecContext(ctx, sqliteIndexes); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+	log.Debug("sqlite schema ready", zap.String("path", path))
+
+	return db, nil
+}
+
+// backfillServices does what the create_services migration does on PostgreSQL: it adds
+// a catalog entry per normalized service name, named after its most used spelling, and
+// links the subscriptions to it.
+func backfillServices(ctx context.Context, db *sqlx.DB) error {
+	var spellings []struct {
+		Name string `db:"name"`
+		Uses int    `db:"uses"`
+	}
+	query := `SELECT trim(service_name) AS name, COUNT(*) AS uses FROM subscriptions GROUP BY 1 ORDER BY uses DESC, name`
+	if err := db.SelectContext(ctx, &spellings, query); err != nil {
+		return fmt.Errorf("failed to get service names: %w", err)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	ids := make(map[string]uuid.UUID)
+	for _, spelling := range spellings {
+		key := domain.NormalizeServiceName(spelling.Name)
+		if _, ok := ids[key]; !ok {
+			ids[key] = uuid.New()
+			query := `INSERT INTO services (id, name, name_key) VALUES ($1, $2, $3)`
+			if _, err := tx.ExecContext(ctx, query, ids[key], spelling.Name, key); err != nil {
+				return fmt.Errorf("failed to create service %q: %w", spelling.Name, err)
+			}
+		}
+		query := `UPDATE subscriptions SET service_id = $1, service_name = (SELECT name FROM services WHERE id = $1)
+			WHERE trim(service_name) = $2`
+		if _, err := tx.ExecContext(ctx, query, ids[key], spelling.Name); err != nil {
+			return fmt.Errorf("failed to link subscriptions of %q: %w", spelling.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// backfillCategories does what the add_subscription_labels migration does on PostgreSQL:
+// it normalizes the categories of the catalog and copies them to the linked subscriptions.
+func backfillCategories(ctx context.Context, db *sqlx.DB) error {
+	var services []struct {
+		ID       string `db:"id"`
+		Category string `db:"category"`
+	}
+	if err := db.SelectContext(ctx, &services, `SELECT id, category FROM services WHERE category <> ''`); err != nil {
+		return fmt.Errorf("failed to get service categories: %w", err)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, svc := range services {
+		category := domain.NormalizeLabel(svc.Category)
+		if _, err := tx.ExecContext(ctx, `UPDATE services SET category = $1 WHERE id = $2`, category, svc.ID); err != nil {
+			return fmt.Errorf("failed to normalize category of service %s: %w", svc.ID, err)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE subscriptions SET category = $1 WHERE service_id = $2`, category, svc.ID); err != nil {
+			return fmt.Errorf("failed to set category of service %s: %w", svc.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
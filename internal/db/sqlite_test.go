@@ -0,0 +1,121 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+	"tz/internal/config"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	_ "modernc.org/sqlite"
+)
+
+func TestNewSQLite_AddsMissingColumns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+
+	old, err := sqlx.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = old.Exec(`CREATE TABLE subscriptions (
+		id TEXT PRIMARY KEY, service_name TEXT NOT NULL, price INTEGER NOT NULL, user_id TEXT NOT NULL,
+		start_date DATE NOT NULL, end_date DATE, created_at TIMESTAMP, updated_at TIMESTAMP)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = old.Close()
+
+	for range 2 {
+		database, err := NewSQLite(config.DatabaseConfig{SQLite: config.SQLiteConfig{Path: path}}, zap.NewNop())
+		if err != nil {
+			t.Fatalf("NewSQLite() error = %v", err)
+		}
+		var columns int
+		err = database.Get(&columns, `SELECT COUNT(*) FROM pragma_table_info('subscriptions') WHERE name = 'billing_period_months'`)
+		_ = database.Close()
+		if err != nil || columns != 1 {
+			t.Fatalf("billing_period_months columns = %d, %v, want 1", columns, err)
+		}
+	}
+}
+
+func TestNewSQLite_BackfillsServices(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+
+	old, err := sqlx.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = old.Exec(`CREATE TABLE subscriptions (
+		id TEXT PRIMARY KEY, service_name TEXT NOT NULL, price INTEGER NOT NULL, user_id TEXT NOT NULL,
+		start_date DATE NOT NULL, end_date DATE, created_at TIMESTAMP, updated_at TIMESTAMP);
+		INSERT INTO subscriptions (id, service_name, price, user_id, start_date) VALUES
+			('1', 'Netflix', 1, 'u', '2025-01-01'), ('2', 'netflix', 1, 'u', '2025-01-01'),
+			('3', 'Netflix ', 1, 'u', '2025-01-01'), ('4', 'Spotify', 1, 'u', '2025-01-01')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = old.Close()
+
+	database, err := NewSQLite(config.DatabaseConfig{SQLite: config.SQLiteConfig{Path: path}}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewSQLite() error = %v", err)
+	}
+	defer database.Close()
+
+	var services []string
+	if err := database.Select(&services, `SELECT name FROM services ORDER BY name`); err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 2 || services[0] != "Netflix" || services[1] != "Spotify" {
+		t.Errorf("services = %q, want [Netflix Spotify]", services)
+	}
+
+	var unlinked, distinct int
+	err = database.Get(&unlinked, `SELECT COUNT(*) FROM subscriptions WHERE service_id IS NULL OR service_name NOT IN ('Netflix', 'Spotify')`)
+	if err != nil || unlinked != 0 {
+		t.Errorf("unlinked subscriptions = %d, %v, want 0", unlinked, err)
+	}
+	err = database.Get(&distinct, `SELECT COUNT(DISTINCT service_id) FROM subscriptions`)
+	if err != nil || distinct != 2 {
+		t.Errorf("distinct service ids = %d, %v, want 2", distinct, err)
+	}
+}
+
+func TestNewSQLite_BackfillsCategories(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+
+	old, err := sqlx.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = old.Exec(`CREATE TABLE services (
+		id TEXT PRIMARY KEY, name TEXT NOT NULL, name_key TEXT NOT NULL UNIQUE, category TEXT NOT NULL DEFAULT '',
+		url TEXT NOT NULL DEFAULT '', default_price INTEGER NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP);
+		CREATE TABLE subscriptions (
+		id TEXT PRIMARY KEY, service_id TEXT REFERENCES services (id), service_name TEXT NOT NULL, price INTEGER NOT NULL,
+		user_id TEXT NOT NULL, start_date DATE NOT NULL, end_date DATE, billing_period_months INTEGER NOT NULL DEFAULT 1,
+		expired_at TIMESTAMP, created_at TIMESTAMP, updated_at TIMESTAMP);
+		INSERT INTO services (id, name, name_key, category) VALUES ('s1', 'Netflix', 'netflix', ' Video  Streaming');
+		INSERT INTO subscriptions (id, service_id, service_name, price, user_id, start_date) VALUES
+			('1', 's1', 'Netflix', 1, 'u', '2025-01-01'), ('2', NULL, 'Spotify', 1, 'u', '2025-01-01')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = old.Close()
+
+	database, err := NewSQLite(config.DatabaseConfig{SQLite: config.SQLiteConfig{Path: path}}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewSQLite() error = %v", err)
+	}
+	defer database.Close()
+
+	var categories []string
+	if err := database.Select(&categories, `SELECT category FROM subscriptions ORDER BY id`); err != nil {
+		t.Fatal(err)
+	}
+	if len(categories) != 2 || categories[0] != "video streaming" || categories[1] != "" {
+		t.Errorf("categories = %q, want [video streaming ]", categories)
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Scope string
+
+const (
+	ScopeSubscriptionsRead  Scope = "subscriptions:read"
+	ScopeSubscriptionsWrite Scope = "subscriptions:write"
+	ScopeCostRead           Scope = "cost:read"
+	ScopeAdmin              Scope = "admin"
+)
+
+var AllScopes = []Scope{
+	ScopeSubscriptionsRead,
+	ScopeSubscriptionsWrite,
+	ScopeCostRead,
+	ScopeAdmin,
+}
+
+func ParseScope(s string) (Scope, error) {
+	for _, scope := range AllScopes {
+		if string(scope) == s {
+			return scope, nil
+		}
+	}
+	return "", ErrInvalidScope
+}
+
+type APIKey struct {
+	ID         uuid.UUID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []Scope
+	Roles      []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (k APIKey) Principal() Principal {
+	return Principal{
+		ID:     k.ID.String(),
+		Kind:   PrincipalAPIKey,
+		Name:   k.Name,
+		Scopes: k.Scopes,
+		Roles:  k.Roles,
+	}
+}
+
+func (k APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
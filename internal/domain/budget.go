@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BudgetPeriod is the calendar span a budget amount applies to.
+type BudgetPeriod string
+
+const (
+	BudgetPeriodMonth BudgetPeriod = "month"
+	BudgetPeriodYear  BudgetPeriod = "year"
+)
+
+func ParseBudgetPeriod(s string) (BudgetPeriod, error) {
+	switch BudgetPeriod(s) {
+	case BudgetPeriodMonth, BudgetPeriodYear:
+		return BudgetPeriod(s), nil
+	default:
+		return "", fmt.Errorf("%w: unknown period %q, expected month or year", ErrInvalidBudget, s)
+	}
+}
+
+// Start returns the start of the period containing t.
+func (p BudgetPeriod) Start(t time.Time) time.Time {
+	if p == BudgetPeriodYear {
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// End returns the start of the period following the one that starts at start.
+func (p BudgetPeriod) End(start time.Time) time.Time {
+	if p == BudgetPeriodYear {
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// Budget caps the spend of a user per period. An empty ServiceNames covers all services.
+type Budget struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	Period       BudgetPeriod
+	Amount       int
+	ServiceNames []string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type UpdateBudget struct {
+	Period       *BudgetPeriod
+	Amount       *int
+	ServiceNames *[]string
+}
+
+// AlertKind tells whether an alert was raised by the spend so far or by the spend
+// projected for the whole period.
+type AlertKind string
+
+const (
+	AlertKindActual   AlertKind = "actual"
+	AlertKindForecast AlertKind = "forecast"
+)
+
+// BudgetThresholds are the shares of a budget, in percent, that raise an alert.
+var BudgetThresholds = []int{80, 100}
+
+// BudgetAlert records that spend crossed Threshold percent of a budget in the period
+// starting at PeriodStart. Each combination of period, threshold and kind is raised once.
+type BudgetAlert struct {
+	ID          uuid.UUID `db:"id"`
+	BudgetID    uuid.UUID `db:"budget_id"`
+	UserID      uuid.UUID `db:"user_id"`
+	PeriodStart time.Time `db:"period_start"`
+	Threshold   int       `db:"threshold"`
+	Kind        AlertKind `db:"kind"`
+	Spend       int       `db:"spend"`
+	Amount      int       `db:"amount"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// Spend is the cost of the subscriptions of a user in one budget period.
+type Spend struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	// Actual is the cost accrued until now, Forecast the cost of the whole period.
+	Actual   int
+	Forecast int
+}
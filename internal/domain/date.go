@@ -0,0 +1,25 @@
+package domain
+
+import "fmt"
+
+// DateFormat selects how subscription dates are rendered in responses.
+type DateFormat string
+
+const (
+	// DateFormatMonth renders MM-YYYY, dropping the day. It is the default.
+	DateFormatMonth DateFormat = "month"
+	// DateFormatDay renders ISO 8601 YYYY-MM-DD.
+	DateFormatDay DateFormat = "day"
+)
+
+// ParseDateFormat accepts "month" and "day". An empty string means DateFormatMonth.
+func ParseDateFormat(s string) (DateFormat, error) {
+	switch DateFormat(s) {
+	case "", DateFormatMonth:
+		return DateFormatMonth, nil
+	case DateFormatDay:
+		return DateFormatDay, nil
+	default:
+		return "", fmt.Errorf("%w: unknown date format %q, expected month or day", ErrInvalidDateFormat, s)
+	}
+}
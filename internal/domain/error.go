@@ -5,5 +5,24 @@ import "errors"
 var (
 	ErrNotFound          = errors.New("subscription not found")
 	ErrInvalidDate       = errors.New("invalid date")
-	ErrInvalidDateFormat = errors.New("invalid date format, expected MM-YYYY")
+	ErrInvalidDateFormat = errors.New("invalid date format, expected MM-YYYY or YYYY-MM-DD")
+	ErrAPIKeyNotFound    = errors.New("api key not found")
+	ErrInvalidScope      = errors.New("invalid scope")
+	ErrUnauthorized      = errors.New("unauthorized")
+	ErrForbidden         = errors.New("forbidden")
+	ErrInvalidCostPolicy = errors.New("invalid cost policy")
+	ErrInvalidTimeZone   = errors.New("invalid time zone")
+
+	ErrUserSettingsNotFound = errors.New("user settings not found")
+	ErrPriceChangeNotFound  = errors.New("price change not found")
+	ErrBudgetNotFound       = errors.New("budget not found")
+	ErrInvalidBudget        = errors.New("invalid budget")
+	ErrWebhookNotFound      = errors.New("webhook not found")
+	ErrInvalidWebhook       = errors.New("invalid webhook")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrDeliveryDelivered    = errors.New("webhook delivery is already delivered")
+	ErrInvalidEventStream   = errors.New("invalid event stream request")
+	ErrServiceNotFound      = errors.New("service not found")
+	ErrInvalidService       = errors.New("invalid service")
+	ErrInvalidLabel         = errors.New("invalid category or tag")
 )
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxLabelLength bounds the length of categories and tags, in characters.
+	MaxLabelLength = 50
+	// MaxTags bounds the number of tags of a subscription.
+	MaxTags = 20
+)
+
+// NormalizeLabel folds case and whitespace of a category or tag, so "Work", "work" and
+// " work " are the same tag.
+func NormalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+// NormalizeLabels normalizes the labels and drops empty and repeated ones, keeping the
+// order of the rest. It returns nil when no label is left. A label longer than MaxLabelLength is an ErrInvalidLabel.
+func NormalizeLabels(labels []string) ([]string, error) {
+	var out []string
+	for _, label := range labels {
+		label = NormalizeLabel(label)
+		if label == "" || slices.Contains(out, label) {
+			continue
+		}
+		if utf8.RuneCountInString(label) > MaxLabelLength {
+			return nil, fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidLabel, label, MaxLabelLength)
+		}
+		out = append(out, label)
+	}
+	return out, nil
+}
@@ -0,0 +1,35 @@
+package domain
+
+type Permission string
+
+const (
+	PermSubscriptionsRead   Permission = "subscriptions:read"
+	PermSubscriptionsCreate Permission = "subscriptions:create"
+	PermSubscriptionsUpdate Permission = "subscriptions:update"
+	PermSubscriptionsDelete Permission = "subscriptions:delete"
+	PermCostRead            Permission = "cost:read"
+	PermUserSettingsRead    Permission = "users:read"
+	PermUserSettingsUpdate  Permission = "users:update"
+	PermBudgetsRead         Permission = "budgets:read"
+	PermBudgetsWrite        Permission = "budgets:write"
+	PermServicesRead        Permission = "services:read"
+	PermServicesWrite       Permission = "services:write"
+	PermWebhooksManage      Permission = "webhooks:manage"
+	PermAPIKeysManage       Permission = "apikeys:manage"
+)
+
+// Scope returns the API key scope a caller must hold before the permission is evaluated.
+func (p Permission) Scope() Scope {
+	switch p {
+	case PermSubscriptionsRead, PermUserSettingsRead, PermServicesRead:
+		return ScopeSubscriptionsRead
+	case PermSubscriptionsCreate, PermSubscriptionsUpdate, PermSubscriptionsDelete, PermUserSettingsUpdate:
+		return ScopeSubscriptionsWrite
+	case PermCostRead, PermBudgetsRead:
+		return ScopeCostRead
+	case PermBudgetsWrite:
+		return ScopeSubscriptionsWrite
+	default:
+		return ScopeAdmin
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+const (
+	PrincipalAPIKey     = "api_key"
+	PrincipalAdminToken = "admin_token"
+
+	RoleAdmin = "admin"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	ID     string
+	Kind   string
+	Name   string
+	Scopes []Scope
+	Roles  []string
+}
+
+func (p Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Service is an entry of the services catalog. Subscriptions resolve their service name
+// through Name and Aliases, compared by NormalizeServiceName.
+type Service struct {
+	ID      uuid.UUID
+	Name    string
+	Aliases []string
+	// Category is the NormalizeLabel key given to new subscriptions of the service.
+	Category string
+	URL      string
+	// DefaultPrice is used by new subscriptions created without a price, 0 for none.
+	DefaultPrice int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type UpdateService struct {
+	Name         *string
+	Aliases      *[]string
+	Category     *string
+	URL          *string
+	DefaultPrice *int
+}
+
+type ServiceFilter struct {
+	Category *string
+}
+
+// NormalizeServiceName folds case and whitespace, so "Netflix", "netflix" and
+// " Netflix " name the same service.
+func NormalizeServiceName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Named reports whether name is the name or an alias of the service.
+func (s Service) Named(name string) bool {
+	key := NormalizeServiceName(name)
+	if NormalizeServiceName(s.Name) == key {
+		return true
+	}
+	for _, alias := range s.Aliases {
+		if NormalizeServiceName(alias) == key {
+			return true
+		}
+	}
+	return false
+}
@@ -7,31 +7,85 @@ import (
 )
 
 type Subscription struct {
-	ID          uuid.UUID  `db:"id"`
+	ID uuid.UUID `db:"id"`
+	// ServiceID is the catalog entry of ServiceName, nil for services not in the catalog.
+	ServiceID   *uuid.UUID `db:"service_id"`
 	ServiceName string     `db:"service_name"`
-	Price       int        `db:"price"`
-	UserID      uuid.UUID  `db:"user_id"`
-	StartDate   time.Time  `db:"start_date"`
-	EndDate     *time.Time `db:"end_date"`
-	CreatedAt   time.Time  `db:"created_at"`
-	UpdatedAt   time.Time  `db:"updated_at"`
+	// Category groups subscriptions for reporting, "" for none. It is a NormalizeLabel key.
+	Category string `db:"category"`
+	// Tags are the NormalizeLabel keys of the tags of the subscription in sorted order.
+	Tags []string `db:"-"`
+	// Price is charged once per billing period.
+	Price     int        `db:"price"`
+	UserID    uuid.UUID  `db:"user_id"`
+	StartDate time.Time  `db:"start_date"`
+	EndDate   *time.Time `db:"end_date"`
+	// BillingPeriod is the number of months between charges, 1 for monthly plans.
+	BillingPeriod int `db:"billing_period_months"`
+	// ExpiredAt is set by the expiry job once EndDate has passed.
+	ExpiredAt *time.Time `db:"expired_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
 }
 
 type UpdateSubscription struct {
-	ServiceName *string    `db:"service_name"`
-	Price       *int       `db:"price"`
-	StartDate   *time.Time `db:"start_date"`
-	EndDate     *time.Time `db:"end_date"`
+	// ServiceName is written together with ServiceID, a nil ServiceID clears it.
+	ServiceName   *string    `db:"service_name"`
+	ServiceID     *uuid.UUID `db:"service_id"`
+	Category      *string    `db:"category"`
+	Price         *int       `db:"price"`
+	StartDate     *time.Time `db:"start_date"`
+	EndDate       *time.Time `db:"end_date"`
+	BillingPeriod *int       `db:"billing_period_months"`
+	// AddTags and RemoveTags change the tags of the subscription, leaving the others as
+	// they are. A tag in both is removed.
+	AddTags    []string
+	RemoveTags []string
+}
+
+// PriceChange schedules a new price for the charges of a subscription from the
+// month of EffectiveFrom on.
+type PriceChange struct {
+	ID             uuid.UUID `db:"id"`
+	SubscriptionID uuid.UUID `db:"subscription_id"`
+	Price          int       `db:"price"`
+	EffectiveFrom  time.Time `db:"effective_from"`
+	CreatedAt      time.Time `db:"created_at"`
 }
 
 type SubscriptionFilter struct {
-	UserID      *uuid.UUID
+	UserID *uuid.UUID
+	// ServiceID selects the subscriptions of a catalog service; ServiceName then is nil.
+	ServiceID   *uuid.UUID
 	ServiceName *string
-	Limit       int
-	Offset      int
+	Category    *string
+	// Tags selects the subscriptions having all of them.
+	Tags   []string
+	Limit  int
+	Offset int
 }
 
 type CostRequest struct {
-	UserID      *uuid.UUID
+	UserID *uuid.UUID
+	// ServiceID selects the subscriptions of a catalog service; ServiceName then is nil.
+	ServiceID   *uuid.UUID
 	ServiceName *string
+	Category    *string
+	// Tags selects the subscriptions having all of them.
+	Tags []string
+}
+
+// UpcomingFilter selects the subscriptions active at some point of [From, To).
+type UpcomingFilter struct {
+	UserID *uuid.UUID
+	// ServiceID selects the subscriptions of a catalog service; ServiceName then is nil.
+	ServiceID   *uuid.UUID
+	ServiceName *string
+	From        time.Time
+	To          time.Time
+}
+
+type SubscriptionStats struct {
+	Active        int `db:"active"`
+	DistinctUsers int `db:"distinct_users"`
 }
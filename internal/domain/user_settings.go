@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserSettings are per-user preferences. TimeZone is an IANA name such as "Asia/Vladivostok".
+type UserSettings struct {
+	UserID    uuid.UUID
+	TimeZone  string
+	UpdatedAt time.Time
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType names an event delivered to webhooks: a change in the lifecycle of a
+// subscription or a budget alert.
+type EventType string
+
+const (
+	EventCreated      EventType = "created"
+	EventUpdated      EventType = "updated"
+	EventDeleted      EventType = "deleted"
+	EventExpired      EventType = "expired"
+	EventPriceChanged EventType = "price_changed"
+	EventBudgetAlert  EventType = "budget.alert"
+)
+
+// EventTypes lists every event type in the order they are documented.
+var EventTypes = []EventType{EventCreated, EventUpdated, EventDeleted, EventExpired, EventPriceChanged, EventBudgetAlert}
+
+func ParseEventType(s string) (EventType, error) {
+	for _, t := range EventTypes {
+		if string(t) == s {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown event type %q", ErrInvalidWebhook, s)
+}
+
+// Event is a subscription change or a budget alert written to the outbox in the
+// transaction of the change. SubscriptionID is uuid.Nil for budget alerts. Payload is
+// the JSON body delivered to webhooks. DispatchedAt is set once deliveries were created
+// for every webhook listening to the type.
+type Event struct {
+	// Seq orders the events as they were written.
+	Seq            int64      `db:"seq"`
+	ID             uuid.UUID  `db:"id"`
+	Type           EventType  `db:"type"`
+	SubscriptionID uuid.UUID  `db:"subscription_id"`
+	Payload        []byte     `db:"payload"`
+	CreatedAt      time.Time  `db:"created_at"`
+	DispatchedAt   *time.Time `db:"dispatched_at"`
+}
+
+// Webhook receives the events of the listed types. Secret signs the deliveries.
+type Webhook struct {
+	ID         uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Accepts reports whether the webhook listens to events of type t.
+func (w Webhook) Accepts(t EventType) bool {
+	for _, et := range w.EventTypes {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
+
+type UpdateWebhook struct {
+	URL        *string
+	Secret     *string
+	EventTypes *[]EventType
+}
+
+// DeliveryStatus is the state of a webhook delivery. Pending deliveries are retried
+// until they succeed or run out of attempts and become dead.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryDead      DeliveryStatus = "dead"
+)
+
+// WebhookDelivery is an event on its way to one webhook.
+type WebhookDelivery struct {
+	ID        uuid.UUID      `db:"id"`
+	WebhookID uuid.UUID      `db:"webhook_id"`
+	EventID   uuid.UUID      `db:"event_id"`
+	EventType EventType      `db:"event_type"`
+	Payload   []byte         `db:"payload"`
+	Status    DeliveryStatus `db:"status"`
+	Attempts  int            `db:"attempts"`
+	// NextAttemptAt is when a pending delivery is due.
+	NextAttemptAt time.Time `db:"next_attempt_at"`
+	// LastStatusCode is the HTTP status of the last attempt, nil when no response came.
+	LastStatusCode *int       `db:"last_status_code"`
+	LastError      string     `db:"last_error"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+type DeliveryFilter struct {
+	WebhookID uuid.UUID
+	Status    *DeliveryStatus
+	Limit     int
+}
@@ -0,0 +1,31 @@
+package dto
+
+// CreateAPIKeyRequest represents the request to issue an API key.
+// @Description CreateAPIKeyRequest
+type CreateAPIKeyRequest struct {
+	Name      string   `json:"name" validate:"required" example:"billing-batch"`
+	Scopes    []string `json:"scopes" validate:"required,min=1" example:"subscriptions:read,cost:read"`
+	Roles     []string `json:"roles" example:"finance"`
+	ExpiresAt *string  `json:"expires_at" example:"2026-01-01T00:00:00Z"`
+}
+
+// APIKeyOutput represents an API key without its secret.
+// @Description APIKeyOutput
+type APIKeyOutput struct {
+	ID         string   `json:"id" example:"0b7e6c2a-3f7e-4a55-9d0b-0c2f0a9b8f11"`
+	Name       string   `json:"name" example:"billing-batch"`
+	Prefix     string   `json:"prefix" example:"tzk_3f9a1c2b"`
+	Scopes     []string `json:"scopes" example:"subscriptions:read,cost:read"`
+	Roles      []string `json:"roles" example:"finance"`
+	ExpiresAt  *string  `json:"expires_at" example:"2026-01-01T00:00:00Z"`
+	LastUsedAt *string  `json:"last_used_at" example:"2025-10-20T10:00:00Z"`
+	RevokedAt  *string  `json:"revoked_at"`
+	CreatedAt  string   `json:"created_at" example:"2025-10-19T10:00:00Z"`
+}
+
+// IssuedAPIKeyOutput is returned once on issue and contains the plain key.
+// @Description IssuedAPIKeyOutput
+type IssuedAPIKeyOutput struct {
+	APIKeyOutput
+	Key string `json:"key" example:"tzk_3f9a1c2b5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f70"`
+}
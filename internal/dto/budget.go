@@ -0,0 +1,53 @@
+package dto
+
+// CreateBudgetRequest caps the spend of a user per period.
+// @Description CreateBudgetRequest
+type CreateBudgetRequest struct {
+	UserID string `json:"user_id" validate:"required" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	// Period is month or year, month when empty.
+	Period string `json:"period" validate:"omitempty,oneof=month year" example:"month"`
+	Amount int    `json:"amount" validate:"required,min=1" example:"2000"`
+	// ServiceNames limits the budget to these services. Empty covers all services.
+	ServiceNames []string `json:"service_names" example:"Yandex Plus,Spotify Premium"`
+}
+
+// UpdateBudgetRequest represents partial budget update fields.
+// @Description UpdateBudgetRequest
+type UpdateBudgetRequest struct {
+	Period       *string   `json:"period" validate:"omitempty,oneof=month year" example:"year"`
+	Amount       *int      `json:"amount" validate:"omitempty,min=1" example:"24000"`
+	ServiceNames *[]string `json:"service_names" example:"Yandex Plus"`
+}
+
+type BudgetFilter struct {
+	UserID *string `form:"user_id"`
+}
+
+// BudgetOutput represents a budget.
+// @Description BudgetOutput
+type BudgetOutput struct {
+	ID           string   `json:"id" example:"3f9c2a71-8d4e-4b6a-a1c5-0e7d9b2f4a68"`
+	UserID       string   `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	Period       string   `json:"period" example:"month"`
+	Amount       int      `json:"amount" example:"2000"`
+	ServiceNames []string `json:"service_names" example:"Yandex Plus,Spotify Premium"`
+	CreatedAt    string   `json:"created_at" example:"2025-11-05T10:00:00Z"`
+	UpdatedAt    string   `json:"updated_at" example:"2025-11-05T10:00:00Z"`
+}
+
+// BudgetAlertOutput reports that spend crossed a threshold of a budget.
+// @Description BudgetAlertOutput
+type BudgetAlertOutput struct {
+	ID       string `json:"id" example:"b7e1d2c3-4a5f-4e6d-8c9b-0a1f2e3d4c5b"`
+	BudgetID string `json:"budget_id" example:"3f9c2a71-8d4e-4b6a-a1c5-0e7d9b2f4a68"`
+	UserID   string `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	// PeriodStart is the first day of the budget period the alert belongs to.
+	PeriodStart string `json:"period_start" example:"11-2025"`
+	// Threshold is the crossed share of the budget in percent, 80 or 100.
+	Threshold int `json:"threshold" example:"80"`
+	// Kind is actual for the spend so far, forecast for the spend projected for the period.
+	Kind      string `json:"kind" example:"forecast"`
+	Spend     int    `json:"spend" example:"1700"`
+	Amount    int    `json:"amount" example:"2000"`
+	CreatedAt string `json:"created_at" example:"2025-11-05T10:00:00Z"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+// CreateServiceRequest adds a service to the catalog.
+// @Description CreateServiceRequest
+type CreateServiceRequest struct {
+	Name string `json:"name" validate:"required" example:"Netflix"`
+	// Aliases are other spellings resolved to the service, compared without case and extra spaces.
+	Aliases []string `json:"aliases" example:"Нетфликс,Netflix Premium"`
+	// Category is given to new subscriptions of the service, lowercased.
+	Category string `json:"category" validate:"max=50" example:"streaming"`
+	URL      string `json:"url" validate:"omitempty,url" example:"https://www.netflix.com"`
+	// DefaultPrice is the price of subscriptions created without one, 0 for none.
+	DefaultPrice int `json:"default_price" validate:"min=0" example:"799"`
+}
+
+// UpdateServiceRequest represents partial service update fields. Aliases replace the current ones.
+// @Description UpdateServiceRequest
+type UpdateServiceRequest struct {
+	Name         *string   `json:"name" validate:"omitempty,min=1" example:"Netflix"`
+	Aliases      *[]string `json:"aliases" example:"Нетфликс"`
+	Category     *string   `json:"category" validate:"omitempty,max=50" example:"streaming"`
+	URL          *string   `json:"url" validate:"omitempty,url" example:"https://www.netflix.com"`
+	DefaultPrice *int      `json:"default_price" validate:"omitempty,min=0" example:"899"`
+}
+
+type ServiceFilter struct {
+	Category *string `form:"category"`
+}
+
+// ServiceOutput represents a service of the catalog.
+// @Description ServiceOutput
+type ServiceOutput struct {
+	ID           string   `json:"id" example:"0b6f3c2e-9a4d-4f1e-8c7b-2d5a6e9f1c3b"`
+	Name         string   `json:"name" example:"Netflix"`
+	Aliases      []string `json:"aliases" example:"Нетфликс,Netflix Premium"`
+	Category     string   `json:"category" example:"streaming"`
+	URL          string   `json:"url" example:"https://www.netflix.com"`
+	DefaultPrice int      `json:"default_price" example:"799"`
+	CreatedAt    string   `json:"created_at" example:"2025-11-10T10:00:00Z"`
+	UpdatedAt    string   `json:"updated_at" example:"2025-11-10T10:00:00Z"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+// CreatePriceChangeRequest schedules a new subscription price.
+// @Description CreatePriceChangeRequest
+type CreatePriceChangeRequest struct {
+	Price         int    `json:"price" validate:"required,min=1" example:"499"`
+	EffectiveFrom string `json:"effective_from" validate:"required" example:"2026-01-01"`
+}
+
+// PriceChangeOutput represents a scheduled price change.
+// @Description PriceChangeOutput
+type PriceChangeOutput struct {
+	ID             string `json:"id" example:"7d0f3c1e-5b8a-4f2e-9c6d-1a2b3c4d5e6f"`
+	SubscriptionID string `json:"subscription_id" example:"a1b2c3d4-e5f6-7890-g1h2-i3j4k5l6m7n8"`
+	Price          int    `json:"price" example:"499"`
+	EffectiveFrom  string `json:"effective_from" example:"01-2026"`
+	CreatedAt      string `json:"created_at" example:"2025-11-04T10:00:00Z"`
+}
+
+type ForecastRequest struct {
+	UserID      *string `form:"user_id"`
+	ServiceName *string `form:"service_name"`
+	Months      int     `form:"months" validate:"omitempty,min=1,max=60"`
+	TimeZone    *string `form:"tz"`
+}
+
+// ForecastMonth is the projected spend of one month.
+// @Description ForecastMonth
+type ForecastMonth struct {
+	Month string `json:"month" example:"11-2025"`
+	Total int    `json:"total" example:"1399"`
+}
+
+// ForecastOutput is the projected spend of the matched subscriptions, starting with
+// the current month.
+// @Description ForecastOutput
+type ForecastOutput struct {
+	Months   []ForecastMonth `json:"months"`
+	Total    int             `json:"total" example:"16788"`
+	TimeZone string          `json:"time_zone" example:"UTC"`
+}
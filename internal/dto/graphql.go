@@ -0,0 +1,31 @@
+package dto
+
+// GraphQLRequest is a query posted to /graphql.
+// @Description GraphQLRequest
+type GraphQLRequest struct {
+	Query         string         `json:"query" validate:"required" example:"{ subscriptions(pageSize: 5) { total items { serviceName price } } }"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// GraphQLResponse is the result of a query. Data is absent when the query was rejected
+// before it ran.
+// @Description GraphQLResponse
+type GraphQLResponse struct {
+	Data   any            `json:"data,omitempty"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+// GraphQLError describes an error of a query. Extensions.code classifies it:
+// BAD_USER_INPUT, NOT_FOUND, FORBIDDEN, QUERY_TOO_DEEP, QUERY_TOO_COMPLEX or INTERNAL.
+type GraphQLError struct {
+	Message    string            `json:"message" example:"subscription not found"`
+	Locations  []GraphQLLocation `json:"locations,omitempty"`
+	Path       []any             `json:"path,omitempty"`
+	Extensions map[string]any    `json:"extensions,omitempty"`
+}
+
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
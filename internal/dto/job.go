@@ -0,0 +1,18 @@
+package dto
+
+// JobStatusOutput describes a scheduled job as seen by this replica.
+// @Description JobStatusOutput
+type JobStatusOutput struct {
+	Name      string  `json:"name" example:"evaluate_budgets"`
+	Spec      string  `json:"spec" example:"*/15 * * * *"`
+	NextRunAt string  `json:"next_run_at" example:"2025-11-07T10:15:00Z"`
+	Running   bool    `json:"running" example:"false"`
+	LastRunAt *string `json:"last_run_at" example:"2025-11-07T10:00:00Z"`
+	// LastDurationMs is the duration of the last run in milliseconds.
+	LastDurationMs int64  `json:"last_duration_ms" example:"42"`
+	LastError      string `json:"last_error,omitempty"`
+	Runs           int    `json:"runs" example:"12"`
+	Failures       int    `json:"failures" example:"0"`
+	// Skipped counts the runs another replica performed while holding the job lock.
+	Skipped int `json:"skipped" example:"3"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// EventStreamFilter narrows the event stream. Empty fields match every event.
+type EventStreamFilter struct {
+	UserID      *string `form:"user_id"`
+	ServiceName *string `form:"service_name"`
+}
+
+// StreamEvent is one server-sent event: the sequence number of the event in the log is
+// its ID, the event type its name and the SubscriptionEvent JSON its data.
+type StreamEvent struct {
+	ID   int64
+	Type string
+	Data []byte
+}
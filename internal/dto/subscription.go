@@ -3,47 +3,117 @@ package dto
 // SubscriptionOutput represents a subscription response.
 // @Description SubscriptionOutput
 type SubscriptionOutput struct {
-	ID          string  `json:"id" example:"a1b2c3d4-e5f6-7890-g1h2-i3j4k5l6m7n8"`
+	ID string `json:"id" example:"a1b2c3d4-e5f6-7890-g1h2-i3j4k5l6m7n8"`
+	// ServiceID is the catalog entry of the service, null for services not in the catalog.
+	ServiceID   *string `json:"service_id" example:"0b6f3c2e-9a4d-4f1e-8c7b-2d5a6e9f1c3b"`
 	ServiceName string  `json:"service_name" example:"Yandex Plus"`
-	Price       int     `json:"price" example:"400"`
-	UserID      string  `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
-	StartDate   string  `json:"start_date" example:"07-2025"`
-	EndDate     *string `json:"end_date" example:"12-2025"`
-	CreatedAt   string  `json:"created_at" example:"2025-04-05T10:00:00Z"`
-	UpdatedAt   string  `json:"updated_at" example:"2025-04-05T10:00:00Z"`
+	// Category is "" for subscriptions without one.
+	Category  string   `json:"category" example:"streaming"`
+	Tags      []string `json:"tags" example:"family,work"`
+	Price     int      `json:"price" example:"400"`
+	UserID    string   `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	StartDate string   `json:"start_date" example:"07-2025"`
+	EndDate   *string  `json:"end_date" example:"12-2025"`
+	// BillingPeriodMonths is the number of months between charges of Price.
+	BillingPeriodMonths int `json:"billing_period_months" example:"1"`
+	// ExpiredAt is when the subscription was marked as expired after its end date, null before.
+	ExpiredAt *string `json:"expired_at" example:"2026-01-01 00:05:00"`
+	CreatedAt string  `json:"created_at" example:"2025-04-05T10:00:00Z"`
+	UpdatedAt string  `json:"updated_at" example:"2025-04-05T10:00:00Z"`
 }
 
 // CreateSubscriptionRequest represents the request to create a subscription.
 // @Description CreateSubscriptionRequest
 type CreateSubscriptionRequest struct {
-	ServiceName string  `json:"service_name" validate:"required" example:"Yandex Plus"`
-	Price       int     `json:"price" validate:"required,min=0" example:"400"`
-	UserID      string  `json:"user_id" validate:"required" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
-	StartDate   string  `json:"start_date" validate:"required" example:"07-2025"`
-	EndDate     *string `json:"end_date" example:"12-2025"`
+	// ServiceID picks a service of the catalog. Without it ServiceName is resolved through
+	// the names and aliases of the catalog, and kept trimmed when no service matches.
+	ServiceID   *string `json:"service_id" validate:"omitempty,uuid" example:"0b6f3c2e-9a4d-4f1e-8c7b-2d5a6e9f1c3b"`
+	ServiceName string  `json:"service_name" validate:"required_without=ServiceID" example:"Yandex Plus"`
+	// Category defaults to the category of the catalog service. Categories and tags are
+	// compared without case and extra spaces and returned lowercased.
+	Category *string  `json:"category" validate:"omitempty,max=50" example:"streaming"`
+	Tags     []string `json:"tags" validate:"max=20,dive,max=50" example:"family,work"`
+	// Price defaults to the default price of the catalog service when omitted.
+	Price     int     `json:"price" validate:"min=0" example:"400"`
+	UserID    string  `json:"user_id" validate:"required" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	StartDate string  `json:"start_date" validate:"required" example:"07-2025"`
+	EndDate   *string `json:"end_date" example:"12-2025"`
+	// BillingPeriodMonths defaults to 1, a monthly plan. Use 12 for yearly plans.
+	BillingPeriodMonths *int `json:"billing_period_months" validate:"omitempty,min=1,max=120" example:"1"`
 }
 
 // UpdateSubscriptionRequest represents partial update fields.
 // @Description UpdateSubscriptionRequest
 type UpdateSubscriptionRequest struct {
+	// ServiceID and ServiceName change the service the way they set it on creation.
+	ServiceID   *string `json:"service_id" validate:"omitempty,uuid" example:"0b6f3c2e-9a4d-4f1e-8c7b-2d5a6e9f1c3b"`
 	ServiceName *string `json:"service_name" example:"Spotify Premium"`
-	Price       *int    `json:"price" validate:"omitempty,min=0" example:"599"`
-	StartDate   *string `json:"start_date" example:"01-2026"`
-	EndDate     *string `json:"end_date" example:"06-2026"`
+	// Category replaces the category, "" removes it.
+	Category *string `json:"category" validate:"omitempty,max=50" example:"music"`
+	// AddTags and RemoveTags change the tags, leaving the others as they are. A tag in
+	// both is removed.
+	AddTags    []string `json:"add_tags" validate:"max=20,dive,max=50" example:"work"`
+	RemoveTags []string `json:"remove_tags" validate:"max=20,dive,max=50" example:"family"`
+	Price      *int     `json:"price" validate:"omitempty,min=0" example:"599"`
+	StartDate  *string  `json:"start_date" example:"01-2026"`
+	EndDate    *string  `json:"end_date" example:"2026-06-15"`
+	// BillingPeriodMonths is the number of months between charges.
+	BillingPeriodMonths *int `json:"billing_period_months" validate:"omitempty,min=1,max=120" example:"12"`
 }
 
 type SubscriptionFilter struct {
 	UserID      *string `form:"user_id"`
 	ServiceName *string `form:"service_name"`
-	Page        int     `form:"page"`
-	PageSize    int     `form:"page_size"`
+	Category    *string `form:"category"`
+	// Tags selects the subscriptions having all of them.
+	Tags     []string `form:"tag"`
+	Page     int      `form:"page"`
+	PageSize int      `form:"page_size"`
 }
 
 type CostRequest struct {
-	UserID      *string `form:"user_id"`
-	ServiceName *string `form:"service_name"`
-	From        *string `form:"from"`
-	To          *string `form:"to"`
+	UserID            *string `form:"user_id"`
+	ServiceName       *string `form:"service_name"`
+	From              *string `form:"from"`
+	To                *string `form:"to"`
+	EndMonthInclusive *bool   `form:"end_month_inclusive"`
+	Proration         *string `form:"proration" validate:"omitempty,oneof=month day"`
+	TimeZone          *string `form:"tz"`
+	Category          *string `form:"category"`
+	// Tags selects the subscriptions having all of them.
+	Tags    []string `form:"tag"`
+	GroupBy *string  `form:"group_by" validate:"omitempty,oneof=category tag"`
+}
+
+// CostPolicyOutput describes how a cost was calculated.
+// @Description CostPolicyOutput
+type CostPolicyOutput struct {
+	EndMonthInclusive bool   `json:"end_month_inclusive" example:"false"`
+	Proration         string `json:"proration" example:"month" enums:"month,day"`
+	TimeZone          string `json:"time_zone" example:"Asia/Vladivostok"`
+}
+
+// CostGroupOutput is the cost of the matched subscriptions with a category or tag.
+// @Description CostGroupOutput
+type CostGroupOutput struct {
+	// Key is the category or tag, "" for the subscriptions without one.
+	Key   string `json:"key" example:"streaming"`
+	Total int    `json:"total" example:"3600"`
+}
+
+// CostOutput is the total cost of the matched subscriptions.
+// @Description CostOutput
+type CostOutput struct {
+	Total  int              `json:"total" example:"4800"`
+	Policy CostPolicyOutput `json:"policy"`
+	// Groups split Total by group_by, largest first. A subscription with several tags
+	// counts in the group of each, so tag groups may add up to more than Total.
+	Groups []CostGroupOutput `json:"groups,omitempty"`
+}
+
+type PurgeRequest struct {
+	UserID      *string
+	ServiceName *string
 }
 
 // SubscriptionsOutput paginated response.
@@ -0,0 +1,32 @@
+package dto
+
+type UpcomingRequest struct {
+	UserID      *string `form:"user_id"`
+	ServiceName *string `form:"service_name"`
+	Within      int     `form:"within" validate:"omitempty,min=1,max=24"`
+	TimeZone    *string `form:"tz"`
+}
+
+// UpcomingSubscription is a subscription that renews or ends within the window.
+// @Description UpcomingSubscription
+type UpcomingSubscription struct {
+	Subscription SubscriptionOutput `json:"subscription"`
+	// NextRenewal is the first charge date in the window, null without one.
+	NextRenewal *string `json:"next_renewal" example:"2025-11-15"`
+	// Renewals counts the charges in the window.
+	Renewals int `json:"renewals" example:"3"`
+	// EndsOn is the end date when it falls in the window.
+	EndsOn *string `json:"ends_on" example:"2026-01-01"`
+	// ExpectedCharge is the sum of the charges in the window.
+	ExpectedCharge int `json:"expected_charge" example:"1197"`
+}
+
+// UpcomingOutput lists the subscriptions renewing or ending in [from, to), soonest first.
+// @Description UpcomingOutput
+type UpcomingOutput struct {
+	Subscriptions []UpcomingSubscription `json:"subscriptions"`
+	From          string                 `json:"from" example:"2025-11-05"`
+	To            string                 `json:"to" example:"2026-02-05"`
+	ExpectedTotal int                    `json:"expected_total" example:"1197"`
+	TimeZone      string                 `json:"time_zone" example:"UTC"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// UpdateUserSettingsRequest replaces the settings of a user.
+// @Description UpdateUserSettingsRequest
+type UpdateUserSettingsRequest struct {
+	TimeZone string `json:"time_zone" validate:"required" example:"Asia/Vladivostok"`
+}
+
+// UserSettingsOutput represents the settings of a user.
+// @Description UserSettingsOutput
+type UserSettingsOutput struct {
+	UserID    string `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	TimeZone  string `json:"time_zone" example:"Asia/Vladivostok"`
+	UpdatedAt string `json:"updated_at" example:"2025-11-03T10:00:00Z"`
+}
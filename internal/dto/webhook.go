@@ -0,0 +1,79 @@
+package dto
+
+import "encoding/json"
+
+// CreateWebhookRequest registers a URL for subscription lifecycle events.
+// @Description CreateWebhookRequest
+type CreateWebhookRequest struct {
+	URL string `json:"url" validate:"required,url" example:"https://billing.example.com/hooks/subscriptions"`
+	// Secret signs the deliveries. A random secret is generated when empty.
+	Secret string `json:"secret" validate:"omitempty,min=16" example:"whsec_3b1f0c9e8a7d6c5b"`
+	// EventTypes are created, updated, deleted, expired, price_changed and budget.alert.
+	EventTypes []string `json:"event_types" validate:"required,min=1" example:"created,deleted"`
+}
+
+// UpdateWebhookRequest represents partial webhook update fields.
+// @Description UpdateWebhookRequest
+type UpdateWebhookRequest struct {
+	URL        *string   `json:"url" validate:"omitempty,url" example:"https://billing.example.com/hooks/v2"`
+	Secret     *string   `json:"secret" validate:"omitempty,min=16" example:"whsec_9e8a7d6c5b3b1f0c"`
+	EventTypes *[]string `json:"event_types" validate:"omitempty,min=1" example:"created,updated,deleted"`
+}
+
+// WebhookOutput represents a webhook. Secret is returned only when it is set.
+// @Description WebhookOutput
+type WebhookOutput struct {
+	ID         string   `json:"id" example:"5c8e2f4a-1b3d-4e6f-8a9b-0c1d2e3f4a5b"`
+	URL        string   `json:"url" example:"https://billing.example.com/hooks/subscriptions"`
+	EventTypes []string `json:"event_types" example:"created,deleted"`
+	Secret     string   `json:"secret,omitempty" example:"whsec_3b1f0c9e8a7d6c5b"`
+	CreatedAt  string   `json:"created_at" example:"2025-11-08T10:00:00Z"`
+	UpdatedAt  string   `json:"updated_at" example:"2025-11-08T10:00:00Z"`
+}
+
+type DeliveryFilter struct {
+	Status *string `form:"status" validate:"omitempty,oneof=pending delivered dead"`
+	// Limit is 50 when empty.
+	Limit int `form:"limit" validate:"omitempty,min=1,max=500"`
+}
+
+// WebhookDeliveryOutput describes an event on its way to a webhook.
+// @Description WebhookDeliveryOutput
+type WebhookDeliveryOutput struct {
+	ID        string `json:"id" example:"9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d"`
+	WebhookID string `json:"webhook_id" example:"5c8e2f4a-1b3d-4e6f-8a9b-0c1d2e3f4a5b"`
+	EventID   string `json:"event_id" example:"1f2e3d4c-5b6a-4978-8695-a4b3c2d1e0f9"`
+	EventType string `json:"event_type" example:"created"`
+	// Status is pending, delivered or dead.
+	Status   string `json:"status" example:"pending"`
+	Attempts int    `json:"attempts" example:"2"`
+	// NextAttemptAt is set for pending deliveries.
+	NextAttemptAt  *string         `json:"next_attempt_at" example:"2025-11-08T10:02:00Z"`
+	LastStatusCode *int            `json:"last_status_code" example:"503"`
+	LastError      string          `json:"last_error,omitempty" example:"unexpected status 503"`
+	DeliveredAt    *string         `json:"delivered_at" example:"2025-11-08T10:04:00Z"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	CreatedAt      string          `json:"created_at" example:"2025-11-08T10:00:00Z"`
+	UpdatedAt      string          `json:"updated_at" example:"2025-11-08T10:02:00Z"`
+}
+
+// SubscriptionEvent is the body of a webhook delivery. Subscription dates are YYYY-MM-DD.
+// @Description SubscriptionEvent
+type SubscriptionEvent struct {
+	ID           string             `json:"id" example:"1f2e3d4c-5b6a-4978-8695-a4b3c2d1e0f9"`
+	Type         string             `json:"type" example:"price_changed"`
+	OccurredAt   string             `json:"occurred_at" example:"2025-11-08T10:00:00Z"`
+	Subscription SubscriptionOutput `json:"subscription"`
+	// PriceChange is set for price_changed events of a scheduled price change.
+	PriceChange *PriceChangeOutput `json:"price_change,omitempty"`
+}
+
+// BudgetAlertEvent is the body of a budget.alert webhook delivery. The period start is
+// YYYY-MM-DD.
+// @Description BudgetAlertEvent
+type BudgetAlertEvent struct {
+	ID         string            `json:"id" example:"4d3c2b1a-0f9e-4d8c-b7a6-a5b4c3d2e1f0"`
+	Type       string            `json:"type" example:"budget.alert"`
+	OccurredAt string            `json:"occurred_at" example:"2025-11-08T10:00:00Z"`
+	Alert      BudgetAlertOutput `json:"alert"`
+}
@@ -0,0 +1,207 @@
+// Package graphapi serves the subscriptions and their users as a GraphQL schema, on top
+// of the same service as the REST API of package handler.
+package graphapi
+
+//go:generate go tool mockgen -source=graphapi.go -destination=mocks/graphapi.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	contextkeys "tz/internal/contextkey"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/tracing"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"go.uber.org/zap"
+)
+
+// SubscriptionServiceI is the part of the subscription service the schema reads.
+type SubscriptionServiceI interface {
+	SubscriptionByID(ctx context.Context, id uuid.UUID) (dto.SubscriptionOutput, error)
+	Subscriptions(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionsOutput, error)
+	SubscriptionsCost(ctx context.Context, req dto.CostRequest) (dto.CostOutput, error)
+	SubscriptionsOfUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]dto.SubscriptionOutput, error)
+}
+
+// Error codes set in the extensions of the errors of a response.
+const (
+	codeBadUserInput    = "BAD_USER_INPUT"
+	codeNotFound        = "NOT_FOUND"
+	codeForbidden       = "FORBIDDEN"
+	codeQueryTooDeep    = "QUERY_TOO_DEEP"
+	codeQueryTooComplex = "QUERY_TOO_COMPLEX"
+	codeInternal        = "INTERNAL"
+)
+
+type Server struct {
+	service SubscriptionServiceI
+	schema  graphql.Schema
+	limits  Limits
+	log     *zap.Logger
+}
+
+func NewServer(service SubscriptionServiceI, limits Limits, log *zap.Logger) (*Server, error) {
+	s := &Server{service: service, limits: limits, log: log.Named("graphql")}
+	schema, err := s.newSchema()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build graphql schema: %w", err)
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// requestState is what the resolvers of one request share.
+type requestState struct {
+	authorize     func(domain.Permission) error
+	subscriptions *loader[uuid.UUID, []dto.SubscriptionOutput]
+}
+
+type requestStateKey struct{}
+
+// Execute runs a query. authorize is asked for the permission of each guarded field; a
+// denied field resolves to null with a FORBIDDEN error while the rest of the query runs.
+// Queries over the limits are rejected before any field resolves.
+func (s *Server) Execute(ctx context.Context, req dto.GraphQLRequest, authorize func(domain.Permission) error) dto.GraphQLResponse {
+	log := s.loggerWith(ctx, zap.String("operation", req.OperationName))
+
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		log.Warn("Failed to parse query", zap.Error(err))
+		return s.response(log, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+	}
+
+	if result := graphql.ValidateDocument(&s.schema, doc, nil); !result.IsValid {
+		log.Warn("Invalid query", zap.Int("errors", len(result.Errors)))
+		return s.response(log, &graphql.Result{Errors: result.Errors})
+	}
+
+	if err := s.limits.check(doc, req.OperationName, req.Variables); err != nil {
+		log.Warn("Query rejected", zap.Error(err))
+		return s.response(log, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+	}
+
+	state := &requestState{
+		authorize: authorize,
+		subscriptions: newLoader(func(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]dto.SubscriptionOutput, error) {
+			return s.service.SubscriptionsOfUsers(ctx, userIDs)
+		}),
+	}
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       context.WithValue(ctx, requestStateKey{}, state),
+	})
+	return s.response(log, result)
+}
+
+// SelectsCost reports whether the operation of req selects a cost field, which reads and
+// prices all the subscriptions it matches like GET /subscriptions/cost. A query that does
+// not parse selects nothing; Execute reports its errors.
+func (s *Server) SelectsCost(req dto.GraphQLRequest) bool {
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		return false
+	}
+	a, operation := newAnalysis(doc, req.OperationName, req.Variables)
+	return operation != nil && a.selects(operation.SelectionSet, "cost", make(map[string]bool))
+}
+
+// response converts result, keeping the codes of the errors. Errors raised by the
+// executor rather than a resolver, such as bad variables, are the caller's.
+func (s *Server) response(log *zap.Logger, result *graphql.Result) dto.GraphQLResponse {
+	resp := dto.GraphQLResponse{Data: result.Data}
+	for _, formatted := range result.Errors {
+		gqlErr := dto.GraphQLError{
+			Message:    formatted.Message,
+			Path:       formatted.Path,
+			Extensions: map[string]any{"code": codeBadUserInput},
+		}
+		for _, loc := range formatted.Locations {
+			gqlErr.Locations = append(gqlErr.Locations, dto.GraphQLLocation{Line: loc.Line, Column: loc.Column})
+		}
+		if qErr := originalQueryError(formatted); qErr != nil {
+			gqlErr.Message = qErr.message
+			gqlErr.Extensions["code"] = qErr.code
+		}
+		resp.Errors = append(resp.Errors, gqlErr)
+	}
+	if len(resp.Errors) > 0 {
+		log.Debug("Query finished with errors", zap.Int("errors", len(resp.Errors)))
+	}
+	return resp
+}
+
+// queryError is an error reported to the client with a code.
+type queryError struct {
+	code    string
+	message string
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+func (e *queryError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+// originalQueryError digs the queryError out of the wrappers of the executor, which
+// drops the extensions of errors returned by thunks.
+func originalQueryError(err error) *queryError {
+	for err != nil {
+		var qErr *queryError
+		if errors.As(err, &qErr) {
+			return qErr
+		}
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			err = e.OriginalError
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// queryErrorOf maps the errors of the service to codes the way the REST API maps them to
+// HTTP statuses. Unexpected errors are logged with msg and hidden from the caller.
+func queryErrorOf(log *zap.Logger, msg string, err error) error {
+	var qErr *queryError
+	switch {
+	case errors.As(err, &qErr):
+		return qErr
+	case errors.Is(err, domain.ErrNotFound):
+		return &queryError{code: codeNotFound, message: "subscription not found"}
+	case errors.Is(err, domain.ErrInvalidDate), errors.Is(err, domain.ErrInvalidDateFormat),
+		errors.Is(err, domain.ErrInvalidCostPolicy), errors.Is(err, domain.ErrInvalidTimeZone),
+		errors.Is(err, domain.ErrInvalidLabel):
+		log.Warn("Invalid argument", zap.Error(err))
+		return &queryError{code: codeBadUserInput, message: err.Error()}
+	case errors.Is(err, domain.ErrForbidden), errors.Is(err, domain.ErrUnauthorized):
+		return &queryError{code: codeForbidden, message: err.Error()}
+	default:
+		log.Error(msg, zap.Error(err))
+		return &queryError{code: codeInternal, message: "internal error"}
+	}
+}
+
+func (s *Server) loggerWith(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	requestID, _ := contextkeys.RequestID(ctx)
+	base := []zap.Field{zap.String("request_id", requestID)}
+	base = append(base, tracing.LogFields(ctx)...)
+	return s.log.With(append(base, fields...)...)
+}
+
+func stateOf(ctx context.Context) *requestState {
+	state, _ := ctx.Value(requestStateKey{}).(*requestState)
+	return state
+}
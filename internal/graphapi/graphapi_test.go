@@ -0,0 +1,310 @@
+package graphapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/graphapi/mocks"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func allowAll(domain.Permission) error {
+	return nil
+}
+
+func newServer(t *testing.T, limits Limits) (*Server, *mocks.MockSubscriptionServiceI) {
+	t.Helper()
+
+	service := mocks.NewMockSubscriptionServiceI(gomock.NewController(t))
+	s, err := NewServer(service, limits, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	return s, service
+}
+
+// data returns the data of resp as JSON.
+func data(t *testing.T, resp dto.GraphQLResponse) string {
+	t.Helper()
+
+	b, err := json.Marshal(resp.Data)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return string(b)
+}
+
+func codes(resp dto.GraphQLResponse) []any {
+	var codes []any
+	for _, err := range resp.Errors {
+		codes = append(codes, err.Extensions["code"])
+	}
+	return codes
+}
+
+func TestServer_Execute(t *testing.T) {
+	id := uuid.New()
+	userID := uuid.New()
+	output := dto.SubscriptionOutput{ID: id.String(), ServiceName: "Netflix", Price: 500, UserID: userID.String(), StartDate: "07-2025", BillingPeriodMonths: 1}
+
+	tests := []struct {
+		name      string
+		req       dto.GraphQLRequest
+		setup     func(s *mocks.MockSubscriptionServiceI)
+		wantData  string
+		wantCodes []any
+	}{
+		{
+			name: "subscription",
+			req:  dto.GraphQLRequest{Query: `{ subscription(id: "` + id.String() + `") { serviceName price endDate } }`},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().SubscriptionByID(gomock.Any(), id).Return(output, nil)
+			},
+			wantData: `{"subscription":{"endDate":null,"price":500,"serviceName":"Netflix"}}`,
+		},
+		{
+			name: "subscription not found",
+			req:  dto.GraphQLRequest{Query: `{ subscription(id: "` + id.String() + `") { id } }`},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().SubscriptionByID(gomock.Any(), id).Return(dto.SubscriptionOutput{}, domain.ErrNotFound)
+			},
+			wantData:  `{"subscription":null}`,
+			wantCodes: []any{codeNotFound},
+		},
+		{
+			name:      "invalid id",
+			req:       dto.GraphQLRequest{Query: `{ subscription(id: "nope") { id } }`},
+			wantData:  `{"subscription":null}`,
+			wantCodes: []any{codeBadUserInput},
+		},
+		{
+			name: "subscriptions with variables",
+			req: dto.GraphQLRequest{
+				Query:     `query List($size: Int) { subscriptions(serviceName: "Netflix", pageSize: $size) { total hasNextPage items { id } } }`,
+				Variables: map[string]any{"size": float64(500)},
+			},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().Subscriptions(gomock.Any(), dto.SubscriptionFilter{ServiceName: ptr("Netflix"), Page: 1, PageSize: 10}).
+					Return(dto.MakeSubscriptionsOutput([]dto.SubscriptionOutput{output}, 11, 1, 10), nil)
+			},
+			wantData: `{"subscriptions":{"hasNextPage":true,"items":[{"id":"` + id.String() + `"}],"total":11}}`,
+		},
+		{
+			name: "cost",
+			req:  dto.GraphQLRequest{Query: `{ cost(from: "01-2025", proration: "day") { total policy { proration } } }`},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().SubscriptionsCost(gomock.Any(), dto.CostRequest{From: ptr("01-2025"), Proration: ptr("day")}).
+					Return(dto.CostOutput{Total: 1200, Policy: dto.CostPolicyOutput{Proration: "day"}}, nil)
+			},
+			wantData: `{"cost":{"policy":{"proration":"day"},"total":1200}}`,
+		},
+		{
+			name:      "invalid cost policy",
+			req:       dto.GraphQLRequest{Query: `{ cost(proration: "week") { total } }`},
+			wantCodes: []any{codeBadUserInput},
+		},
+		{
+			name: "invalid date",
+			req:  dto.GraphQLRequest{Query: `{ cost(from: "2025") { total } }`},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().SubscriptionsCost(gomock.Any(), gomock.Any()).Return(dto.CostOutput{}, domain.ErrInvalidDateFormat)
+			},
+			wantCodes: []any{codeBadUserInput},
+		},
+		{
+			name: "internal error is hidden",
+			req:  dto.GraphQLRequest{Query: `{ subscriptions { total } }`},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().Subscriptions(gomock.Any(), gomock.Any()).Return(dto.SubscriptionsOutput{}, errors.New("db down"))
+			},
+			wantCodes: []any{codeInternal},
+		},
+		{
+			name:      "syntax error",
+			req:       dto.GraphQLRequest{Query: `{ subscriptions {`},
+			wantData:  `null`,
+			wantCodes: []any{codeBadUserInput},
+		},
+		{
+			name:      "unknown field",
+			req:       dto.GraphQLRequest{Query: `{ subscriptions { nope } }`},
+			wantData:  `null`,
+			wantCodes: []any{codeBadUserInput},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, service := newServer(t, Limits{})
+			if tt.setup != nil {
+				tt.setup(service)
+			}
+
+			resp := s.Execute(context.Background(), tt.req, allowAll)
+			if tt.wantData != "" {
+				if got := data(t, resp); got != tt.wantData {
+					t.Errorf("data = %s, want %s", got, tt.wantData)
+				}
+			}
+			if got := codes(resp); len(got) != len(tt.wantCodes) || len(got) > 0 && got[0] != tt.wantCodes[0] {
+				t.Errorf("error codes = %v (%+v), want %v", got, resp.Errors, tt.wantCodes)
+			}
+		})
+	}
+}
+
+func TestServer_Execute_BatchesSubscriptionsOfUsers(t *testing.T) {
+	s, service := newServer(t, Limits{})
+	alice, bob := uuid.New(), uuid.New()
+	first := dto.SubscriptionOutput{ID: uuid.NewString(), ServiceName: "Netflix", UserID: alice.String()}
+	second := dto.SubscriptionOutput{ID: uuid.NewString(), ServiceName: "Spotify", UserID: bob.String()}
+
+	service.EXPECT().Subscriptions(gomock.Any(), gomock.Any()).
+		Return(dto.MakeSubscriptionsOutput([]dto.SubscriptionOutput{first, second}, 2, 1, 10), nil)
+	service.EXPECT().SubscriptionsOfUsers(gomock.Any(), gomock.InAnyOrder([]uuid.UUID{alice, bob})).
+		Return(map[uuid.UUID][]dto.SubscriptionOutput{alice: {first}, bob: {second}}, nil).Times(1)
+
+	resp := s.Execute(context.Background(), dto.GraphQLRequest{
+		Query: `{ subscriptions { items { user { id subscriptions { serviceName } } } } }`,
+	}, allowAll)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("errors = %+v", resp.Errors)
+	}
+
+	want := `{"subscriptions":{"items":[` +
+		`{"user":{"id":"` + alice.String() + `","subscriptions":[{"serviceName":"Netflix"}]}},` +
+		`{"user":{"id":"` + bob.String() + `","subscriptions":[{"serviceName":"Spotify"}]}}]}}`
+	if got := data(t, resp); got != want {
+		t.Errorf("data = %s, want %s", got, want)
+	}
+}
+
+func TestServer_Execute_Forbidden(t *testing.T) {
+	s, service := newServer(t, Limits{})
+	userID := uuid.New()
+	service.EXPECT().SubscriptionsOfUsers(gomock.Any(), []uuid.UUID{userID}).Return(nil, nil)
+
+	authorize := func(permission domain.Permission) error {
+		if permission == domain.PermCostRead {
+			return domain.ErrForbidden
+		}
+		return nil
+	}
+	resp := s.Execute(context.Background(), dto.GraphQLRequest{
+		Query: `{ user(id: "` + userID.String() + `") { subscriptions { id } } cost { total } }`,
+	}, authorize)
+
+	// The denied field is null, the rest of the query runs.
+	if got, want := data(t, resp), `{"cost":null,"user":{"subscriptions":[]}}`; got != want {
+		t.Errorf("data = %s, want %s", got, want)
+	}
+	if got := codes(resp); len(got) != 1 || got[0] != codeForbidden {
+		t.Errorf("error codes = %v, want [%s]", got, codeForbidden)
+	}
+}
+
+func TestServer_Execute_Limits(t *testing.T) {
+	ids := make([]any, 50)
+	for i := range ids {
+		ids[i] = uuid.NewString()
+	}
+
+	tests := []struct {
+		name     string
+		limits   Limits
+		req      dto.GraphQLRequest
+		wantCode string
+	}{
+		{
+			name:     "too deep",
+			limits:   Limits{MaxDepth: 4},
+			req:      dto.GraphQLRequest{Query: `{ subscriptions { items { user { subscriptions { user { id } } } } } }`},
+			wantCode: codeQueryTooDeep,
+		},
+		{
+			name:     "too deep through a fragment",
+			limits:   Limits{MaxDepth: 3},
+			req:      dto.GraphQLRequest{Query: `{ subscriptions { ...page } } fragment page on SubscriptionPage { items { user { id } } }`},
+			wantCode: codeQueryTooDeep,
+		},
+		{
+			name:     "too complex page",
+			limits:   Limits{MaxComplexity: 500},
+			req:      dto.GraphQLRequest{Query: `{ subscriptions(pageSize: 100) { items { user { cost { total } } } } }`},
+			wantCode: codeQueryTooComplex,
+		},
+		{
+			name:     "too complex page from a variable default",
+			limits:   Limits{MaxComplexity: 500},
+			req:      dto.GraphQLRequest{Query: `query Page($n: Int = 100) { subscriptions(pageSize: $n) { items { user { cost { total } } } } }`},
+			wantCode: codeQueryTooComplex,
+		},
+		{
+			name:   "too complex users from variables",
+			limits: Limits{MaxComplexity: 200},
+			req: dto.GraphQLRequest{
+				Query:     `query Users($ids: [ID!]!) { users(ids: $ids) { cost { total } } }`,
+				Variables: map[string]any{"ids": ids},
+			},
+			wantCode: codeQueryTooComplex,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newServer(t, tt.limits)
+
+			resp := s.Execute(context.Background(), tt.req, allowAll)
+			if resp.Data != nil {
+				t.Errorf("data = %v, want none", resp.Data)
+			}
+			if got := codes(resp); len(got) != 1 || got[0] != tt.wantCode {
+				t.Errorf("error codes = %v, want [%s]", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestLimits_Check_Introspection(t *testing.T) {
+	s, _ := newServer(t, Limits{MaxDepth: 2, MaxComplexity: 5})
+
+	resp := s.Execute(context.Background(), dto.GraphQLRequest{
+		Query: `{ __schema { types { name fields { name type { name } } } } }`,
+	}, allowAll)
+	if len(resp.Errors) > 0 {
+		t.Errorf("errors = %+v, want none", resp.Errors)
+	}
+}
+
+func TestServer_SelectsCost(t *testing.T) {
+	s, _ := newServer(t, Limits{})
+
+	tests := []struct {
+		name string
+		req  dto.GraphQLRequest
+		want bool
+	}{
+		{name: "cost", req: dto.GraphQLRequest{Query: `{ cost { total } }`}, want: true},
+		{name: "cost of users in a fragment", req: dto.GraphQLRequest{Query: `{ users(ids: ["1"]) { ...Spend } } fragment Spend on User { cost { total } }`}, want: true},
+		{name: "no cost", req: dto.GraphQLRequest{Query: `{ subscriptions { total } }`}},
+		{name: "other operation", req: dto.GraphQLRequest{Query: `query A { subscriptions { total } } query B { cost { total } }`, OperationName: "A"}},
+		{name: "unparsable", req: dto.GraphQLRequest{Query: `{ cost {`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.SelectsCost(tt.req); got != tt.want {
+				t.Errorf("SelectsCost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,257 @@
+package graphapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Limits bound the queries the server runs. Zero disables a limit.
+type Limits struct {
+	// MaxDepth is the deepest nesting of fields, the fields of the query being at depth 1.
+	MaxDepth int
+	// MaxComplexity bounds the estimated number of resolved fields. A field counts 1,
+	// a cost field costComplexity, and the fields under a list count once per item.
+	MaxComplexity int
+}
+
+const (
+	// costComplexity is the complexity of a cost field, which reads and prices all
+	// the subscriptions it matches.
+	costComplexity = 10
+	// defaultListSize is the assumed length of lists whose arguments do not bound them.
+	defaultListSize = 10
+	// complexityCap keeps the estimate of absurd queries from overflowing.
+	complexityCap = 1 << 30
+)
+
+// analysis measures the operation of a document. Introspection fields, named with
+// a leading "__", are not counted.
+type analysis struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+	// defaults are the default values of the variables of the operation.
+	defaults map[string]ast.Value
+}
+
+// newAnalysis returns the analysis of the operation of doc named operationName, the
+// only operation when the name is empty. The operation is nil when doc has no such
+// operation; the executor reports it.
+func newAnalysis(doc *ast.Document, operationName string, variables map[string]any) (analysis, *ast.OperationDefinition) {
+	a := analysis{fragments: make(map[string]*ast.FragmentDefinition), variables: variables}
+	var operations []*ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			operations = append(operations, def)
+		case *ast.FragmentDefinition:
+			a.fragments[def.Name.Value] = def
+		}
+	}
+
+	var operation *ast.OperationDefinition
+	for _, op := range operations {
+		if operationName == "" && len(operations) == 1 || op.Name != nil && op.Name.Value == operationName {
+			operation = op
+		}
+	}
+	if operation == nil {
+		return a, nil
+	}
+	a.defaults = make(map[string]ast.Value, len(operation.VariableDefinitions))
+	for _, def := range operation.VariableDefinitions {
+		if def.DefaultValue != nil {
+			a.defaults[def.Variable.Name.Value] = def.DefaultValue
+		}
+	}
+	return a, operation
+}
+
+// check returns a limit error when the selected operation of doc exceeds limits. The
+// document has been validated, so its fragments do not form cycles.
+func (l Limits) check(doc *ast.Document, operationName string, variables map[string]any) error {
+	a, operation := newAnalysis(doc, operationName, variables)
+	if operation == nil {
+		return nil
+	}
+
+	if l.MaxDepth > 0 {
+		if depth := a.depth(operation.SelectionSet); depth > l.MaxDepth {
+			return &queryError{code: codeQueryTooDeep, message: fmt.Sprintf("query depth %d exceeds the limit of %d", depth, l.MaxDepth)}
+		}
+	}
+	if l.MaxComplexity > 0 {
+		if complexity := a.complexity(operation.SelectionSet); complexity > l.MaxComplexity {
+			return &queryError{code: codeQueryTooComplex, message: fmt.Sprintf("query complexity %d exceeds the limit of %d", complexity, l.MaxComplexity)}
+		}
+	}
+	return nil
+}
+
+// selects reports whether set selects a field named name at any depth. Fragment spreads
+// are followed at most once, so that a document with fragment cycles, which is not
+// validated yet, terminates.
+func (a analysis) selects(set *ast.SelectionSet, name string, visited map[string]bool) bool {
+	if set == nil {
+		return false
+	}
+
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if selection.Name.Value == name || a.selects(selection.SelectionSet, name, visited) {
+				return true
+			}
+		case *ast.InlineFragment:
+			if a.selects(selection.SelectionSet, name, visited) {
+				return true
+			}
+		case *ast.FragmentSpread:
+			fragment, ok := a.fragments[selection.Name.Value]
+			if !ok || visited[selection.Name.Value] {
+				continue
+			}
+			visited[selection.Name.Value] = true
+			if a.selects(fragment.SelectionSet, name, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a analysis) depth(set *ast.SelectionSet) int {
+	if set == nil {
+		return 0
+	}
+
+	deepest := 0
+	for _, selection := range set.Selections {
+		var depth int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			depth = 1 + a.depth(selection.SelectionSet)
+		case *ast.InlineFragment:
+			depth = a.depth(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if fragment, ok := a.fragments[selection.Name.Value]; ok {
+				depth = a.depth(fragment.SelectionSet)
+			}
+		}
+		deepest = max(deepest, depth)
+	}
+	return deepest
+}
+
+func (a analysis) complexity(set *ast.SelectionSet) int {
+	if set == nil {
+		return 0
+	}
+
+	total := 0
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			own := 1
+			if selection.Name.Value == "cost" {
+				own = costComplexity
+			}
+			total += own + capped(a.listSize(selection)*a.complexity(selection.SelectionSet))
+		case *ast.InlineFragment:
+			total += a.complexity(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if fragment, ok := a.fragments[selection.Name.Value]; ok {
+				total += a.complexity(fragment.SelectionSet)
+			}
+		}
+		total = capped(total)
+	}
+	return total
+}
+
+// listSize is the number of times the selection of field is resolved: the page size of
+// subscriptions, the number of ids of users, 1 for other fields.
+func (a analysis) listSize(field *ast.Field) int {
+	switch field.Name.Value {
+	case "subscriptions":
+		if size, ok := a.intArgument(field, "pageSize"); ok {
+			return min(max(size, 1), maxPageSize)
+		}
+		return defaultListSize
+	case "users":
+		for _, arg := range field.Arguments {
+			if arg.Name.Value == "ids" {
+				return max(a.listLength(arg.Value), 1)
+			}
+		}
+		return defaultListSize
+	default:
+		return 1
+	}
+}
+
+func (a analysis) intArgument(field *ast.Field, name string) (int, bool) {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != name {
+			continue
+		}
+		switch value := a.resolve(arg.Value).(type) {
+		case int:
+			return value, true
+		case float64:
+			return int(value), true
+		case json.Number:
+			n, err := value.Int64()
+			return int(n), err == nil
+		case string:
+			n, err := strconv.Atoi(value)
+			return n, err == nil
+		}
+	}
+	return 0, false
+}
+
+func (a analysis) listLength(value ast.Value) int {
+	switch value := a.resolve(value).(type) {
+	case []any:
+		return len(value)
+	case []string:
+		return len(value)
+	default:
+		return 1
+	}
+}
+
+// resolve returns the Go value of a literal, or of the variable it refers to. A variable
+// the request leaves out takes the default of its definition.
+func (a analysis) resolve(value ast.Value) any {
+	switch value := value.(type) {
+	case *ast.Variable:
+		if v, ok := a.variables[value.Name.Value]; ok {
+			return v
+		}
+		if def, ok := a.defaults[value.Name.Value]; ok {
+			return a.resolve(def)
+		}
+		return nil
+	case *ast.IntValue:
+		return value.Value
+	case *ast.ListValue:
+		return make([]any, len(value.Values))
+	default:
+		return nil
+	}
+}
+
+func capped(n int) int {
+	return min(n, complexityCap)
+}
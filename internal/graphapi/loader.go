@@ -0,0 +1,61 @@
+package graphapi
+
+import (
+	"context"
+	"sync"
+)
+
+// loader batches the keys requested while one level of a query resolves into a single
+// fetch. The executor resolves a level before it calls the thunks of that level, so the
+// first thunk called fetches the keys of all of them. Results are kept for the request.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	results map[K]*loaded[V]
+}
+
+type loaded[V any] struct {
+	value V
+	err   error
+	done  bool
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{fetch: fetch, results: make(map[K]*loaded[V])}
+}
+
+// load queues key and returns a thunk of its value. Keys missing from the fetched map
+// get the zero value.
+func (l *loader[K, V]) load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	if _, ok := l.results[key]; !ok {
+		l.results[key] = &loaded[V]{}
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		result := l.results[key]
+		if !result.done {
+			l.dispatch(ctx)
+		}
+		return result.value, result.err
+	}
+}
+
+// dispatch fetches the pending keys. l.mu is held.
+func (l *loader[K, V]) dispatch(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		result := l.results[key]
+		result.value, result.err, result.done = values[key], err, true
+	}
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: graphapi.go
+//
+// Generated by this command:
+//
+//	mockgen -source=graphapi.go -destination=mocks/graphapi.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dto "tz/internal/dto"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionServiceI is a mock of SubscriptionServiceI interface.
+type MockSubscriptionServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionServiceIMockRecorder is the mock recorder for MockSubscriptionServiceI.
+type MockSubscriptionServiceIMockRecorder struct {
+	mock *MockSubscriptionServiceI
+}
+
+// NewMockSubscriptionServiceI creates a new mock instance.
+func NewMockSubscriptionServiceI(ctrl *gomock.Controller) *MockSubscriptionServiceI {
+	mock := &MockSubscriptionServiceI{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionServiceI) EXPECT() *MockSubscriptionServiceIMockRecorder {
+	return m.recorder
+}
+
+// SubscriptionByID mocks base method.
+func (m *MockSubscriptionServiceI) SubscriptionByID(ctx context.Context, id uuid.UUID) (dto.SubscriptionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionByID", ctx, id)
+	ret0, _ := ret[0].(dto.SubscriptionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscriptionByID indicates an expected call of SubscriptionByID.
+func (mr *MockSubscriptionServiceIMockRecorder) SubscriptionByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionByID", reflect.TypeOf((*MockSubscriptionServiceI)(nil).SubscriptionByID), ctx, id)
+}
+
+// Subscriptions mocks base method.
+func (m *MockSubscriptionServiceI) Subscriptions(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscriptions", ctx, filter)
+	ret0, _ := ret[0].(dto.SubscriptionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscriptions indicates an expected call of Subscriptions.
+func (mr *MockSubscriptionServiceIMockRecorder) Subscriptions(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscriptions", reflect.TypeOf((*MockSubscriptionServiceI)(nil).Subscriptions), ctx, filter)
+}
+
+// SubscriptionsCost mocks base method.
+func (m *MockSubscriptionServiceI) SubscriptionsCost(ctx context.Context, req dto.CostRequest) (dto.CostOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionsCost", ctx, req)
+	ret0, _ := ret[0].(dto.CostOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscriptionsCost indicates an expected call of SubscriptionsCost.
+func (mr *MockSubscriptionServiceIMockRecorder) SubscriptionsCost(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionsCost", reflect.TypeOf((*MockSubscriptionServiceI)(nil).SubscriptionsCost), ctx, req)
+}
+
+// SubscriptionsOfUsers mocks base method.
+func (m *MockSubscriptionServiceI) SubscriptionsOfUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]dto.SubscriptionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionsOfUsers", ctx, userIDs)
+	ret0, _ := ret[0].(map[uuid.UUID][]dto.SubscriptionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscriptionsOfUsers indicates an expected call of SubscriptionsOfUsers.
+func (mr *MockSubscriptionServiceIMockRecorder) SubscriptionsOfUsers(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionsOfUsers", reflect.TypeOf((*MockSubscriptionServiceI)(nil).SubscriptionsOfUsers), ctx, userIDs)
+}
@@ -0,0 +1,380 @@
+package graphapi
+
+import (
+	"fmt"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/pkg/valid"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxPageSize is the largest page of subscriptions, as in the REST API.
+	maxPageSize = 100
+	// maxUsers limits the ids of the users field.
+	maxUsers = 100
+)
+
+func (s *Server) newSchema() (graphql.Schema, error) {
+	costPolicyType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "CostPolicy",
+		Description: "How a cost was calculated.",
+		Fields: graphql.Fields{
+			"endMonthInclusive": field(graphql.NewNonNull(graphql.Boolean), func(p dto.CostPolicyOutput) any { return p.EndMonthInclusive }),
+			"proration":         field(graphql.NewNonNull(graphql.String), func(p dto.CostPolicyOutput) any { return p.Proration }),
+			"timeZone":          field(graphql.NewNonNull(graphql.String), func(p dto.CostPolicyOutput) any { return p.TimeZone }),
+		},
+	})
+
+	costGroupType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "CostGroup",
+		Description: "Cost of the matched subscriptions with a category or tag, an empty key for those without one.",
+		Fields: graphql.Fields{
+			"key":   field(graphql.NewNonNull(graphql.String), func(g dto.CostGroupOutput) any { return g.Key }),
+			"total": field(graphql.NewNonNull(graphql.Int), func(g dto.CostGroupOutput) any { return g.Total }),
+		},
+	})
+
+	costType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Cost",
+		Description: "Total cost of the matched subscriptions.",
+		Fields: graphql.Fields{
+			"total":  field(graphql.NewNonNull(graphql.Int), func(c dto.CostOutput) any { return c.Total }),
+			"policy": field(graphql.NewNonNull(costPolicyType), func(c dto.CostOutput) any { return c.Policy }),
+			"groups": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(costGroupType))),
+				Description: "The total split by groupBy, largest first. Empty without groupBy.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(dto.CostOutput).Groups, nil
+				},
+			},
+		},
+	})
+
+	var userType *graphql.Object
+	subscriptionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          field(graphql.NewNonNull(graphql.ID), func(s dto.SubscriptionOutput) any { return s.ID }),
+				"serviceId":   field(graphql.ID, func(s dto.SubscriptionOutput) any { return deref(s.ServiceID) }),
+				"serviceName": field(graphql.NewNonNull(graphql.String), func(s dto.SubscriptionOutput) any { return s.ServiceName }),
+				"category":    field(graphql.NewNonNull(graphql.String), func(s dto.SubscriptionOutput) any { return s.Category }),
+				"tags":        field(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), func(s dto.SubscriptionOutput) any { return s.Tags }),
+				"price":       field(graphql.NewNonNull(graphql.Int), func(s dto.SubscriptionOutput) any { return s.Price }),
+				"userId":      field(graphql.NewNonNull(graphql.ID), func(s dto.SubscriptionOutput) any { return s.UserID }),
+				"user": {
+					Type:    graphql.NewNonNull(userType),
+					Resolve: s.subscriptionUser,
+				},
+				"startDate":           field(graphql.NewNonNull(graphql.String), func(s dto.SubscriptionOutput) any { return s.StartDate }),
+				"endDate":             field(graphql.String, func(s dto.SubscriptionOutput) any { return deref(s.EndDate) }),
+				"billingPeriodMonths": field(graphql.NewNonNull(graphql.Int), func(s dto.SubscriptionOutput) any { return s.BillingPeriodMonths }),
+				"expiredAt":           field(graphql.String, func(s dto.SubscriptionOutput) any { return deref(s.ExpiredAt) }),
+				"createdAt":           field(graphql.NewNonNull(graphql.String), func(s dto.SubscriptionOutput) any { return s.CreatedAt }),
+				"updatedAt":           field(graphql.NewNonNull(graphql.String), func(s dto.SubscriptionOutput) any { return s.UpdatedAt }),
+			}
+		}),
+	})
+
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "User",
+		Description: "A user and the subscriptions they hold.",
+		Fields: graphql.Fields{
+			"id": field(graphql.NewNonNull(graphql.ID), func(id uuid.UUID) any { return id.String() }),
+			"subscriptions": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(subscriptionType))),
+				Description: "The subscriptions of the user, oldest first. The subscriptions of all users of a query are read at once.",
+				Args: graphql.FieldConfigArgument{
+					"serviceName": {Type: graphql.String},
+				},
+				Resolve: s.userSubscriptions,
+			},
+			"cost": {
+				Type:    costType,
+				Args:    costArgs(false),
+				Resolve: s.guard(domain.PermCostRead, "Failed to calculate total cost", s.userCost),
+			},
+		},
+	})
+
+	pageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SubscriptionPage",
+		Fields: graphql.Fields{
+			"total":       field(graphql.NewNonNull(graphql.Int), func(p dto.SubscriptionsOutput) any { return p.Total }),
+			"page":        field(graphql.NewNonNull(graphql.Int), func(p dto.SubscriptionsOutput) any { return p.Page }),
+			"pageSize":    field(graphql.NewNonNull(graphql.Int), func(p dto.SubscriptionsOutput) any { return p.PageSize }),
+			"hasNextPage": field(graphql.NewNonNull(graphql.Boolean), func(p dto.SubscriptionsOutput) any { return p.HasNextPage }),
+			"hasPrevPage": field(graphql.NewNonNull(graphql.Boolean), func(p dto.SubscriptionsOutput) any { return p.HasPrevPage }),
+			"items": field(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(subscriptionType))), func(p dto.SubscriptionsOutput) any {
+				return p.Subscriptions
+			}),
+		},
+	})
+
+	// The guarded fields are nullable, so that a denied or failed one does not take
+	// the rest of the query down with it.
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"subscription": {
+				Type: subscriptionType,
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: s.guard(domain.PermSubscriptionsRead, "Failed to get subscription", s.subscription),
+			},
+			"subscriptions": {
+				Type: pageType,
+				Args: graphql.FieldConfigArgument{
+					"userId":      {Type: graphql.ID},
+					"serviceName": {Type: graphql.String},
+					"category":    {Type: graphql.String},
+					"tags":        {Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Subscriptions having all of them."},
+					"page":        {Type: graphql.Int, DefaultValue: 1},
+					"pageSize":    {Type: graphql.Int, DefaultValue: 10, Description: "At most 100."},
+				},
+				Resolve: s.guard(domain.PermSubscriptionsRead, "Failed to list subscriptions", s.subscriptions),
+			},
+			"user": {
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: s.guard(domain.PermSubscriptionsRead, "Failed to get user", s.user),
+			},
+			"users": {
+				Type: graphql.NewList(graphql.NewNonNull(userType)),
+				Args: graphql.FieldConfigArgument{
+					"ids": {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.ID))), Description: "At most 100."},
+				},
+				Resolve: s.guard(domain.PermSubscriptionsRead, "Failed to get users", s.users),
+			},
+			"cost": {
+				Type:    costType,
+				Args:    costArgs(true),
+				Resolve: s.guard(domain.PermCostRead, "Failed to calculate total cost", s.cost),
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// costArgs are the arguments of a cost field, the parameters of GET /subscriptions/cost.
+// The cost of a user takes the user from its parent.
+func costArgs(withUser bool) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"serviceName":       {Type: graphql.String},
+		"from":              {Type: graphql.String, Description: "MM-YYYY or YYYY-MM-DD."},
+		"to":                {Type: graphql.String, Description: "MM-YYYY or YYYY-MM-DD."},
+		"endMonthInclusive": {Type: graphql.Boolean},
+		"proration":         {Type: graphql.String, Description: "month or day."},
+		"timeZone":          {Type: graphql.String, Description: "IANA time zone of the current month."},
+		"category":          {Type: graphql.String},
+		"tags":              {Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Subscriptions having all of them."},
+		"groupBy":           {Type: graphql.String, Description: "category or tag."},
+	}
+	if withUser {
+		args["userId"] = &graphql.ArgumentConfig{Type: graphql.ID}
+	}
+	return args
+}
+
+// guard resolves a field after checking permission. Errors are mapped by queryErrorOf,
+// with msg logged for unexpected ones.
+func (s *Server) guard(permission domain.Permission, msg string, resolve func(p graphql.ResolveParams) (any, error)) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		log := s.loggerWith(p.Context, zap.String("field", p.Info.FieldName))
+		if err := stateOf(p.Context).authorize(permission); err != nil {
+			log.Warn("Field denied", zap.String("permission", string(permission)), zap.Error(err))
+			return nil, queryErrorOf(log, msg, err)
+		}
+
+		value, err := resolve(p)
+		if err != nil {
+			return nil, queryErrorOf(log, msg, err)
+		}
+		return value, nil
+	}
+}
+
+func (s *Server) subscription(p graphql.ResolveParams) (any, error) {
+	id, err := parseID(p.Args["id"], "subscription id")
+	if err != nil {
+		return nil, err
+	}
+	return s.service.SubscriptionByID(p.Context, id)
+}
+
+func (s *Server) subscriptions(p graphql.ResolveParams) (any, error) {
+	filter := dto.SubscriptionFilter{
+		ServiceName: optionalString(p.Args, "serviceName"),
+		Category:    optionalString(p.Args, "category"),
+		Tags:        stringList(p.Args, "tags"),
+		Page:        p.Args["page"].(int),
+		PageSize:    p.Args["pageSize"].(int),
+	}
+	if userID := optionalString(p.Args, "userId"); userID != nil {
+		if _, err := parseID(*userID, "userId"); err != nil {
+			return nil, err
+		}
+		filter.UserID = userID
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > maxPageSize {
+		filter.PageSize = 10
+	}
+	return s.service.Subscriptions(p.Context, filter)
+}
+
+func (s *Server) user(p graphql.ResolveParams) (any, error) {
+	return parseID(p.Args["id"], "user id")
+}
+
+func (s *Server) users(p graphql.ResolveParams) (any, error) {
+	ids := p.Args["ids"].([]any)
+	if len(ids) > maxUsers {
+		return nil, &queryError{code: codeBadUserInput, message: fmt.Sprintf("at most %d users may be requested", maxUsers)}
+	}
+
+	users := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		userID, err := parseID(id, "user id")
+		if err != nil {
+			return nil, err
+		}
+		users[i] = userID
+	}
+	return users, nil
+}
+
+func (s *Server) cost(p graphql.ResolveParams) (any, error) {
+	req := costRequest(p.Args)
+	req.UserID = optionalString(p.Args, "userId")
+	if req.UserID != nil {
+		if _, err := parseID(*req.UserID, "userId"); err != nil {
+			return nil, err
+		}
+	}
+	return s.calculateCost(p, req)
+}
+
+func (s *Server) userCost(p graphql.ResolveParams) (any, error) {
+	req := costRequest(p.Args)
+	userID := p.Source.(uuid.UUID).String()
+	req.UserID = &userID
+	return s.calculateCost(p, req)
+}
+
+func (s *Server) calculateCost(p graphql.ResolveParams, req dto.CostRequest) (any, error) {
+	if err := valid.ValidateStruct(req); err != nil {
+		return nil, &queryError{code: codeBadUserInput, message: err.Error()}
+	}
+	return s.service.SubscriptionsCost(p.Context, req)
+}
+
+// subscriptionUser resolves the holder of a subscription. Its subscriptions, when
+// selected, are loaded with those of the other users of the query.
+func (s *Server) subscriptionUser(p graphql.ResolveParams) (any, error) {
+	sub := p.Source.(dto.SubscriptionOutput)
+	userID, err := uuid.Parse(sub.UserID)
+	if err != nil {
+		log := s.loggerWith(p.Context, zap.String("subscription_id", sub.ID))
+		return nil, queryErrorOf(log, "Invalid user id of subscription", err)
+	}
+	return userID, nil
+}
+
+// userSubscriptions returns a thunk, so that the executor asks for the subscriptions of
+// all users of a level before the loader reads them.
+func (s *Server) userSubscriptions(p graphql.ResolveParams) (any, error) {
+	userID := p.Source.(uuid.UUID)
+	serviceName := optionalString(p.Args, "serviceName")
+	load := stateOf(p.Context).subscriptions.load(p.Context, userID)
+
+	return func() (any, error) {
+		subscriptions, err := load()
+		if err != nil {
+			log := s.loggerWith(p.Context, zap.String("field", p.Info.FieldName))
+			return nil, queryErrorOf(log, "Failed to fetch subscriptions of users", err)
+		}
+
+		matched := make([]dto.SubscriptionOutput, 0, len(subscriptions))
+		for _, sub := range subscriptions {
+			if serviceName == nil || sub.ServiceName == *serviceName {
+				matched = append(matched, sub)
+			}
+		}
+		return matched, nil
+	}, nil
+}
+
+// field resolves a field of a T source with value.
+func field[T any](typ graphql.Output, value func(T) any) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return value(p.Source.(T)), nil
+		},
+	}
+}
+
+func costRequest(args map[string]any) dto.CostRequest {
+	req := dto.CostRequest{
+		ServiceName: optionalString(args, "serviceName"),
+		From:        optionalString(args, "from"),
+		To:          optionalString(args, "to"),
+		Proration:   optionalString(args, "proration"),
+		TimeZone:    optionalString(args, "timeZone"),
+		Category:    optionalString(args, "category"),
+		Tags:        stringList(args, "tags"),
+		GroupBy:     optionalString(args, "groupBy"),
+	}
+	if inclusive, ok := args["endMonthInclusive"].(bool); ok {
+		req.EndMonthInclusive = &inclusive
+	}
+	return req
+}
+
+func parseID(value any, name string) (uuid.UUID, error) {
+	id, _ := value.(string)
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, &queryError{code: codeBadUserInput, message: "invalid " + name}
+	}
+	return parsed, nil
+}
+
+func optionalString(args map[string]any, name string) *string {
+	if value, ok := args[name].(string); ok {
+		return &value
+	}
+	return nil
+}
+
+// stringList returns the values of a list argument, nil when it is not given.
+func stringList(args map[string]any, name string) []string {
+	values, _ := args[name].([]any)
+	if values == nil {
+		return nil
+	}
+	out := make([]string, len(values))
+	for i, value := range values {
+		out[i], _ = value.(string)
+	}
+	return out
+}
+
+// deref returns the value of s, or an untyped nil for the executor to write null.
+func deref(s *string) any {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
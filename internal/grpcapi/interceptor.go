@@ -0,0 +1,239 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"tz/internal/authz"
+	contextkeys "tz/internal/contextkey"
+	"tz/internal/domain"
+	"tz/internal/ratelimit"
+	"tz/internal/tracing"
+	subscriptionv1 "tz/pkg/api/subscription/v1"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys of the calls, the lower-case counterparts of the REST headers and the
+// date_format query parameter.
+const (
+	apiKeyMetadata     = "x-api-key"
+	requestIDMetadata  = "x-request-id"
+	dateFormatMetadata = "date-format"
+	retryAfterMetadata = "retry-after"
+)
+
+// principalKey is the context key of the principal of an authenticated call.
+type principalKey struct{}
+
+// requestIDPattern limits accepted request IDs the same as in the REST API.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{8,128}$`)
+
+// permissions are the permissions of the methods, as of the matching REST routes.
+var permissions = map[string]domain.Permission{
+	subscriptionv1.SubscriptionService_CreateSubscription_FullMethodName:   domain.PermSubscriptionsCreate,
+	subscriptionv1.SubscriptionService_GetSubscription_FullMethodName:      domain.PermSubscriptionsRead,
+	subscriptionv1.SubscriptionService_ListSubscriptions_FullMethodName:    domain.PermSubscriptionsRead,
+	subscriptionv1.SubscriptionService_GetSubscriptionsCost_FullMethodName: domain.PermCostRead,
+	subscriptionv1.SubscriptionService_UpdateSubscription_FullMethodName:   domain.PermSubscriptionsUpdate,
+	subscriptionv1.SubscriptionService_DeleteSubscription_FullMethodName:   domain.PermSubscriptionsDelete,
+}
+
+// rateLimitGroups are the rate limit groups of the methods, as of the matching REST routes.
+var rateLimitGroups = map[string]string{
+	subscriptionv1.SubscriptionService_CreateSubscription_FullMethodName:   ratelimit.GroupSubscriptions,
+	subscriptionv1.SubscriptionService_GetSubscription_FullMethodName:      ratelimit.GroupSubscriptions,
+	subscriptionv1.SubscriptionService_ListSubscriptions_FullMethodName:    ratelimit.GroupSubscriptions,
+	subscriptionv1.SubscriptionService_GetSubscriptionsCost_FullMethodName: ratelimit.GroupCost,
+	subscriptionv1.SubscriptionService_UpdateSubscription_FullMethodName:   ratelimit.GroupSubscriptions,
+	subscriptionv1.SubscriptionService_DeleteSubscription_FullMethodName:   ratelimit.GroupSubscriptions,
+}
+
+// logging assigns the request ID, taken from the x-request-id metadata when it is
+// valid, logs the completed call and turns a panic into an Internal status.
+func (s *Server) logging(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	start := time.Now()
+	requestID := firstMetadata(ctx, requestIDMetadata)
+	if !requestIDPattern.MatchString(requestID) {
+		requestID = uuid.New().String()
+	}
+	ctx = contextkeys.WithRequestID(ctx, requestID)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, requestID))
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.loggerWith(ctx).Error("Panic in gRPC call", zap.String("method", info.FullMethod), zap.Any("panic", r), zap.Stack("stack"))
+			err = status.Error(codes.Internal, "internal error")
+		}
+
+		code := status.Code(err)
+		fields := []zap.Field{
+			zap.String("request_id", requestID),
+			zap.String("method", info.FullMethod),
+			zap.String("code", code.String()),
+			zap.Duration("latency_ms", time.Since(start)),
+		}
+		fields = append(fields, tracing.LogFields(ctx)...)
+
+		switch code {
+		case codes.OK:
+			s.log.Info("grpc_request_completed", fields...)
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+			s.log.Error("grpc_request_completed", fields...)
+		default:
+			s.log.Warn("grpc_request_completed", fields...)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// authenticate resolves the caller from the x-api-key metadata or a Bearer token in
+// authorization and checks the permission of the method, see authz.Authenticator. Each
+// attempt first spends a token of the auth budget of the client IP, as in the REST API.
+func (s *Server) authenticate(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !s.auth.Enabled {
+		return handler(ctx, req)
+	}
+	if err := s.takeToken(ctx, ratelimit.GroupAuth, ratelimit.ClientKey(clientIP(ctx))); err != nil {
+		return nil, err
+	}
+
+	log := s.loggerWith(ctx)
+	permission, ok := permissions[info.FullMethod]
+	if !ok {
+		log.Warn("Access denied", zap.String("reason", "no permission for "+info.FullMethod))
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+
+	rawKey := credentials(ctx)
+	if rawKey == "" {
+		log.Warn("Missing credentials")
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	principal, err := s.authn.Authenticate(ctx, rawKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthorized) {
+			log.Warn("Invalid api key")
+			return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+		}
+		log.Error("Failed to authenticate call", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	log = log.With(
+		zap.String("principal_id", principal.ID),
+		zap.String("principal_kind", principal.Kind),
+		zap.Strings("roles", principal.Roles),
+		zap.String("permission", string(permission)),
+		zap.String("method", info.FullMethod),
+	)
+
+	if err := s.authn.Authorize(ctx, principal, permission); err != nil {
+		log.Warn("Access denied", zap.Error(err))
+		if errors.Is(err, authz.ErrInsufficientScope) {
+			return nil, status.Error(codes.PermissionDenied, "insufficient scope")
+		}
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+
+	return handler(context.WithValue(ctx, principalKey{}, principal), req)
+}
+
+// rateLimit spends a token from the group budget of the method for the caller, keyed by
+// its principal or, for anonymous calls, by client IP, the same as the REST API.
+func (s *Server) rateLimit(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	group, ok := rateLimitGroups[info.FullMethod]
+	if !ok {
+		group = ratelimit.GroupSubscriptions
+	}
+	key := ratelimit.ClientKey(clientIP(ctx))
+	if principal, ok := ctx.Value(principalKey{}).(domain.Principal); ok {
+		key = ratelimit.PrincipalKey(principal)
+	}
+
+	if err := s.takeToken(ctx, group, key); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// takeToken spends a token from the group budget of key. A refusal is a
+// ResourceExhausted status with the seconds to wait in the retry-after header.
+func (s *Server) takeToken(ctx context.Context, group, key string) error {
+	if s.limiter == nil {
+		return nil
+	}
+
+	res, limited, err := s.limiter.Take(ctx, group, key)
+	if err != nil {
+		s.loggerWith(ctx, zap.String("group", group)).Error("Rate limit store failed, allowing call", zap.Error(err))
+		return nil
+	}
+	if !limited || res.Allowed {
+		return nil
+	}
+
+	retryAfter := strconv.Itoa(int(math.Ceil(res.RetryAfter.Seconds())))
+	_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterMetadata, retryAfter))
+	s.loggerWith(ctx, zap.String("group", group), zap.String("key", key)).Warn("Rate limit exceeded")
+	return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+}
+
+// dateFormat stores the date format of the date-format metadata in the context.
+func (s *Server) dateFormat(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	format, err := domain.ParseDateFormat(firstMetadata(ctx, dateFormatMetadata))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return handler(contextkeys.WithDateFormat(ctx, format), req)
+}
+
+func (s *Server) loggerWith(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	requestID, _ := contextkeys.RequestID(ctx)
+	base := []zap.Field{zap.String("request_id", requestID)}
+	base = append(base, tracing.LogFields(ctx)...)
+	return s.log.With(append(base, fields...)...)
+}
+
+// clientIP is the IP of the peer of the call, its whole address when it has no port.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func credentials(ctx context.Context) string {
+	if key := firstMetadata(ctx, apiKeyMetadata); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(firstMetadata(ctx, "authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,280 @@
+// Package grpcapi serves the subscriptions over gRPC, next to the REST API of package
+// handler and on top of the same service.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"tz/internal/authz"
+	"tz/internal/config"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/handler"
+	"tz/internal/ratelimit"
+	subscriptionv1 "tz/pkg/api/subscription/v1"
+	"tz/pkg/valid"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Deps are the collaborators of Server. APIKeys and Policy are used when auth is enabled;
+// Limiter is optional and may be nil.
+type Deps struct {
+	Service handler.SubscriptionServiceI
+	APIKeys handler.APIKeyServiceI
+	Policy  authz.Policy
+	Limiter *ratelimit.Limiter
+}
+
+type Server struct {
+	subscriptionv1.UnimplementedSubscriptionServiceServer
+
+	service handler.SubscriptionServiceI
+	authn   *authz.Authenticator
+	limiter *ratelimit.Limiter
+	auth    config.AuthConfig
+	log     *zap.Logger
+}
+
+func NewServer(deps Deps, auth config.AuthConfig, log *zap.Logger) *Server {
+	return &Server{
+		service: deps.Service,
+		authn:   authz.NewAuthenticator(auth.AdminToken, deps.APIKeys, deps.Policy),
+		limiter: deps.Limiter,
+		auth:    auth,
+		log:     log.Named("grpc"),
+	}
+}
+
+// Register adds the subscription service to srv.
+func (s *Server) Register(srv *grpc.Server) {
+	subscriptionv1.RegisterSubscriptionServiceServer(srv, s)
+}
+
+// Interceptor logs, authenticates, authorizes and rate limits the calls, the same as the
+// middleware of the REST API.
+func (s *Server) Interceptor() grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(s.logging, s.authenticate, s.rateLimit, s.dateFormat)
+}
+
+func (s *Server) CreateSubscription(ctx context.Context, req *subscriptionv1.CreateSubscriptionRequest) (*subscriptionv1.CreateSubscriptionResponse, error) {
+	log := s.loggerWith(ctx)
+	create := dto.CreateSubscriptionRequest{
+		ServiceID:   req.ServiceId,
+		ServiceName: req.GetServiceName(),
+		Price:       int(req.GetPrice()),
+		UserID:      req.GetUserId(),
+		StartDate:   req.GetStartDate(),
+		EndDate:     req.EndDate,
+		Category:    req.Category,
+		Tags:        req.GetTags(),
+	}
+	if req.BillingPeriodMonths != nil {
+		months := int(req.GetBillingPeriodMonths())
+		create.BillingPeriodMonths = &months
+	}
+
+	if err := valid.ValidateStruct(create); err != nil {
+		log.Warn("Validation failed for create subscription", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	subscription, err := s.service.CreateSubscription(ctx, create)
+	if err != nil {
+		return nil, s.statusError(log, "Failed to create subscription", err)
+	}
+	return &subscriptionv1.CreateSubscriptionResponse{Subscription: toSubscription(subscription)}, nil
+}
+
+func (s *Server) GetSubscription(ctx context.Context, req *subscriptionv1.GetSubscriptionRequest) (*subscriptionv1.GetSubscriptionResponse, error) {
+	log := s.loggerWith(ctx)
+	id, err := parseID(req.GetId())
+	if err != nil {
+		log.Warn("Invalid subscription ID", zap.Error(err))
+		return nil, err
+	}
+
+	subscription, err := s.service.SubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, s.statusError(log, "Failed to get subscription", err)
+	}
+	return &subscriptionv1.GetSubscriptionResponse{Subscription: toSubscription(subscription)}, nil
+}
+
+func (s *Server) ListSubscriptions(ctx context.Context, req *subscriptionv1.ListSubscriptionsRequest) (*subscriptionv1.ListSubscriptionsResponse, error) {
+	log := s.loggerWith(ctx)
+	filter := dto.SubscriptionFilter{
+		UserID:      req.UserId,
+		ServiceName: req.ServiceName,
+		Category:    req.Category,
+		Tags:        req.GetTags(),
+		Page:        int(req.GetPage()),
+		PageSize:    int(req.GetPageSize()),
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 10
+	}
+
+	subscriptions, err := s.service.Subscriptions(ctx, filter)
+	if err != nil {
+		return nil, s.statusError(log, "Failed to list subscriptions", err)
+	}
+
+	resp := &subscriptionv1.ListSubscriptionsResponse{
+		Total:         int64(subscriptions.Total),
+		Page:          int32(subscriptions.Page),
+		PageSize:      int32(subscriptions.PageSize),
+		HasNextPage:   subscriptions.HasNextPage,
+		HasPrevPage:   subscriptions.HasPrevPage,
+		Subscriptions: make([]*subscriptionv1.Subscription, len(subscriptions.Subscriptions)),
+	}
+	for i, subscription := range subscriptions.Subscriptions {
+		resp.Subscriptions[i] = toSubscription(subscription)
+	}
+	return resp, nil
+}
+
+func (s *Server) GetSubscriptionsCost(ctx context.Context, req *subscriptionv1.GetSubscriptionsCostRequest) (*subscriptionv1.GetSubscriptionsCostResponse, error) {
+	log := s.loggerWith(ctx)
+	request := dto.CostRequest{
+		UserID:            req.UserId,
+		ServiceName:       req.ServiceName,
+		From:              req.From,
+		To:                req.To,
+		EndMonthInclusive: req.EndMonthInclusive,
+		Proration:         req.Proration,
+		TimeZone:          req.TimeZone,
+		Category:          req.Category,
+		Tags:              req.GetTags(),
+		GroupBy:           req.GroupBy,
+	}
+
+	if err := valid.ValidateStruct(request); err != nil {
+		log.Warn("Validation failed for cost request", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	cost, err := s.service.SubscriptionsCost(ctx, request)
+	if err != nil {
+		return nil, s.statusError(log, "Failed to calculate total cost", err)
+	}
+	resp := &subscriptionv1.GetSubscriptionsCostResponse{
+		Total: int64(cost.Total),
+		Policy: &subscriptionv1.CostPolicy{
+			EndMonthInclusive: cost.Policy.EndMonthInclusive,
+			Proration:         cost.Policy.Proration,
+			TimeZone:          cost.Policy.TimeZone,
+		},
+	}
+	for _, group := range cost.Groups {
+		resp.Groups = append(resp.Groups, &subscriptionv1.CostGroup{Key: group.Key, Total: int64(group.Total)})
+	}
+	return resp, nil
+}
+
+func (s *Server) UpdateSubscription(ctx context.Context, req *subscriptionv1.UpdateSubscriptionRequest) (*subscriptionv1.UpdateSubscriptionResponse, error) {
+	log := s.loggerWith(ctx)
+	id, err := parseID(req.GetId())
+	if err != nil {
+		log.Warn("Invalid subscription ID in update", zap.Error(err))
+		return nil, err
+	}
+
+	update := dto.UpdateSubscriptionRequest{
+		ServiceID:   req.ServiceId,
+		ServiceName: req.ServiceName,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Category:    req.Category,
+		AddTags:     req.GetAddTags(),
+		RemoveTags:  req.GetRemoveTags(),
+	}
+	if req.Price != nil {
+		price := int(req.GetPrice())
+		update.Price = &price
+	}
+	if req.BillingPeriodMonths != nil {
+		months := int(req.GetBillingPeriodMonths())
+		update.BillingPeriodMonths = &months
+	}
+
+	if err := valid.ValidateStruct(update); err != nil {
+		log.Warn("Validation failed for update request", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	subscription, err := s.service.UpdateSubscription(ctx, id, update)
+	if err != nil {
+		return nil, s.statusError(log, "Failed to update subscription", err)
+	}
+	return &subscriptionv1.UpdateSubscriptionResponse{Subscription: toSubscription(subscription)}, nil
+}
+
+func (s *Server) DeleteSubscription(ctx context.Context, req *subscriptionv1.DeleteSubscriptionRequest) (*subscriptionv1.DeleteSubscriptionResponse, error) {
+	log := s.loggerWith(ctx)
+	id, err := parseID(req.GetId())
+	if err != nil {
+		log.Warn("Invalid subscription ID in delete", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.service.DeleteSubscription(ctx, id); err != nil {
+		return nil, s.statusError(log, "Failed to delete subscription", err)
+	}
+	return &subscriptionv1.DeleteSubscriptionResponse{}, nil
+}
+
+// statusError maps the errors of the service to status codes the way the REST API maps
+// them to HTTP statuses. Unexpected errors are logged with msg and hidden from the caller.
+func (s *Server) statusError(log *zap.Logger, msg string, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		log.Warn("Subscription not found", zap.Error(err))
+		return status.Error(codes.NotFound, "subscription not found")
+	case errors.Is(err, domain.ErrInvalidDate), errors.Is(err, domain.ErrInvalidDateFormat),
+		errors.Is(err, domain.ErrInvalidCostPolicy), errors.Is(err, domain.ErrInvalidTimeZone),
+		errors.Is(err, domain.ErrInvalidService), errors.Is(err, domain.ErrInvalidLabel):
+		log.Warn("Invalid argument", zap.Error(err))
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		log.Error(msg, zap.Error(err))
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func parseID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid subscription ID")
+	}
+	return parsed, nil
+}
+
+func toSubscription(s dto.SubscriptionOutput) *subscriptionv1.Subscription {
+	return &subscriptionv1.Subscription{
+		Id:                  s.ID,
+		ServiceId:           s.ServiceID,
+		ServiceName:         s.ServiceName,
+		Category:            s.Category,
+		Tags:                s.Tags,
+		Price:               int64(s.Price),
+		UserId:              s.UserID,
+		StartDate:           s.StartDate,
+		EndDate:             s.EndDate,
+		BillingPeriodMonths: int32(s.BillingPeriodMonths),
+		ExpiredAt:           s.ExpiredAt,
+		CreatedAt:           s.CreatedAt,
+		UpdatedAt:           s.UpdatedAt,
+	}
+}
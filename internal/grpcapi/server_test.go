@@ -0,0 +1,374 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+	"tz/internal/authz"
+	"tz/internal/config"
+	contextkeys "tz/internal/contextkey"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/handler/mocks"
+	"tz/internal/ratelimit"
+	subscriptionv1 "tz/pkg/api/subscription/v1"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// dial serves s over an in-memory connection and returns a client of it.
+func dial(t *testing.T, s *Server) subscriptionv1.SubscriptionServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(s.Interceptor())
+	s.Register(srv)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return subscriptionv1.NewSubscriptionServiceClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	id := uuid.New()
+	userID := uuid.NewString()
+	output := dto.SubscriptionOutput{ID: id.String(), ServiceName: "Netflix", Price: 500, UserID: userID, StartDate: "07-2025", BillingPeriodMonths: 1}
+
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error
+		setup    func(s *mocks.MockSubscriptionServiceI)
+		wantCode codes.Code
+	}{
+		{
+			name: "create",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				resp, err := c.CreateSubscription(ctx, &subscriptionv1.CreateSubscriptionRequest{
+					ServiceName: "Netflix", Price: 500, UserId: userID, StartDate: "07-2025", BillingPeriodMonths: ptr(int32(12)),
+				})
+				if err == nil && resp.GetSubscription().GetId() != id.String() {
+					return fmt.Errorf("subscription = %v", resp.GetSubscription())
+				}
+				return err
+			},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().CreateSubscription(gomock.Any(), dto.CreateSubscriptionRequest{
+					ServiceName: "Netflix", Price: 500, UserID: userID, StartDate: "07-2025", BillingPeriodMonths: ptr(12),
+				}).Return(output, nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "create without service name",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				_, err := c.CreateSubscription(ctx, &subscriptionv1.CreateSubscriptionRequest{Price: 500, UserId: userID, StartDate: "07-2025"})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "create with invalid date",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				_, err := c.CreateSubscription(ctx, &subscriptionv1.CreateSubscriptionRequest{ServiceName: "Netflix", Price: 500, UserId: userID, StartDate: "2025"})
+				return err
+			},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).Return(dto.SubscriptionOutput{}, domain.ErrInvalidDateFormat)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "get",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				resp, err := c.GetSubscription(ctx, &subscriptionv1.GetSubscriptionRequest{Id: id.String()})
+				if err == nil && resp.GetSubscription().GetServiceName() != "Netflix" {
+					return fmt.Errorf("subscription = %v", resp.GetSubscription())
+				}
+				return err
+			},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().SubscriptionByID(gomock.Any(), id).Return(output, nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "get invalid id",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				_, err := c.GetSubscription(ctx, &subscriptionv1.GetSubscriptionRequest{Id: "42"})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "get missing",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				_, err := c.GetSubscription(ctx, &subscriptionv1.GetSubscriptionRequest{Id: id.String()})
+				return err
+			},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().SubscriptionByID(gomock.Any(), id).Return(dto.SubscriptionOutput{}, domain.ErrNotFound)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "list with defaults",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				resp, err := c.ListSubscriptions(ctx, &subscriptionv1.ListSubscriptionsRequest{ServiceName: ptr("Netflix"), PageSize: 1000})
+				if err == nil && (resp.GetTotal() != 1 || len(resp.GetSubscriptions()) != 1) {
+					return fmt.Errorf("response = %v", resp)
+				}
+				return err
+			},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().Subscriptions(gomock.Any(), dto.SubscriptionFilter{ServiceName: ptr("Netflix"), Page: 1, PageSize: 10}).
+					Return(dto.MakeSubscriptionsOutput([]dto.SubscriptionOutput{output}, 1, 1, 10), nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "cost",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				resp, err := c.GetSubscriptionsCost(ctx, &subscriptionv1.GetSubscriptionsCostRequest{From: ptr("01-2025"), Proration: ptr("day")})
+				if err == nil && (resp.GetTotal() != 4800 || resp.GetPolicy().GetProration() != "day") {
+					return fmt.Errorf("response = %v", resp)
+				}
+				return err
+			},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().SubscriptionsCost(gomock.Any(), dto.CostRequest{From: ptr("01-2025"), Proration: ptr("day")}).
+					Return(dto.CostOutput{Total: 4800, Policy: dto.CostPolicyOutput{Proration: "day"}}, nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "cost with unknown proration",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				_, err := c.GetSubscriptionsCost(ctx, &subscriptionv1.GetSubscriptionsCostRequest{Proration: ptr("week")})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "cost with unknown time zone",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				_, err := c.GetSubscriptionsCost(ctx, &subscriptionv1.GetSubscriptionsCostRequest{TimeZone: ptr("Mars/Olympus")})
+				return err
+			},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().SubscriptionsCost(gomock.Any(), gomock.Any()).Return(dto.CostOutput{}, domain.ErrInvalidTimeZone)
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "update",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				_, err := c.UpdateSubscription(ctx, &subscriptionv1.UpdateSubscriptionRequest{Id: id.String(), Price: ptr(int64(599))})
+				return err
+			},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().UpdateSubscription(gomock.Any(), id, dto.UpdateSubscriptionRequest{Price: ptr(599)}).Return(output, nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "update with negative price",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				_, err := c.UpdateSubscription(ctx, &subscriptionv1.UpdateSubscriptionRequest{Id: id.String(), Price: ptr(int64(-1))})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "delete",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				_, err := c.DeleteSubscription(ctx, &subscriptionv1.DeleteSubscriptionRequest{Id: id.String()})
+				return err
+			},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().DeleteSubscription(gomock.Any(), id).Return(nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "delete fails",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				_, err := c.DeleteSubscription(ctx, &subscriptionv1.DeleteSubscriptionRequest{Id: id.String()})
+				return err
+			},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().DeleteSubscription(gomock.Any(), id).Return(errors.New("connection refused"))
+			},
+			wantCode: codes.Internal,
+		},
+		{
+			name: "unknown date format",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				ctx = metadata.AppendToOutgoingContext(ctx, dateFormatMetadata, "week")
+				_, err := c.GetSubscription(ctx, &subscriptionv1.GetSubscriptionRequest{Id: id.String()})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "day date format",
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				ctx = metadata.AppendToOutgoingContext(ctx, dateFormatMetadata, "day")
+				_, err := c.GetSubscription(ctx, &subscriptionv1.GetSubscriptionRequest{Id: id.String()})
+				return err
+			},
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().SubscriptionByID(gomock.Any(), id).DoAndReturn(func(ctx context.Context, _ uuid.UUID) (dto.SubscriptionOutput, error) {
+					if format := contextkeys.DateFormat(ctx); format != domain.DateFormatDay {
+						return dto.SubscriptionOutput{}, fmt.Errorf("date format = %s", format)
+					}
+					return output, nil
+				})
+			},
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := mocks.NewMockSubscriptionServiceI(gomock.NewController(t))
+			if tt.setup != nil {
+				tt.setup(service)
+			}
+			client := dial(t, NewServer(Deps{Service: service}, config.AuthConfig{}, zap.NewNop()))
+
+			err := tt.call(context.Background(), client)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("code = %s, want %s, error %v", code, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestServer_Auth(t *testing.T) {
+	id := uuid.New()
+	readOnly := domain.APIKey{ID: uuid.New(), Scopes: []domain.Scope{domain.ScopeSubscriptionsRead}, Roles: []string{"reader"}}
+	policy := authz.NewRolePolicy(authz.RoleConfig{Roles: map[string][]string{
+		"reader": {string(domain.PermSubscriptionsRead), string(domain.PermSubscriptionsDelete)},
+		"admin":  {"*"},
+	}})
+
+	tests := []struct {
+		name     string
+		md       []string
+		setup    func(s *mocks.MockSubscriptionServiceI, keys *mocks.MockAPIKeyServiceI)
+		call     func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error
+		wantCode codes.Code
+	}{
+		{name: "missing credentials", wantCode: codes.Unauthenticated},
+		{
+			name: "invalid key", md: []string{apiKeyMetadata, "bad"},
+			setup: func(_ *mocks.MockSubscriptionServiceI, keys *mocks.MockAPIKeyServiceI) {
+				keys.EXPECT().Authenticate(gomock.Any(), "bad").Return(domain.APIKey{}, domain.ErrUnauthorized)
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name: "key with scope", md: []string{"authorization", "Bearer reader"},
+			setup: func(s *mocks.MockSubscriptionServiceI, keys *mocks.MockAPIKeyServiceI) {
+				keys.EXPECT().Authenticate(gomock.Any(), "reader").Return(readOnly, nil)
+				s.EXPECT().SubscriptionByID(gomock.Any(), id).Return(dto.SubscriptionOutput{ID: id.String()}, nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "key without scope", md: []string{apiKeyMetadata, "reader"},
+			setup: func(_ *mocks.MockSubscriptionServiceI, keys *mocks.MockAPIKeyServiceI) {
+				keys.EXPECT().Authenticate(gomock.Any(), "reader").Return(readOnly, nil)
+			},
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				_, err := c.DeleteSubscription(ctx, &subscriptionv1.DeleteSubscriptionRequest{Id: id.String()})
+				return err
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "admin token", md: []string{apiKeyMetadata, "admin-token"},
+			setup: func(s *mocks.MockSubscriptionServiceI, _ *mocks.MockAPIKeyServiceI) {
+				s.EXPECT().DeleteSubscription(gomock.Any(), id).Return(nil)
+			},
+			call: func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+				_, err := c.DeleteSubscription(ctx, &subscriptionv1.DeleteSubscriptionRequest{Id: id.String()})
+				return err
+			},
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			service, keys := mocks.NewMockSubscriptionServiceI(ctrl), mocks.NewMockAPIKeyServiceI(ctrl)
+			if tt.setup != nil {
+				tt.setup(service, keys)
+			}
+			client := dial(t, NewServer(Deps{Service: service, APIKeys: keys, Policy: policy},
+				config.AuthConfig{Enabled: true, AdminToken: "admin-token"}, zap.NewNop()))
+
+			call := tt.call
+			if call == nil {
+				call = func(ctx context.Context, c subscriptionv1.SubscriptionServiceClient) error {
+					_, err := c.GetSubscription(ctx, &subscriptionv1.GetSubscriptionRequest{Id: id.String()})
+					return err
+				}
+			}
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tt.md...)
+			if code := status.Code(call(ctx, client)); code != tt.wantCode {
+				t.Errorf("code = %s, want %s", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	limiter, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), map[string]ratelimit.Limit{
+		ratelimit.GroupCost: {Requests: 1, Period: time.Hour},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := mocks.NewMockSubscriptionServiceI(gomock.NewController(t))
+	service.EXPECT().SubscriptionsCost(gomock.Any(), gomock.Any()).Return(dto.CostOutput{}, nil)
+	service.EXPECT().Subscriptions(gomock.Any(), gomock.Any()).Return(dto.SubscriptionsOutput{}, nil)
+	client := dial(t, NewServer(Deps{Service: service, Limiter: limiter}, config.AuthConfig{}, zap.NewNop()))
+
+	ctx := context.Background()
+	for _, want := range []codes.Code{codes.OK, codes.ResourceExhausted} {
+		var header metadata.MD
+		_, err := client.GetSubscriptionsCost(ctx, &subscriptionv1.GetSubscriptionsCostRequest{}, grpc.Header(&header))
+		if code := status.Code(err); code != want {
+			t.Errorf("GetSubscriptionsCost() code = %s, want %s", code, want)
+		}
+		if want == codes.ResourceExhausted && len(header.Get(retryAfterMetadata)) == 0 {
+			t.Errorf("refused call has no %s header", retryAfterMetadata)
+		}
+	}
+	if _, err := client.ListSubscriptions(ctx, &subscriptionv1.ListSubscriptionsRequest{}); err != nil {
+		t.Errorf("ListSubscriptions() error = %v", err)
+	}
+}
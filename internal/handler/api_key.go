@@ -0,0 +1,123 @@
+package handler
+
+//go:generate go tool mockgen -source=api_key.go -destination=mocks/api_key.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type APIKeyServiceI interface {
+	IssueAPIKey(ctx context.Context, req dto.CreateAPIKeyRequest) (dto.IssuedAPIKeyOutput, error)
+	APIKeys(ctx context.Context) ([]dto.APIKeyOutput, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+	Authenticate(ctx context.Context, rawKey string) (domain.APIKey, error)
+}
+
+// @Summary Issue an API key
+// @Description Create an API key with the given scopes. The plain key is returned only once.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param api_key body dto.CreateAPIKeyRequest true "API key data"
+// @Success 201 {object} dto.IssuedAPIKeyOutput
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/api-keys [post]
+func (h *SubscriptionHandler) issueAPIKey(c *gin.Context) {
+	log := h.loggerWith(c)
+	var req dto.CreateAPIKeyRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Warn("Failed to bind issue api key request")
+		h.errorResponse(c, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	if err := valid.ValidateStruct(req); err != nil {
+		log.Warn("Validation failed for issue api key", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	key, err := h.apiKeys.IssueAPIKey(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidScope) || errors.Is(err, domain.ErrInvalidDate) {
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Failed to issue api key", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// @Summary List API keys
+// @Description List issued API keys without their secrets
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} dto.APIKeyOutput
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/api-keys [get]
+func (h *SubscriptionHandler) listAPIKeys(c *gin.Context) {
+	log := h.loggerWith(c)
+
+	keys, err := h.apiKeys.APIKeys(c.Request.Context())
+	if err != nil {
+		log.Error("Failed to list api keys", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// @Summary Revoke an API key
+// @Description Revoke an API key by ID
+// @Tags admin
+// @Security ApiKeyAuth
+// @Param id path string true "API key ID (UUID)"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/api-keys/{id} [delete]
+func (h *SubscriptionHandler) revokeAPIKey(c *gin.Context) {
+	log := h.loggerWith(c)
+
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid api key ID in revoke", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid api key ID")
+		return
+	}
+
+	if err := h.apiKeys.RevokeAPIKey(c.Request.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "api key not found")
+			return
+		}
+		log.Error("Failed to revoke api key", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"tz/internal/authz"
+	"tz/internal/domain"
+	"tz/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	principalKey = "principal"
+	apiKeyHeader = "X-API-Key"
+)
+
+// authenticate resolves the caller from the X-API-Key header or a Bearer token, see
+// authz.Authenticator. Each attempt first spends a token of the auth budget of the
+// client IP, so that floods of invalid credentials are throttled before any lookup.
+func (h *SubscriptionHandler) authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !h.auth.Enabled {
+			c.Next()
+			return
+		}
+		if !h.takeToken(c, ratelimit.GroupAuth, ratelimit.ClientKey(c.ClientIP())) {
+			return
+		}
+
+		log := h.loggerWith(c)
+		rawKey := credentials(c.Request)
+		if rawKey == "" {
+			log.Warn("Missing credentials")
+			h.errorResponse(c, http.StatusUnauthorized, "missing credentials")
+			return
+		}
+
+		principal, err := h.authn.Authenticate(c.Request.Context(), rawKey)
+		if err != nil {
+			if errors.Is(err, domain.ErrUnauthorized) {
+				log.Warn("Invalid api key")
+				h.errorResponse(c, http.StatusUnauthorized, "invalid credentials")
+				return
+			}
+			log.Error("Failed to authenticate request", zap.Error(err))
+			h.errorResponse(c, http.StatusInternalServerError, "internal error")
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Next()
+	}
+}
+
+// authorize rejects requests whose principal lacks the scope backing the permission
+// or is not granted the permission by the policy.
+func (h *SubscriptionHandler) authorize(permission domain.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.allow(c, permission)
+		switch {
+		case err == nil:
+			c.Next()
+		case errors.Is(err, domain.ErrUnauthorized):
+			h.errorResponse(c, http.StatusUnauthorized, "missing credentials")
+		case errors.Is(err, authz.ErrInsufficientScope):
+			h.errorResponse(c, http.StatusForbidden, "insufficient scope")
+		default:
+			h.errorResponse(c, http.StatusForbidden, "forbidden")
+		}
+	}
+}
+
+// allow checks the permission for the principal of the request, for handlers that
+// authorize more than one permission per request. A denial is logged and returned as
+// domain.ErrUnauthorized without a principal, domain.ErrForbidden otherwise.
+func (h *SubscriptionHandler) allow(c *gin.Context, permission domain.Permission) error {
+	if !h.auth.Enabled {
+		return nil
+	}
+
+	principal, ok := h.principal(c)
+	if !ok {
+		h.loggerWith(c).Warn("Authorization without principal")
+		return domain.ErrUnauthorized
+	}
+
+	log := h.loggerWith(c,
+		zap.String("principal_id", principal.ID),
+		zap.String("principal_kind", principal.Kind),
+		zap.Strings("roles", principal.Roles),
+		zap.String("permission", string(permission)),
+		zap.String("route", c.FullPath()),
+	)
+
+	if err := h.authn.Authorize(c.Request.Context(), principal, permission); err != nil {
+		log.Warn("Access denied", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (h *SubscriptionHandler) principal(c *gin.Context) (domain.Principal, bool) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return domain.Principal{}, false
+	}
+	principal, ok := value.(domain.Principal)
+	return principal, ok
+}
+
+func credentials(r *http.Request) string {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return key
+	}
+	auth := r.Header.Get("Authorization")
+	if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
@@ -0,0 +1,250 @@
+package handler
+
+//go:generate go tool mockgen -source=budget.go -destination=mocks/budget.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type BudgetServiceI interface {
+	CreateBudget(ctx context.Context, req dto.CreateBudgetRequest) (dto.BudgetOutput, error)
+	Budget(ctx context.Context, id uuid.UUID) (dto.BudgetOutput, error)
+	Budgets(ctx context.Context, filter dto.BudgetFilter) ([]dto.BudgetOutput, error)
+	UpdateBudget(ctx context.Context, id uuid.UUID, req dto.UpdateBudgetRequest) (dto.BudgetOutput, error)
+	DeleteBudget(ctx context.Context, id uuid.UUID) error
+	BudgetAlerts(ctx context.Context, budgetID uuid.UUID) ([]dto.BudgetAlertOutput, error)
+}
+
+// @Summary Create a budget
+// @Description Cap the monthly or yearly subscription spend of a user, optionally for some services only
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param budget body dto.CreateBudgetRequest true "Budget"
+// @Success 200 {object} dto.BudgetOutput
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets [post]
+func (h *SubscriptionHandler) createBudget(c *gin.Context) {
+	log := h.loggerWith(c)
+	var req dto.CreateBudgetRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Warn("Failed to bind create budget request")
+		h.errorResponse(c, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	if err := valid.ValidateStruct(req); err != nil {
+		log.Warn("Validation failed for create budget", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	budget, err := h.budgets.CreateBudget(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidBudget) {
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Failed to create budget", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+// @Summary List budgets
+// @Description List budgets, optionally of one user
+// @Tags budgets
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id query string false "User ID (UUID)"
+// @Success 200 {array} dto.BudgetOutput
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets [get]
+func (h *SubscriptionHandler) listBudgets(c *gin.Context) {
+	log := h.loggerWith(c)
+	var filter dto.BudgetFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		log.Warn("Failed to bind budget filter")
+		h.errorResponse(c, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	budgets, err := h.budgets.Budgets(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidBudget) {
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Failed to list budgets", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, budgets)
+}
+
+// @Summary Get a budget
+// @Tags budgets
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Budget ID (UUID)"
+// @Success 200 {object} dto.BudgetOutput
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets/{id} [get]
+func (h *SubscriptionHandler) budget(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid budget ID", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid budget ID")
+		return
+	}
+
+	budget, err := h.budgets.Budget(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrBudgetNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "budget not found")
+			return
+		}
+		log.Error("Failed to get budget", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+// @Summary Update a budget
+// @Description Partially update a budget. Alerts already raised are kept.
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Budget ID (UUID)"
+// @Param budget body dto.UpdateBudgetRequest true "Fields to update"
+// @Success 200 {object} dto.BudgetOutput
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets/{id} [patch]
+func (h *SubscriptionHandler) updateBudget(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid budget ID in update", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid budget ID")
+		return
+	}
+
+	var req dto.UpdateBudgetRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Warn("Failed to bind update budget request")
+		h.errorResponse(c, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	if err := valid.ValidateStruct(req); err != nil {
+		log.Warn("Validation failed for update budget", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	budget, err := h.budgets.UpdateBudget(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrBudgetNotFound):
+			h.errorResponse(c, http.StatusNotFound, "budget not found")
+		case errors.Is(err, domain.ErrInvalidBudget):
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			log.Error("Failed to update budget", zap.Error(err))
+			h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+// @Summary Delete a budget
+// @Description Delete a budget together with its alerts
+// @Tags budgets
+// @Security ApiKeyAuth
+// @Param id path string true "Budget ID (UUID)"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets/{id} [delete]
+func (h *SubscriptionHandler) deleteBudget(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid budget ID in delete", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid budget ID")
+		return
+	}
+
+	if err := h.budgets.DeleteBudget(c.Request.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrBudgetNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "budget not found")
+			return
+		}
+		log.Error("Failed to delete budget", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List budget alerts
+// @Description List the alerts raised when the actual or forecast spend of a budget crossed 80% or 100%, newest period first
+// @Tags budgets
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Budget ID (UUID)"
+// @Param date_format query string false "Date format of the response" Enums(month, day) default(month)
+// @Success 200 {array} dto.BudgetAlertOutput
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets/{id}/alerts [get]
+func (h *SubscriptionHandler) budgetAlerts(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid budget ID in alerts", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid budget ID")
+		return
+	}
+
+	alerts, err := h.budgets.BudgetAlerts(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrBudgetNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "budget not found")
+			return
+		}
+		log.Error("Failed to list budget alerts", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, alerts)
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"tz/internal/config"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/handler/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestBudget_Routes(t *testing.T) {
+	id := uuid.New()
+	target := "/budgets/" + id.String()
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		setup      func(s *mocks.MockBudgetServiceI)
+		wantStatus int
+	}{
+		{
+			name: "create", method: http.MethodPost, target: "/budgets/", body: `{"user_id":"u","amount":2000,"service_names":["Netflix"]}`,
+			setup: func(s *mocks.MockBudgetServiceI) {
+				s.EXPECT().CreateBudget(gomock.Any(), dto.CreateBudgetRequest{UserID: "u", Amount: 2000, ServiceNames: []string{"Netflix"}}).
+					Return(dto.BudgetOutput{}, fmt.Errorf("%w: invalid user_id", domain.ErrInvalidBudget))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "create without amount", method: http.MethodPost, target: "/budgets/", body: `{"user_id":"u"}`, wantStatus: http.StatusBadRequest},
+		{name: "create with unknown period", method: http.MethodPost, target: "/budgets/", body: `{"user_id":"u","amount":1,"period":"week"}`, wantStatus: http.StatusBadRequest},
+		{
+			name: "list", method: http.MethodGet, target: "/budgets/?user_id=u",
+			setup: func(s *mocks.MockBudgetServiceI) {
+				s.EXPECT().Budgets(gomock.Any(), dto.BudgetFilter{UserID: ptr("u")}).Return([]dto.BudgetOutput{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{name: "get invalid id", method: http.MethodGet, target: "/budgets/42", wantStatus: http.StatusBadRequest},
+		{
+			name: "get missing", method: http.MethodGet, target: target,
+			setup: func(s *mocks.MockBudgetServiceI) {
+				s.EXPECT().Budget(gomock.Any(), id).Return(dto.BudgetOutput{}, domain.ErrBudgetNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "update", method: http.MethodPatch, target: target, body: `{"amount":3000}`,
+			setup: func(s *mocks.MockBudgetServiceI) {
+				s.EXPECT().UpdateBudget(gomock.Any(), id, dto.UpdateBudgetRequest{Amount: ptr(3000)}).Return(dto.BudgetOutput{Amount: 3000}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{name: "update with zero amount", method: http.MethodPatch, target: target, body: `{"amount":0}`, wantStatus: http.StatusBadRequest},
+		{
+			name: "delete", method: http.MethodDelete, target: target,
+			setup: func(s *mocks.MockBudgetServiceI) {
+				s.EXPECT().DeleteBudget(gomock.Any(), id).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "alerts", method: http.MethodGet, target: target + "/alerts?date_format=day",
+			setup: func(s *mocks.MockBudgetServiceI) {
+				s.EXPECT().BudgetAlerts(gomock.Any(), id).Return([]dto.BudgetAlertOutput{{Threshold: 80}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "alerts of a missing budget", method: http.MethodGet, target: target + "/alerts",
+			setup: func(s *mocks.MockBudgetServiceI) {
+				s.EXPECT().BudgetAlerts(gomock.Any(), id).Return(nil, domain.ErrBudgetNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			budgets := mocks.NewMockBudgetServiceI(gomock.NewController(t))
+			router := NewHandler(Deps{Budgets: budgets}, config.AuthConfig{}, zap.NewNop()).Init()
+			if tt.setup != nil {
+				tt.setup(budgets)
+			}
+
+			w := serve(router, tt.method, tt.target, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
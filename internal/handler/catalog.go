@@ -0,0 +1,210 @@
+package handler
+
+//go:generate go tool mockgen -source=catalog.go -destination=mocks/catalog.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type CatalogServiceI interface {
+	CreateService(ctx context.Context, req dto.CreateServiceRequest) (dto.ServiceOutput, error)
+	Service(ctx context.Context, id uuid.UUID) (dto.ServiceOutput, error)
+	Services(ctx context.Context, filter dto.ServiceFilter) ([]dto.ServiceOutput, error)
+	UpdateService(ctx context.Context, id uuid.UUID, req dto.UpdateServiceRequest) (dto.ServiceOutput, error)
+	DeleteService(ctx context.Context, id uuid.UUID) error
+}
+
+// @Summary Add a service to the catalog
+// @Description Add a service with its canonical name, aliases, category, URL and default price. New subscriptions whose service name matches the name or an alias are linked to it.
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param service body dto.CreateServiceRequest true "Service"
+// @Success 200 {object} dto.ServiceOutput
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /services [post]
+func (h *SubscriptionHandler) createService(c *gin.Context) {
+	log := h.loggerWith(c)
+	var req dto.CreateServiceRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Warn("Failed to bind create service request")
+		h.errorResponse(c, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	if err := valid.ValidateStruct(req); err != nil {
+		log.Warn("Validation failed for create service", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	svc, err := h.catalog.CreateService(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidService) {
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Failed to create service", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, svc)
+}
+
+// @Summary List services
+// @Description List the services of the catalog by name, optionally of one category
+// @Tags services
+// @Produce json
+// @Security ApiKeyAuth
+// @Param category query string false "Category"
+// @Success 200 {array} dto.ServiceOutput
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /services [get]
+func (h *SubscriptionHandler) listServices(c *gin.Context) {
+	log := h.loggerWith(c)
+	var filter dto.ServiceFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		log.Warn("Failed to bind service filter")
+		h.errorResponse(c, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	services, err := h.catalog.Services(c.Request.Context(), filter)
+	if err != nil {
+		log.Error("Failed to list services", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, services)
+}
+
+// @Summary Get a service
+// @Tags services
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Service ID (UUID)"
+// @Success 200 {object} dto.ServiceOutput
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /services/{id} [get]
+func (h *SubscriptionHandler) catalogService(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid service ID", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid service ID")
+		return
+	}
+
+	svc, err := h.catalog.Service(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrServiceNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "service not found")
+			return
+		}
+		log.Error("Failed to get service", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, svc)
+}
+
+// @Summary Update a service
+// @Description Partially update a service. Aliases replace the current ones. Subscriptions keep the service name they were created with.
+// @Tags services
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Service ID (UUID)"
+// @Param service body dto.UpdateServiceRequest true "Fields to update"
+// @Success 200 {object} dto.ServiceOutput
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /services/{id} [patch]
+func (h *SubscriptionHandler) updateService(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid service ID in update", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid service ID")
+		return
+	}
+
+	var req dto.UpdateServiceRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Warn("Failed to bind update service request")
+		h.errorResponse(c, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	if err := valid.ValidateStruct(req); err != nil {
+		log.Warn("Validation failed for update service", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	svc, err := h.catalog.UpdateService(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrServiceNotFound):
+			h.errorResponse(c, http.StatusNotFound, "service not found")
+		case errors.Is(err, domain.ErrInvalidService):
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			log.Error("Failed to update service", zap.Error(err))
+			h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, svc)
+}
+
+// @Summary Delete a service
+// @Description Delete a service with its aliases. Its subscriptions keep their service name and lose the link to the catalog.
+// @Tags services
+// @Security ApiKeyAuth
+// @Param id path string true "Service ID (UUID)"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /services/{id} [delete]
+func (h *SubscriptionHandler) deleteService(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid service ID in delete", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid service ID")
+		return
+	}
+
+	if err := h.catalog.DeleteService(c.Request.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrServiceNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "service not found")
+			return
+		}
+		log.Error("Failed to delete service", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"tz/internal/config"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/handler/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCatalog_Routes(t *testing.T) {
+	id := uuid.New()
+	target := "/services/" + id.String()
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		setup      func(s *mocks.MockCatalogServiceI)
+		wantStatus int
+	}{
+		{
+			name: "create", method: http.MethodPost, target: "/services/", body: `{"name":"Netflix","aliases":["Нетфликс"],"default_price":799}`,
+			setup: func(s *mocks.MockCatalogServiceI) {
+				s.EXPECT().CreateService(gomock.Any(), dto.CreateServiceRequest{Name: "Netflix", Aliases: []string{"Нетфликс"}, DefaultPrice: 799}).
+					Return(dto.ServiceOutput{ID: id.String()}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "create with a taken name", method: http.MethodPost, target: "/services/", body: `{"name":"netflix"}`,
+			setup: func(s *mocks.MockCatalogServiceI) {
+				s.EXPECT().CreateService(gomock.Any(), gomock.Any()).
+					Return(dto.ServiceOutput{}, fmt.Errorf("%w: \"netflix\" is already a name", domain.ErrInvalidService))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "create without name", method: http.MethodPost, target: "/services/", body: `{"category":"music"}`, wantStatus: http.StatusBadRequest},
+		{name: "create with negative price", method: http.MethodPost, target: "/services/", body: `{"name":"Kion","default_price":-1}`, wantStatus: http.StatusBadRequest},
+		{
+			name: "list", method: http.MethodGet, target: "/services/?category=music",
+			setup: func(s *mocks.MockCatalogServiceI) {
+				s.EXPECT().Services(gomock.Any(), dto.ServiceFilter{Category: ptr("music")}).Return([]dto.ServiceOutput{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{name: "get invalid id", method: http.MethodGet, target: "/services/42", wantStatus: http.StatusBadRequest},
+		{
+			name: "get missing", method: http.MethodGet, target: target,
+			setup: func(s *mocks.MockCatalogServiceI) {
+				s.EXPECT().Service(gomock.Any(), id).Return(dto.ServiceOutput{}, domain.ErrServiceNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "update", method: http.MethodPatch, target: target, body: `{"aliases":[]}`,
+			setup: func(s *mocks.MockCatalogServiceI) {
+				s.EXPECT().UpdateService(gomock.Any(), id, dto.UpdateServiceRequest{Aliases: &[]string{}}).Return(dto.ServiceOutput{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{name: "update with invalid url", method: http.MethodPatch, target: target, body: `{"url":"netflix"}`, wantStatus: http.StatusBadRequest},
+		{
+			name: "delete missing", method: http.MethodDelete, target: target,
+			setup: func(s *mocks.MockCatalogServiceI) {
+				s.EXPECT().DeleteService(gomock.Any(), id).Return(domain.ErrServiceNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			catalog := mocks.NewMockCatalogServiceI(gomock.NewController(t))
+			router := NewHandler(Deps{Catalog: catalog}, config.AuthConfig{}, zap.NewNop()).Init()
+			if tt.setup != nil {
+				tt.setup(catalog)
+			}
+
+			w := serve(router, tt.method, tt.target, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
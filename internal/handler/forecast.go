@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// @Summary Forecast subscription spend
+// @Description Project the charges of the matched subscriptions for each month, starting with the current one. Open subscriptions continue, end dates, billing periods and scheduled price changes are applied.
+// @Tags subscriptions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id query string false "User ID (UUID)"
+// @Param service_name query string false "Service name"
+// @Param months query int false "Number of months" default(12) minimum(1) maximum(60)
+// @Param tz query string false "IANA time zone of the current month, overrides the user and cost.time_zone zones"
+// @Success 200 {object} dto.ForecastOutput
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /subscriptions/forecast [get]
+func (h *SubscriptionHandler) forecast(c *gin.Context) {
+	log := h.loggerWith(c)
+	var req dto.ForecastRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Warn("Failed to bind forecast request")
+		h.errorResponse(c, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	if err := valid.ValidateStruct(req); err != nil {
+		log.Warn("Validation failed for forecast request", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	forecast, err := h.service.Forecast(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTimeZone) {
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Failed to forecast spend", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, forecast)
+}
+
+// @Summary Schedule a price change
+// @Description Set a new price for the charges of a subscription from the month of effective_from on
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Subscription ID (UUID)"
+// @Param price_change body dto.CreatePriceChangeRequest true "Price change"
+// @Param date_format query string false "Date format of the response" Enums(month, day) default(month)
+// @Success 201 {object} dto.PriceChangeOutput
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /subscriptions/{id}/price-changes [post]
+func (h *SubscriptionHandler) createPriceChange(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid subscription ID in price change", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+
+	var req dto.CreatePriceChangeRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Warn("Failed to bind price change request")
+		h.errorResponse(c, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	if err := valid.ValidateStruct(req); err != nil {
+		log.Warn("Validation failed for price change", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	change, err := h.service.CreatePriceChange(c.Request.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "subscription not found")
+			return
+		}
+		if isInvalidDate(err) {
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Failed to create price change", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusCreated, change)
+}
+
+// @Summary List price changes
+// @Description List the scheduled price changes of a subscription by effective date
+// @Tags subscriptions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Subscription ID (UUID)"
+// @Param date_format query string false "Date format of the response" Enums(month, day) default(month)
+// @Success 200 {array} dto.PriceChangeOutput
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /subscriptions/{id}/price-changes [get]
+func (h *SubscriptionHandler) listPriceChanges(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid subscription ID in price changes", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+
+	changes, err := h.service.PriceChanges(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "subscription not found")
+			return
+		}
+		log.Error("Failed to list price changes", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
+
+// @Summary Delete a price change
+// @Description Cancel a scheduled price change
+// @Tags subscriptions
+// @Security ApiKeyAuth
+// @Param id path string true "Subscription ID (UUID)"
+// @Param change_id path string true "Price change ID (UUID)"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /subscriptions/{id}/price-changes/{change_id} [delete]
+func (h *SubscriptionHandler) deletePriceChange(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid subscription ID in price change delete", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+	changeID, err := h.parseID(c, "change_id")
+	if err != nil {
+		log.Warn("Invalid price change ID", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid price change ID")
+		return
+	}
+
+	if err := h.service.DeletePriceChange(c.Request.Context(), id, changeID); err != nil {
+		if errors.Is(err, domain.ErrPriceChangeNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "price change not found")
+			return
+		}
+		log.Error("Failed to delete price change", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List upcoming renewals and endings
+// @Description List the subscriptions that renew or reach their end date from today for the next months, with the charges expected in that window
+// @Tags subscriptions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id query string false "User ID (UUID)"
+// @Param service_name query string false "Service name"
+// @Param within query int false "Window length in months" default(1) minimum(1) maximum(24)
+// @Param tz query string false "IANA time zone of today, overrides the user and cost.time_zone zones"
+// @Param date_format query string false "Date format of the response" Enums(month, day) default(month)
+// @Success 200 {object} dto.UpcomingOutput
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /subscriptions/upcoming [get]
+func (h *SubscriptionHandler) upcoming(c *gin.Context) {
+	log := h.loggerWith(c)
+	var req dto.UpcomingRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Warn("Failed to bind upcoming request")
+		h.errorResponse(c, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	if err := valid.ValidateStruct(req); err != nil {
+		log.Warn("Validation failed for upcoming request", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	upcoming, err := h.service.Upcoming(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTimeZone) {
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Failed to list upcoming subscriptions", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, upcoming)
+}
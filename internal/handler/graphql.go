@@ -0,0 +1,58 @@
+package handler
+
+//go:generate go tool mockgen -source=graphql.go -destination=mocks/graphql.go -package=mocks
+
+import (
+	"context"
+	"net/http"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/ratelimit"
+	"tz/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type GraphQLI interface {
+	Execute(ctx context.Context, req dto.GraphQLRequest, authorize func(domain.Permission) error) dto.GraphQLResponse
+	// SelectsCost reports whether the operation of req selects a cost field.
+	SelectsCost(req dto.GraphQLRequest) bool
+}
+
+// @Summary Run a GraphQL query
+// @Description Query subscriptions, users with their subscriptions and costs in one request. Fields need the permissions of the matching REST endpoints; a denied field is null with a FORBIDDEN error. Queries deeper or more complex than the configured limits are rejected with QUERY_TOO_DEEP or QUERY_TOO_COMPLEX. Queries selecting cost fields also spend the rate limit budget of GET /subscriptions/cost. Errors of a query are returned with status 200, with their code in extensions.code. The schema can be read by introspection.
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param query body dto.GraphQLRequest true "Query"
+// @Param date_format query string false "Date format of the subscriptions" Enums(month, day) default(month)
+// @Success 200 {object} dto.GraphQLResponse
+// @Failure 400 {object} map[string]string
+// @Router /graphql [post]
+func (h *SubscriptionHandler) graphQL(c *gin.Context) {
+	log := h.loggerWith(c)
+	var req dto.GraphQLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warn("Failed to bind graphql request", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := valid.ValidateStruct(req); err != nil {
+		log.Warn("Validation failed for graphql request", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Cost fields compute the same as GET /subscriptions/cost and spend its budget too.
+	if h.limiter != nil && h.graphql.SelectsCost(req) && !h.takeToken(c, ratelimit.GroupCost, h.rateLimitKey(c)) {
+		return
+	}
+
+	resp := h.graphql.Execute(c.Request.Context(), req, func(permission domain.Permission) error {
+		return h.allow(c, permission)
+	})
+	c.JSON(http.StatusOK, resp)
+}
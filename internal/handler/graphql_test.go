@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"tz/internal/authz"
+	"tz/internal/config"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/handler/mocks"
+	"tz/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestGraphQL(t *testing.T) {
+	reader := domain.APIKey{ID: uuid.New(), Scopes: []domain.Scope{domain.ScopeSubscriptionsRead}, Roles: []string{"reader"}}
+	policy := authz.NewRolePolicy(authz.RoleConfig{Roles: map[string][]string{
+		"reader": {string(domain.PermSubscriptionsRead), string(domain.PermCostRead)},
+	}})
+	query := `{"query":"{ subscriptions { total } cost { total } }"}`
+
+	tests := []struct {
+		name       string
+		auth       config.AuthConfig
+		apiKey     string
+		body       string
+		setup      func(g *mocks.MockGraphQLI, keys *mocks.MockAPIKeyServiceI)
+		wantStatus int
+		// wantDenied are the permissions the handler denies to the query.
+		wantDenied []domain.Permission
+	}{
+		{
+			name:       "auth disabled",
+			body:       query,
+			setup:      func(g *mocks.MockGraphQLI, _ *mocks.MockAPIKeyServiceI) {},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "key without cost scope",
+			auth:   config.AuthConfig{Enabled: true},
+			apiKey: "reader",
+			body:   query,
+			setup: func(_ *mocks.MockGraphQLI, keys *mocks.MockAPIKeyServiceI) {
+				keys.EXPECT().Authenticate(gomock.Any(), "reader").Return(reader, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantDenied: []domain.Permission{domain.PermCostRead},
+		},
+		{
+			name:       "missing credentials",
+			auth:       config.AuthConfig{Enabled: true},
+			body:       query,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid body",
+			body:       `{"query":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing query",
+			body:       `{"variables":{}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+			graphql, keys := mocks.NewMockGraphQLI(ctrl), mocks.NewMockAPIKeyServiceI(ctrl)
+
+			var denied []domain.Permission
+			if tt.setup != nil {
+				tt.setup(graphql, keys)
+				graphql.EXPECT().Execute(gomock.Any(), dto.GraphQLRequest{Query: "{ subscriptions { total } cost { total } }"}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ dto.GraphQLRequest, authorize func(domain.Permission) error) dto.GraphQLResponse {
+						for _, permission := range []domain.Permission{domain.PermSubscriptionsRead, domain.PermCostRead} {
+							if err := authorize(permission); err != nil {
+								if !errors.Is(err, domain.ErrForbidden) {
+									t.Errorf("authorize(%s) error = %v, want forbidden", permission, err)
+								}
+								denied = append(denied, permission)
+							}
+						}
+						return dto.GraphQLResponse{Data: map[string]any{"subscriptions": nil}}
+					})
+			}
+
+			router := NewHandler(Deps{GraphQL: graphql, APIKeys: keys, Policy: policy}, tt.auth, zap.NewNop()).Init()
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.apiKey != "" {
+				req.Header.Set(apiKeyHeader, tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if len(denied) != len(tt.wantDenied) || len(denied) > 0 && denied[0] != tt.wantDenied[0] {
+				t.Errorf("denied = %v, want %v", denied, tt.wantDenied)
+			}
+			if w.Code == http.StatusOK {
+				var resp dto.GraphQLResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Data == nil {
+					t.Errorf("body = %s, want data", w.Body)
+				}
+			}
+		})
+	}
+}
+
+func TestGraphQL_CostRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), map[string]ratelimit.Limit{
+		ratelimit.GroupCost: {Requests: 1, Period: time.Hour},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graphql := mocks.NewMockGraphQLI(gomock.NewController(t))
+	graphql.EXPECT().SelectsCost(gomock.Any()).Return(true).Times(2)
+	graphql.EXPECT().Execute(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.GraphQLResponse{Data: map[string]any{}})
+	router := NewHandler(Deps{GraphQL: graphql, Limiter: limiter}, config.AuthConfig{}, zap.NewNop()).Init()
+
+	for _, want := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ cost { total } }"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != want {
+			t.Errorf("status = %d, want %d", w.Code, want)
+		}
+	}
+}
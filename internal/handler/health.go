@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"tz/internal/health"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type HealthI interface {
+	Ready(ctx context.Context) health.Report
+}
+
+// @Summary Liveness probe
+// @Description Reports that the process is running. Does not check dependencies.
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /livez [get]
+func (h *SubscriptionHandler) livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+}
+
+// @Summary Readiness probe
+// @Description Runs dependency checks (database, migrations, ...) and reports each of them.
+// @Description Fails once graceful shutdown has started.
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /readyz [get]
+func (h *SubscriptionHandler) readyz(c *gin.Context) {
+	if h.health == nil {
+		c.JSON(http.StatusOK, health.Report{Status: health.StatusOK, Checks: map[string]health.CheckResult{}})
+		return
+	}
+
+	report := h.health.Ready(c.Request.Context())
+	if report.Status != health.StatusOK {
+		h.loggerWith(c).Warn("Readiness check failed", zap.Any("checks", report.Checks))
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
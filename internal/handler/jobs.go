@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+	"tz/internal/dto"
+	"tz/internal/scheduler"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobsI interface {
+	Statuses() []scheduler.Status
+}
+
+// @Summary List scheduled jobs
+// @Description List the background jobs of this replica with their schedule and last run. Empty when the scheduler is disabled.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} dto.JobStatusOutput
+// @Router /admin/jobs [get]
+func (h *SubscriptionHandler) listJobs(c *gin.Context) {
+	out := []dto.JobStatusOutput{}
+	if h.jobs != nil {
+		for _, st := range h.jobs.Statuses() {
+			out = append(out, jobStatusToDto(st))
+		}
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func jobStatusToDto(st scheduler.Status) dto.JobStatusOutput {
+	var lastRunAt *string
+	if st.LastRunAt != nil {
+		at := st.LastRunAt.UTC().Format(time.RFC3339)
+		lastRunAt = &at
+	}
+	return dto.JobStatusOutput{
+		Name:           st.Name,
+		Spec:           st.Spec,
+		NextRunAt:      st.NextRunAt.UTC().Format(time.RFC3339),
+		Running:        st.Running,
+		LastRunAt:      lastRunAt,
+		LastDurationMs: st.LastDuration.Milliseconds(),
+		LastError:      st.LastError,
+		Runs:           st.Runs,
+		Failures:       st.Failures,
+		Skipped:        st.Skipped,
+	}
+}
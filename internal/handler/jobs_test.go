@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+	"tz/internal/config"
+	"tz/internal/dto"
+	"tz/internal/scheduler"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type fakeJobs []scheduler.Status
+
+func (f fakeJobs) Statuses() []scheduler.Status { return f }
+
+func TestListJobs(t *testing.T) {
+	lastRun := time.Date(2025, time.November, 7, 10, 0, 0, 0, time.UTC)
+	jobs := fakeJobs{{
+		Name:         "evaluate_budgets",
+		Spec:         "*/15 * * * *",
+		NextRunAt:    lastRun.Add(15 * time.Minute),
+		LastRunAt:    &lastRun,
+		LastDuration: 42 * time.Millisecond,
+		LastError:    "db is down",
+		Runs:         3,
+		Failures:     1,
+		Skipped:      2,
+	}}
+
+	tests := []struct {
+		name string
+		jobs JobsI
+		want []dto.JobStatusOutput
+	}{
+		{name: "scheduler disabled", want: []dto.JobStatusOutput{}},
+		{
+			name: "jobs",
+			jobs: jobs,
+			want: []dto.JobStatusOutput{{
+				Name:           "evaluate_budgets",
+				Spec:           "*/15 * * * *",
+				NextRunAt:      "2025-11-07T10:15:00Z",
+				LastRunAt:      ptr("2025-11-07T10:00:00Z"),
+				LastDurationMs: 42,
+				LastError:      "db is down",
+				Runs:           3,
+				Failures:       1,
+				Skipped:        2,
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := NewHandler(Deps{Jobs: tt.jobs}, config.AuthConfig{}, zap.NewNop()).Init()
+
+			w := serve(router, http.MethodGet, "/admin/jobs", "")
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, body %s", w.Code, w.Body)
+			}
+			var got []dto.JobStatusOutput
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decode body %s: %v", w.Body, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("jobs = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if *got[i].LastRunAt != *tt.want[i].LastRunAt {
+					t.Errorf("LastRunAt = %s, want %s", *got[i].LastRunAt, *tt.want[i].LastRunAt)
+				}
+				got[i].LastRunAt = tt.want[i].LastRunAt
+				if got[i] != tt.want[i] {
+					t.Errorf("job = %+v, want %+v", got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
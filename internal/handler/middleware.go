@@ -1,27 +1,42 @@
 package handler
 
 import (
-	"context"
+	"net/http"
+	"regexp"
 	"time"
 	contextkeys "tz/internal/contextkey"
+	"tz/internal/domain"
+	"tz/internal/tracing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 const (
-	requestIDKey = "request_id"
+	requestIDKey    = "request_id"
+	requestIDHeader = "X-Request-ID"
 )
 
+// requestIDPattern limits accepted client request IDs to a safe charset and length.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{8,128}$`)
+
 func (s *SubscriptionHandler) logging() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
-		requestID := uuid.New().String()
+		requestID := c.GetHeader(requestIDHeader)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
 
 		c.Set(requestIDKey, requestID)
-		ctx := context.WithValue(c.Request.Context(), contextkeys.RequestIDKey, requestID)
-		c.Request = c.Request.WithContext(ctx)
+		c.Header(requestIDHeader, requestID)
+		c.Request = c.Request.WithContext(contextkeys.WithRequestID(c.Request.Context(), requestID))
 
 		path := c.Request.URL.Path
 		method := c.Request.Method
@@ -52,6 +67,7 @@ func (s *SubscriptionHandler) logging() gin.HandlerFunc {
 			zap.Duration("latency_ms", latency),
 			zap.Int("response_size_bytes", bodySize),
 		}
+		fields = append(fields, tracing.LogFields(c.Request.Context())...)
 
 		var logFn func(string, ...zap.Field)
 		switch {
@@ -67,14 +83,92 @@ func (s *SubscriptionHandler) logging() gin.HandlerFunc {
 	}
 }
 
+// tracing continues the trace from an incoming traceparent header, or starts a new one,
+// and wraps the request in a server span. Malformed traceparent values are ignored by
+// the propagator. The resulting traceparent is echoed in the response headers.
+func (s *SubscriptionHandler) tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx, span := tracing.Tracer().Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(c.Request.URL.Path),
+				semconv.ClientAddress(c.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(c.Writer.Header()))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, "")
+		}
+	}
+}
+
+// instrument records request count and latency per route template.
+// Requests that match no route are grouped under a single label.
+func (s *SubscriptionHandler) instrument() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.metrics == nil {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		s.metrics.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// dateFormat reads the date_format query parameter and stores the requested
+// response date format in the request context.
+func (s *SubscriptionHandler) dateFormat() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format, err := domain.ParseDateFormat(c.Query("date_format"))
+		if err != nil {
+			s.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		c.Request = c.Request.WithContext(contextkeys.WithDateFormat(c.Request.Context(), format))
+		c.Next()
+	}
+}
+
 func (s *SubscriptionHandler) loggerWith(c *gin.Context, fields ...zap.Field) *zap.Logger {
 	base := []zap.Field{
 		zap.String("request_id", s.getRequestID(c)),
 		zap.String("client_ip", c.ClientIP()),
 	}
+	base = append(base, tracing.LogFields(c.Request.Context())...)
 	return s.log.With(append(base, fields...)...)
 }
 
 func (s *SubscriptionHandler) getRequestID(c *gin.Context) string {
 	return c.GetString(requestIDKey)
 }
+
+// errorResponse aborts the request with an error body that carries the request ID.
+func (s *SubscriptionHandler) errorResponse(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"error":      message,
+		"request_id": s.getRequestID(c),
+	})
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"tz/internal/authz"
+	"tz/internal/config"
+	"tz/internal/domain"
+	"tz/internal/handler/mocks"
+	"tz/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestLogging_RequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := NewHandler(Deps{}, config.AuthConfig{}, zap.NewNop()).Init()
+
+	tests := []struct {
+		name     string
+		header   string
+		wantEcho bool
+	}{
+		{name: "valid id is honored", header: "gw-2f1c9a7e-42", wantEcho: true},
+		{name: "missing id is generated", header: "", wantEcho: false},
+		{name: "too short id is replaced", header: "abc", wantEcho: false},
+		{name: "unsafe id is replaced", header: "id with spaces\r\n", wantEcho: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/subscriptions/not-a-uuid", nil)
+			if tt.header != "" {
+				req.Header.Set(requestIDHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			got := w.Header().Get(requestIDHeader)
+			if got == "" {
+				t.Fatalf("response has no %s header", requestIDHeader)
+			}
+			if (got == tt.header) != tt.wantEcho {
+				t.Errorf("%s = %q, incoming %q, want echo %v", requestIDHeader, got, tt.header, tt.wantEcho)
+			}
+
+			var body map[string]string
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode error body: %v", err)
+			}
+			if body["request_id"] != got {
+				t.Errorf("error body request_id = %q, want %q", body["request_id"], got)
+			}
+		})
+	}
+}
+
+func TestTracing_EchoesTraceparent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	router := NewHandler(Deps{}, config.AuthConfig{}, zap.NewNop()).Init()
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("traceparent", traceparent)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if got := w.Header().Get("traceparent"); got != traceparent {
+		t.Errorf("traceparent = %q, want %q", got, traceparent)
+	}
+}
+
+func TestMetrics_RequiresAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	metrics := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	policy := authz.NewRolePolicy(authz.RoleConfig{Roles: map[string][]string{domain.RoleAdmin: {"*"}}})
+
+	tests := []struct {
+		name       string
+		auth       config.AuthConfig
+		apiKey     string
+		wantStatus int
+	}{
+		{name: "auth disabled", wantStatus: http.StatusOK},
+		{name: "missing credentials", auth: config.AuthConfig{Enabled: true, AdminToken: "secret"}, wantStatus: http.StatusUnauthorized},
+		{name: "admin token", auth: config.AuthConfig{Enabled: true, AdminToken: "secret"}, apiKey: "secret", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewHandler(Deps{MetricsHandler: metrics, Policy: policy}, tt.auth, zap.NewNop()).Init()
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(apiKeyHeader, tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAuthenticate_RateLimitsClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	apiKeys := mocks.NewMockAPIKeyServiceI(gomock.NewController(t))
+	apiKeys.EXPECT().Authenticate(gomock.Any(), "tzk_bogus").Return(domain.APIKey{}, domain.ErrUnauthorized)
+	limiter, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), map[string]ratelimit.Limit{
+		ratelimit.GroupAuth: {Requests: 1, Period: time.Hour},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := NewHandler(Deps{APIKeys: apiKeys, Limiter: limiter}, config.AuthConfig{Enabled: true}, zap.NewNop()).Init()
+
+	for _, want := range []int{http.StatusUnauthorized, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions/", nil)
+		req.Header.Set(apiKeyHeader, "tzk_bogus")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != want {
+			t.Errorf("status = %d, want %d", w.Code, want)
+		}
+	}
+}
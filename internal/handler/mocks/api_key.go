@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: api_key.go
+//
+// Generated by this command:
+//
+//	mockgen -source=api_key.go -destination=mocks/api_key.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "tz/internal/domain"
+	dto "tz/internal/dto"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPIKeyServiceI is a mock of APIKeyServiceI interface.
+type MockAPIKeyServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyServiceIMockRecorder is the mock recorder for MockAPIKeyServiceI.
+type MockAPIKeyServiceIMockRecorder struct {
+	mock *MockAPIKeyServiceI
+}
+
+// NewMockAPIKeyServiceI creates a new mock instance.
+func NewMockAPIKeyServiceI(ctrl *gomock.Controller) *MockAPIKeyServiceI {
+	mock := &MockAPIKeyServiceI{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyServiceI) EXPECT() *MockAPIKeyServiceIMockRecorder {
+	return m.recorder
+}
+
+// APIKeys mocks base method.
+func (m *MockAPIKeyServiceI) APIKeys(ctx context.Context) ([]dto.APIKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIKeys", ctx)
+	ret0, _ := ret[0].([]dto.APIKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APIKeys indicates an expected call of APIKeys.
+func (mr *MockAPIKeyServiceIMockRecorder) APIKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIKeys", reflect.TypeOf((*MockAPIKeyServiceI)(nil).APIKeys), ctx)
+}
+
+// Authenticate mocks base method.
+func (m *MockAPIKeyServiceI) Authenticate(ctx context.Context, rawKey string) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, rawKey)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAPIKeyServiceIMockRecorder) Authenticate(ctx, rawKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAPIKeyServiceI)(nil).Authenticate), ctx, rawKey)
+}
+
+// IssueAPIKey mocks base method.
+func (m *MockAPIKeyServiceI) IssueAPIKey(ctx context.Context, req dto.CreateAPIKeyRequest) (dto.IssuedAPIKeyOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueAPIKey", ctx, req)
+	ret0, _ := ret[0].(dto.IssuedAPIKeyOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueAPIKey indicates an expected call of IssueAPIKey.
+func (mr *MockAPIKeyServiceIMockRecorder) IssueAPIKey(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueAPIKey", reflect.TypeOf((*MockAPIKeyServiceI)(nil).IssueAPIKey), ctx, req)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyServiceI) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyServiceIMockRecorder) RevokeAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyServiceI)(nil).RevokeAPIKey), ctx, id)
+}
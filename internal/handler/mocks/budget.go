@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: budget.go
+//
+// Generated by this command:
+//
+//	mockgen -source=budget.go -destination=mocks/budget.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dto "tz/internal/dto"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBudgetServiceI is a mock of BudgetServiceI interface.
+type MockBudgetServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockBudgetServiceIMockRecorder is the mock recorder for MockBudgetServiceI.
+type MockBudgetServiceIMockRecorder struct {
+	mock *MockBudgetServiceI
+}
+
+// NewMockBudgetServiceI creates a new mock instance.
+func NewMockBudgetServiceI(ctrl *gomock.Controller) *MockBudgetServiceI {
+	mock := &MockBudgetServiceI{ctrl: ctrl}
+	mock.recorder = &MockBudgetServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudgetServiceI) EXPECT() *MockBudgetServiceIMockRecorder {
+	return m.recorder
+}
+
+// Budget mocks base method.
+func (m *MockBudgetServiceI) Budget(ctx context.Context, id uuid.UUID) (dto.BudgetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Budget", ctx, id)
+	ret0, _ := ret[0].(dto.BudgetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Budget indicates an expected call of Budget.
+func (mr *MockBudgetServiceIMockRecorder) Budget(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Budget", reflect.TypeOf((*MockBudgetServiceI)(nil).Budget), ctx, id)
+}
+
+// BudgetAlerts mocks base method.
+func (m *MockBudgetServiceI) BudgetAlerts(ctx context.Context, budgetID uuid.UUID) ([]dto.BudgetAlertOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BudgetAlerts", ctx, budgetID)
+	ret0, _ := ret[0].([]dto.BudgetAlertOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BudgetAlerts indicates an expected call of BudgetAlerts.
+func (mr *MockBudgetServiceIMockRecorder) BudgetAlerts(ctx, budgetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BudgetAlerts", reflect.TypeOf((*MockBudgetServiceI)(nil).BudgetAlerts), ctx, budgetID)
+}
+
+// Budgets mocks base method.
+func (m *MockBudgetServiceI) Budgets(ctx context.Context, filter dto.BudgetFilter) ([]dto.BudgetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Budgets", ctx, filter)
+	ret0, _ := ret[0].([]dto.BudgetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Budgets indicates an expected call of Budgets.
+func (mr *MockBudgetServiceIMockRecorder) Budgets(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Budgets", reflect.TypeOf((*MockBudgetServiceI)(nil).Budgets), ctx, filter)
+}
+
+// CreateBudget mocks base method.
+func (m *MockBudgetServiceI) CreateBudget(ctx context.Context, req dto.CreateBudgetRequest) (dto.BudgetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBudget", ctx, req)
+	ret0, _ := ret[0].(dto.BudgetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBudget indicates an expected call of CreateBudget.
+func (mr *MockBudgetServiceIMockRecorder) CreateBudget(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBudget", reflect.TypeOf((*MockBudgetServiceI)(nil).CreateBudget), ctx, req)
+}
+
+// DeleteBudget mocks base method.
+func (m *MockBudgetServiceI) DeleteBudget(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBudget", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBudget indicates an expected call of DeleteBudget.
+func (mr *MockBudgetServiceIMockRecorder) DeleteBudget(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBudget", reflect.TypeOf((*MockBudgetServiceI)(nil).DeleteBudget), ctx, id)
+}
+
+// UpdateBudget mocks base method.
+func (m *MockBudgetServiceI) UpdateBudget(ctx context.Context, id uuid.UUID, req dto.UpdateBudgetRequest) (dto.BudgetOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBudget", ctx, id, req)
+	ret0, _ := ret[0].(dto.BudgetOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBudget indicates an expected call of UpdateBudget.
+func (mr *MockBudgetServiceIMockRecorder) UpdateBudget(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBudget", reflect.TypeOf((*MockBudgetServiceI)(nil).UpdateBudget), ctx, id, req)
+}
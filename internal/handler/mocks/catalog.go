@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: catalog.go
+//
+// Generated by this command:
+//
+//	mockgen -source=catalog.go -destination=mocks/catalog.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dto "tz/internal/dto"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCatalogServiceI is a mock of CatalogServiceI interface.
+type MockCatalogServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogServiceIMockRecorder is the mock recorder for MockCatalogServiceI.
+type MockCatalogServiceIMockRecorder struct {
+	mock *MockCatalogServiceI
+}
+
+// NewMockCatalogServiceI creates a new mock instance.
+func NewMockCatalogServiceI(ctrl *gomock.Controller) *MockCatalogServiceI {
+	mock := &MockCatalogServiceI{ctrl: ctrl}
+	mock.recorder = &MockCatalogServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogServiceI) EXPECT() *MockCatalogServiceIMockRecorder {
+	return m.recorder
+}
+
+// CreateService mocks base method.
+func (m *MockCatalogServiceI) CreateService(ctx context.Context, req dto.CreateServiceRequest) (dto.ServiceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateService", ctx, req)
+	ret0, _ := ret[0].(dto.ServiceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateService indicates an expected call of CreateService.
+func (mr *MockCatalogServiceIMockRecorder) CreateService(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockCatalogServiceI)(nil).CreateService), ctx, req)
+}
+
+// DeleteService mocks base method.
+func (m *MockCatalogServiceI) DeleteService(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteService", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteService indicates an expected call of DeleteService.
+func (mr *MockCatalogServiceIMockRecorder) DeleteService(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockCatalogServiceI)(nil).DeleteService), ctx, id)
+}
+
+// Service mocks base method.
+func (m *MockCatalogServiceI) Service(ctx context.Context, id uuid.UUID) (dto.ServiceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Service", ctx, id)
+	ret0, _ := ret[0].(dto.ServiceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Service indicates an expected call of Service.
+func (mr *MockCatalogServiceIMockRecorder) Service(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Service", reflect.TypeOf((*MockCatalogServiceI)(nil).Service), ctx, id)
+}
+
+// Services mocks base method.
+func (m *MockCatalogServiceI) Services(ctx context.Context, filter dto.ServiceFilter) ([]dto.ServiceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Services", ctx, filter)
+	ret0, _ := ret[0].([]dto.ServiceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Services indicates an expected call of Services.
+func (mr *MockCatalogServiceIMockRecorder) Services(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Services", reflect.TypeOf((*MockCatalogServiceI)(nil).Services), ctx, filter)
+}
+
+// UpdateService mocks base method.
+func (m *MockCatalogServiceI) UpdateService(ctx context.Context, id uuid.UUID, req dto.UpdateServiceRequest) (dto.ServiceOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateService", ctx, id, req)
+	ret0, _ := ret[0].(dto.ServiceOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateService indicates an expected call of UpdateService.
+func (mr *MockCatalogServiceIMockRecorder) UpdateService(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockCatalogServiceI)(nil).UpdateService), ctx, id, req)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: graphql.go
+//
+// Generated by this command:
+//
+//	mockgen -source=graphql.go -destination=mocks/graphql.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "tz/internal/domain"
+	dto "tz/internal/dto"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGraphQLI is a mock of GraphQLI interface.
+type MockGraphQLI struct {
+	ctrl     *gomock.Controller
+	recorder *MockGraphQLIMockRecorder
+	isgomock struct{}
+}
+
+// MockGraphQLIMockRecorder is the mock recorder for MockGraphQLI.
+type MockGraphQLIMockRecorder struct {
+	mock *MockGraphQLI
+}
+
+// NewMockGraphQLI creates a new mock instance.
+func NewMockGraphQLI(ctrl *gomock.Controller) *MockGraphQLI {
+	mock := &MockGraphQLI{ctrl: ctrl}
+	mock.recorder = &MockGraphQLIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGraphQLI) EXPECT() *MockGraphQLIMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockGraphQLI) Execute(ctx context.Context, req dto.GraphQLRequest, authorize func(domain.Permission) error) dto.GraphQLResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, req, authorize)
+	ret0, _ := ret[0].(dto.GraphQLResponse)
+	return ret0
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockGraphQLIMockRecorder) Execute(ctx, req, authorize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockGraphQLI)(nil).Execute), ctx, req, authorize)
+}
+
+// SelectsCost mocks base method.
+func (m *MockGraphQLI) SelectsCost(req dto.GraphQLRequest) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectsCost", req)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SelectsCost indicates an expected call of SelectsCost.
+func (mr *MockGraphQLIMockRecorder) SelectsCost(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectsCost", reflect.TypeOf((*MockGraphQLI)(nil).SelectsCost), req)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stream.go
+//
+// Generated by this command:
+//
+//	mockgen -source=stream.go -destination=mocks/stream.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dto "tz/internal/dto"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventStreamI is a mock of EventStreamI interface.
+type MockEventStreamI struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventStreamIMockRecorder
+	isgomock struct{}
+}
+
+// MockEventStreamIMockRecorder is the mock recorder for MockEventStreamI.
+type MockEventStreamIMockRecorder struct {
+	mock *MockEventStreamI
+}
+
+// NewMockEventStreamI creates a new mock instance.
+func NewMockEventStreamI(ctrl *gomock.Controller) *MockEventStreamI {
+	mock := &MockEventStreamI{ctrl: ctrl}
+	mock.recorder = &MockEventStreamIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventStreamI) EXPECT() *MockEventStreamIMockRecorder {
+	return m.recorder
+}
+
+// StreamEvents mocks base method.
+func (m *MockEventStreamI) StreamEvents(ctx context.Context, filter dto.EventStreamFilter, lastEventID string) (<-chan dto.StreamEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamEvents", ctx, filter, lastEventID)
+	ret0, _ := ret[0].(<-chan dto.StreamEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamEvents indicates an expected call of StreamEvents.
+func (mr *MockEventStreamIMockRecorder) StreamEvents(ctx, filter, lastEventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamEvents", reflect.TypeOf((*MockEventStreamI)(nil).StreamEvents), ctx, filter, lastEventID)
+}
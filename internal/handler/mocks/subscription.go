@@ -0,0 +1,243 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: subscription.go
+//
+// Generated by this command:
+//
+//	mockgen -source=subscription.go -destination=mocks/subscription.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	dto "tz/internal/dto"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionServiceI is a mock of SubscriptionServiceI interface.
+type MockSubscriptionServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionServiceIMockRecorder is the mock recorder for MockSubscriptionServiceI.
+type MockSubscriptionServiceIMockRecorder struct {
+	mock *MockSubscriptionServiceI
+}
+
+// NewMockSubscriptionServiceI creates a new mock instance.
+func NewMockSubscriptionServiceI(ctrl *gomock.Controller) *MockSubscriptionServiceI {
+	mock := &MockSubscriptionServiceI{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionServiceI) EXPECT() *MockSubscriptionServiceIMockRecorder {
+	return m.recorder
+}
+
+// CreatePriceChange mocks base method.
+func (m *MockSubscriptionServiceI) CreatePriceChange(ctx context.Context, subscriptionID uuid.UUID, req dto.CreatePriceChangeRequest) (dto.PriceChangeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceChange", ctx, subscriptionID, req)
+	ret0, _ := ret[0].(dto.PriceChangeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceChange indicates an expected call of CreatePriceChange.
+func (mr *MockSubscriptionServiceIMockRecorder) CreatePriceChange(ctx, subscriptionID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceChange", reflect.TypeOf((*MockSubscriptionServiceI)(nil).CreatePriceChange), ctx, subscriptionID, req)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockSubscriptionServiceI) CreateSubscription(ctx context.Context, sub dto.CreateSubscriptionRequest) (dto.SubscriptionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, sub)
+	ret0, _ := ret[0].(dto.SubscriptionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockSubscriptionServiceIMockRecorder) CreateSubscription(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockSubscriptionServiceI)(nil).CreateSubscription), ctx, sub)
+}
+
+// DeletePriceChange mocks base method.
+func (m *MockSubscriptionServiceI) DeletePriceChange(ctx context.Context, subscriptionID, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePriceChange", ctx, subscriptionID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePriceChange indicates an expected call of DeletePriceChange.
+func (mr *MockSubscriptionServiceIMockRecorder) DeletePriceChange(ctx, subscriptionID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePriceChange", reflect.TypeOf((*MockSubscriptionServiceI)(nil).DeletePriceChange), ctx, subscriptionID, id)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockSubscriptionServiceI) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockSubscriptionServiceIMockRecorder) DeleteSubscription(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockSubscriptionServiceI)(nil).DeleteSubscription), ctx, id)
+}
+
+// Forecast mocks base method.
+func (m *MockSubscriptionServiceI) Forecast(ctx context.Context, req dto.ForecastRequest) (dto.ForecastOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forecast", ctx, req)
+	ret0, _ := ret[0].(dto.ForecastOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Forecast indicates an expected call of Forecast.
+func (mr *MockSubscriptionServiceIMockRecorder) Forecast(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forecast", reflect.TypeOf((*MockSubscriptionServiceI)(nil).Forecast), ctx, req)
+}
+
+// PriceChanges mocks base method.
+func (m *MockSubscriptionServiceI) PriceChanges(ctx context.Context, subscriptionID uuid.UUID) ([]dto.PriceChangeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PriceChanges", ctx, subscriptionID)
+	ret0, _ := ret[0].([]dto.PriceChangeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PriceChanges indicates an expected call of PriceChanges.
+func (mr *MockSubscriptionServiceIMockRecorder) PriceChanges(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PriceChanges", reflect.TypeOf((*MockSubscriptionServiceI)(nil).PriceChanges), ctx, subscriptionID)
+}
+
+// SubscriptionByID mocks base method.
+func (m *MockSubscriptionServiceI) SubscriptionByID(ctx context.Context, id uuid.UUID) (dto.SubscriptionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionByID", ctx, id)
+	ret0, _ := ret[0].(dto.SubscriptionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscriptionByID indicates an expected call of SubscriptionByID.
+func (mr *MockSubscriptionServiceIMockRecorder) SubscriptionByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionByID", reflect.TypeOf((*MockSubscriptionServiceI)(nil).SubscriptionByID), ctx, id)
+}
+
+// Subscriptions mocks base method.
+func (m *MockSubscriptionServiceI) Subscriptions(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscriptions", ctx, filter)
+	ret0, _ := ret[0].(dto.SubscriptionsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscriptions indicates an expected call of Subscriptions.
+func (mr *MockSubscriptionServiceIMockRecorder) Subscriptions(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscriptions", reflect.TypeOf((*MockSubscriptionServiceI)(nil).Subscriptions), ctx, filter)
+}
+
+// SubscriptionsCost mocks base method.
+func (m *MockSubscriptionServiceI) SubscriptionsCost(ctx context.Context, req dto.CostRequest) (dto.CostOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionsCost", ctx, req)
+	ret0, _ := ret[0].(dto.CostOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscriptionsCost indicates an expected call of SubscriptionsCost.
+func (mr *MockSubscriptionServiceIMockRecorder) SubscriptionsCost(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionsCost", reflect.TypeOf((*MockSubscriptionServiceI)(nil).SubscriptionsCost), ctx, req)
+}
+
+// Upcoming mocks base method.
+func (m *MockSubscriptionServiceI) Upcoming(ctx context.Context, req dto.UpcomingRequest) (dto.UpcomingOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upcoming", ctx, req)
+	ret0, _ := ret[0].(dto.UpcomingOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upcoming indicates an expected call of Upcoming.
+func (mr *MockSubscriptionServiceIMockRecorder) Upcoming(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upcoming", reflect.TypeOf((*MockSubscriptionServiceI)(nil).Upcoming), ctx, req)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockSubscriptionServiceI) UpdateSubscription(ctx context.Context, id uuid.UUID, sub dto.UpdateSubscriptionRequest) (dto.SubscriptionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", ctx, id, sub)
+	ret0, _ := ret[0].(dto.SubscriptionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockSubscriptionServiceIMockRecorder) UpdateSubscription(ctx, id, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockSubscriptionServiceI)(nil).UpdateSubscription), ctx, id, sub)
+}
+
+// MockMetricsI is a mock of MetricsI interface.
+type MockMetricsI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsIMockRecorder
+	isgomock struct{}
+}
+
+// MockMetricsIMockRecorder is the mock recorder for MockMetricsI.
+type MockMetricsIMockRecorder struct {
+	mock *MockMetricsI
+}
+
+// NewMockMetricsI creates a new mock instance.
+func NewMockMetricsI(ctrl *gomock.Controller) *MockMetricsI {
+	mock := &MockMetricsI{ctrl: ctrl}
+	mock.recorder = &MockMetricsIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricsI) EXPECT() *MockMetricsIMockRecorder {
+	return m.recorder
+}
+
+// ObserveHTTPRequest mocks base method.
+func (m *MockMetricsI) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveHTTPRequest", method, route, status, duration)
+}
+
+// ObserveHTTPRequest indicates an expected call of ObserveHTTPRequest.
+func (mr *MockMetricsIMockRecorder) ObserveHTTPRequest(method, route, status, duration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveHTTPRequest", reflect.TypeOf((*MockMetricsI)(nil).ObserveHTTPRequest), method, route, status, duration)
+}
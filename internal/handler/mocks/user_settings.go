@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_settings.go
+//
+// Generated by this command:
+//
+//	mockgen -source=user_settings.go -destination=mocks/user_settings.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dto "tz/internal/dto"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserSettingsServiceI is a mock of UserSettingsServiceI interface.
+type MockUserSettingsServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSettingsServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockUserSettingsServiceIMockRecorder is the mock recorder for MockUserSettingsServiceI.
+type MockUserSettingsServiceIMockRecorder struct {
+	mock *MockUserSettingsServiceI
+}
+
+// NewMockUserSettingsServiceI creates a new mock instance.
+func NewMockUserSettingsServiceI(ctrl *gomock.Controller) *MockUserSettingsServiceI {
+	mock := &MockUserSettingsServiceI{ctrl: ctrl}
+	mock.recorder = &MockUserSettingsServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSettingsServiceI) EXPECT() *MockUserSettingsServiceIMockRecorder {
+	return m.recorder
+}
+
+// UpdateUserSettings mocks base method.
+func (m *MockUserSettingsServiceI) UpdateUserSettings(ctx context.Context, userID uuid.UUID, req dto.UpdateUserSettingsRequest) (dto.UserSettingsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserSettings", ctx, userID, req)
+	ret0, _ := ret[0].(dto.UserSettingsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserSettings indicates an expected call of UpdateUserSettings.
+func (mr *MockUserSettingsServiceIMockRecorder) UpdateUserSettings(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserSettings", reflect.TypeOf((*MockUserSettingsServiceI)(nil).UpdateUserSettings), ctx, userID, req)
+}
+
+// UserSettings mocks base method.
+func (m *MockUserSettingsServiceI) UserSettings(ctx context.Context, userID uuid.UUID) (dto.UserSettingsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSettings", ctx, userID)
+	ret0, _ := ret[0].(dto.UserSettingsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserSettings indicates an expected call of UserSettings.
+func (mr *MockUserSettingsServiceIMockRecorder) UserSettings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSettings", reflect.TypeOf((*MockUserSettingsServiceI)(nil).UserSettings), ctx, userID)
+}
@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook.go -destination=mocks/webhook.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dto "tz/internal/dto"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookServiceI is a mock of WebhookServiceI interface.
+type MockWebhookServiceI struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceIMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookServiceIMockRecorder is the mock recorder for MockWebhookServiceI.
+type MockWebhookServiceIMockRecorder struct {
+	mock *MockWebhookServiceI
+}
+
+// NewMockWebhookServiceI creates a new mock instance.
+func NewMockWebhookServiceI(ctrl *gomock.Controller) *MockWebhookServiceI {
+	mock := &MockWebhookServiceI{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookServiceI) EXPECT() *MockWebhookServiceIMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookServiceI) CreateWebhook(ctx context.Context, req dto.CreateWebhookRequest) (dto.WebhookOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, req)
+	ret0, _ := ret[0].(dto.WebhookOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookServiceIMockRecorder) CreateWebhook(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookServiceI)(nil).CreateWebhook), ctx, req)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookServiceI) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookServiceIMockRecorder) DeleteWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookServiceI)(nil).DeleteWebhook), ctx, id)
+}
+
+// Deliveries mocks base method.
+func (m *MockWebhookServiceI) Deliveries(ctx context.Context, webhookID uuid.UUID, filter dto.DeliveryFilter) ([]dto.WebhookDeliveryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliveries", ctx, webhookID, filter)
+	ret0, _ := ret[0].([]dto.WebhookDeliveryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deliveries indicates an expected call of Deliveries.
+func (mr *MockWebhookServiceIMockRecorder) Deliveries(ctx, webhookID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliveries", reflect.TypeOf((*MockWebhookServiceI)(nil).Deliveries), ctx, webhookID, filter)
+}
+
+// RetryDelivery mocks base method.
+func (m *MockWebhookServiceI) RetryDelivery(ctx context.Context, webhookID, id uuid.UUID) (dto.WebhookDeliveryOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryDelivery", ctx, webhookID, id)
+	ret0, _ := ret[0].(dto.WebhookDeliveryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryDelivery indicates an expected call of RetryDelivery.
+func (mr *MockWebhookServiceIMockRecorder) RetryDelivery(ctx, webhookID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryDelivery", reflect.TypeOf((*MockWebhookServiceI)(nil).RetryDelivery), ctx, webhookID, id)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookServiceI) UpdateWebhook(ctx context.Context, id uuid.UUID, req dto.UpdateWebhookRequest) (dto.WebhookOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, id, req)
+	ret0, _ := ret[0].(dto.WebhookOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookServiceIMockRecorder) UpdateWebhook(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookServiceI)(nil).UpdateWebhook), ctx, id, req)
+}
+
+// Webhook mocks base method.
+func (m *MockWebhookServiceI) Webhook(ctx context.Context, id uuid.UUID) (dto.WebhookOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Webhook", ctx, id)
+	ret0, _ := ret[0].(dto.WebhookOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Webhook indicates an expected call of Webhook.
+func (mr *MockWebhookServiceIMockRecorder) Webhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Webhook", reflect.TypeOf((*MockWebhookServiceI)(nil).Webhook), ctx, id)
+}
+
+// Webhooks mocks base method.
+func (m *MockWebhookServiceI) Webhooks(ctx context.Context) ([]dto.WebhookOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Webhooks", ctx)
+	ret0, _ := ret[0].([]dto.WebhookOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Webhooks indicates an expected call of Webhooks.
+func (mr *MockWebhookServiceIMockRecorder) Webhooks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Webhooks", reflect.TypeOf((*MockWebhookServiceI)(nil).Webhooks), ctx)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+	"tz/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// rateLimit spends a token from the group budget of the caller, identified by
+// its principal or, for anonymous requests, by client IP.
+func (h *SubscriptionHandler) rateLimit(group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.takeToken(c, group, h.rateLimitKey(c)) {
+			c.Next()
+		}
+	}
+}
+
+// rateLimitKey identifies the caller of a request by its principal or, for anonymous
+// requests, by client IP.
+func (h *SubscriptionHandler) rateLimitKey(c *gin.Context) string {
+	if principal, ok := h.principal(c); ok {
+		return ratelimit.PrincipalKey(principal)
+	}
+	return ratelimit.ClientKey(c.ClientIP())
+}
+
+// takeToken spends a token from the group budget of key and reports whether the request
+// may go on. A refused request is answered with 429.
+func (h *SubscriptionHandler) takeToken(c *gin.Context, group, key string) bool {
+	if h.limiter == nil {
+		return true
+	}
+
+	res, limited, err := h.limiter.Take(c.Request.Context(), group, key)
+	if err != nil {
+		h.loggerWith(c, zap.String("group", group)).Error("Rate limit store failed, allowing request", zap.Error(err))
+		return true
+	}
+	if !limited {
+		return true
+	}
+
+	header := c.Writer.Header()
+	header.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	header.Set("RateLimit-Reset", seconds(res.Reset))
+
+	if !res.Allowed {
+		header.Set("Retry-After", seconds(res.RetryAfter))
+		h.loggerWith(c, zap.String("group", group), zap.String("key", key)).Warn("Rate limit exceeded")
+		h.errorResponse(c, http.StatusTooManyRequests, "rate limit exceeded")
+		return false
+	}
+
+	return true
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
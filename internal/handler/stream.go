@@ -0,0 +1,89 @@
+package handler
+
+//go:generate go tool mockgen -source=stream.go -destination=mocks/stream.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// defaultStreamHeartbeat is used when Deps.StreamHeartbeat is not set.
+const defaultStreamHeartbeat = 15 * time.Second
+
+type EventStreamI interface {
+	StreamEvents(ctx context.Context, filter dto.EventStreamFilter, lastEventID string) (<-chan dto.StreamEvent, error)
+}
+
+// @Summary Stream subscription changes
+// @Description Server-sent events of created, updated and deleted subscriptions. Each event carries the sequence number of the change as its id, the event type as its name and the event JSON, the same as in webhooks, as its data. A client that reconnects with the Last-Event-ID header first gets the changes it missed. A comment line is sent when the stream is idle.
+// @Tags subscriptions
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param user_id query string false "User ID (UUID)"
+// @Param service_name query string false "Service name"
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Success 200 {object} dto.SubscriptionEvent
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /subscriptions/events [get]
+func (h *SubscriptionHandler) subscriptionEvents(c *gin.Context) {
+	log := h.loggerWith(c)
+	var filter dto.EventStreamFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		log.Warn("Failed to bind event stream filter")
+		h.errorResponse(c, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	ctx := c.Request.Context()
+	events, err := h.stream.StreamEvents(ctx, filter, c.GetHeader("Last-Event-ID"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidEventStream) {
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Failed to open event stream", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	// The stream outlives the write timeout of the server.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tz/internal/config"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/handler/mocks"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestSubscriptionEvents(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		lastEventID string
+		setup       func(s *mocks.MockEventStreamI)
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name: "stream", target: "/subscriptions/events?service_name=Netflix", lastEventID: "41",
+			setup: func(s *mocks.MockEventStreamI) {
+				events := make(chan dto.StreamEvent, 2)
+				events <- dto.StreamEvent{ID: 42, Type: "created", Data: []byte(`{"type":"created"}`)}
+				events <- dto.StreamEvent{ID: 43, Type: "deleted", Data: []byte(`{"type":"deleted"}`)}
+				close(events)
+				s.EXPECT().StreamEvents(gomock.Any(), dto.EventStreamFilter{ServiceName: ptr("Netflix")}, "41").Return(events, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "id: 42\nevent: created\ndata: {\"type\":\"created\"}\n\nid: 43\nevent: deleted\ndata: {\"type\":\"deleted\"}\n\n",
+		},
+		{
+			name: "invalid Last-Event-ID", target: "/subscriptions/events", lastEventID: "x",
+			setup: func(s *mocks.MockEventStreamI) {
+				s.EXPECT().StreamEvents(gomock.Any(), dto.EventStreamFilter{}, "x").
+					Return(nil, fmt.Errorf("%w: invalid Last-Event-ID", domain.ErrInvalidEventStream))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error", target: "/subscriptions/events",
+			setup: func(s *mocks.MockEventStreamI) {
+				s.EXPECT().StreamEvents(gomock.Any(), gomock.Any(), "").Return(nil, fmt.Errorf("boom"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			stream := mocks.NewMockEventStreamI(gomock.NewController(t))
+			router := NewHandler(Deps{Stream: stream}, config.AuthConfig{}, zap.NewNop()).Init()
+			tt.setup(stream)
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := w.Header().Get("Content-Type"); got != "text/event-stream" {
+				t.Errorf("Content-Type = %q, want text/event-stream", got)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
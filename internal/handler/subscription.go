@@ -1,12 +1,18 @@
 package handler
 
+//go:generate go tool mockgen -source=subscription.go -destination=mocks/subscription.go -package=mocks
+
 import (
 	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
+	"tz/internal/authz"
+	"tz/internal/config"
 	"tz/internal/domain"
 	"tz/internal/dto"
+	"tz/internal/ratelimit"
 	"tz/pkg/valid"
 
 	"github.com/gin-gonic/gin"
@@ -20,18 +26,87 @@ type SubscriptionServiceI interface {
 	CreateSubscription(ctx context.Context, sub dto.CreateSubscriptionRequest) (dto.SubscriptionOutput, error)
 	SubscriptionByID(ctx context.Context, id uuid.UUID) (dto.SubscriptionOutput, error)
 	Subscriptions(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionsOutput, error)
-	SubscriptionsCost(ctx context.Context, req dto.CostRequest) (int, error)
+	SubscriptionsCost(ctx context.Context, req dto.CostRequest) (dto.CostOutput, error)
 	UpdateSubscription(ctx context.Context, id uuid.UUID, sub dto.UpdateSubscriptionRequest) (dto.SubscriptionOutput, error)
 	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	Forecast(ctx context.Context, req dto.ForecastRequest) (dto.ForecastOutput, error)
+	Upcoming(ctx context.Context, req dto.UpcomingRequest) (dto.UpcomingOutput, error)
+	CreatePriceChange(ctx context.Context, subscriptionID uuid.UUID, req dto.CreatePriceChangeRequest) (dto.PriceChangeOutput, error)
+	PriceChanges(ctx context.Context, subscriptionID uuid.UUID) ([]dto.PriceChangeOutput, error)
+	DeletePriceChange(ctx context.Context, subscriptionID, id uuid.UUID) error
+}
+
+type MetricsI interface {
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+}
+
+// Deps are the collaborators of SubscriptionHandler.
+// Limiter, Metrics, MetricsHandler, Health, Jobs and GraphQL are optional and may be nil.
+type Deps struct {
+	Service        SubscriptionServiceI
+	APIKeys        APIKeyServiceI
+	Users          UserSettingsServiceI
+	Budgets        BudgetServiceI
+	Catalog        CatalogServiceI
+	Webhooks       WebhookServiceI
+	Stream         EventStreamI
+	GraphQL        GraphQLI
+	Jobs           JobsI
+	Policy         authz.Policy
+	Limiter        *ratelimit.Limiter
+	Metrics        MetricsI
+	MetricsHandler http.Handler
+	Health         HealthI
+	// StreamHeartbeat is the interval of the comments sent on idle event streams,
+	// 15 seconds when zero.
+	StreamHeartbeat time.Duration
 }
 
 type SubscriptionHandler struct {
-	service SubscriptionServiceI
-	log     *zap.Logger
+	service         SubscriptionServiceI
+	apiKeys         APIKeyServiceI
+	users           UserSettingsServiceI
+	budgets         BudgetServiceI
+	catalog         CatalogServiceI
+	webhooks        WebhookServiceI
+	stream          EventStreamI
+	graphql         GraphQLI
+	jobs            JobsI
+	authn           *authz.Authenticator
+	limiter         *ratelimit.Limiter
+	metrics         MetricsI
+	metricsHandler  http.Handler
+	health          HealthI
+	streamHeartbeat time.Duration
+	auth            config.AuthConfig
+	log             *zap.Logger
 }
 
-func NewHandler(service SubscriptionServiceI, log *zap.Logger) *SubscriptionHandler {
-	return &SubscriptionHandler{service: service, log: log}
+func NewHandler(deps Deps, auth config.AuthConfig, log *zap.Logger) *SubscriptionHandler {
+	heartbeat := deps.StreamHeartbeat
+	if heartbeat <= 0 {
+		heartbeat = defaultStreamHeartbeat
+	}
+
+	return &SubscriptionHandler{
+		service:         deps.Service,
+		apiKeys:         deps.APIKeys,
+		users:           deps.Users,
+		budgets:         deps.Budgets,
+		catalog:         deps.Catalog,
+		webhooks:        deps.Webhooks,
+		stream:          deps.Stream,
+		graphql:         deps.GraphQL,
+		jobs:            deps.Jobs,
+		authn:           authz.NewAuthenticator(auth.AdminToken, deps.APIKeys, deps.Policy),
+		limiter:         deps.Limiter,
+		metrics:         deps.Metrics,
+		metricsHandler:  deps.MetricsHandler,
+		health:          deps.Health,
+		streamHeartbeat: heartbeat,
+		auth:            auth,
+		log:             log,
+	}
 }
 
 func (h *SubscriptionHandler) Init() *gin.Engine {
@@ -39,7 +114,9 @@ func (h *SubscriptionHandler) Init() *gin.Engine {
 
 	router.Use(
 		gin.Recovery(),
+		h.tracing(),
 		h.logging(),
+		h.instrument(),
 	)
 
 	h.initAPI(router)
@@ -49,22 +126,81 @@ func (h *SubscriptionHandler) Init() *gin.Engine {
 }
 
 func (h *SubscriptionHandler) initAPI(router *gin.Engine) {
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"message": "ok",
-		})
-	})
+	router.GET("/livez", h.livez)
+	router.GET("/readyz", h.readyz)
+	router.GET("/health", h.livez)
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	subscriptions := router.Group("/subscriptions")
+	if h.metricsHandler != nil {
+		router.GET("/metrics", h.authenticate(), h.authorize(domain.PermAPIKeysManage), gin.WrapH(h.metricsHandler))
+	}
+
+	subscriptions := router.Group("/subscriptions", h.authenticate())
+	{
+		cost := subscriptions.Group("", h.rateLimit(ratelimit.GroupCost))
+		cost.GET("/cost", h.authorize(domain.PermCostRead), h.subscriptionsCost)
+		cost.GET("/forecast", h.authorize(domain.PermCostRead), h.forecast)
+
+		crud := subscriptions.Group("", h.rateLimit(ratelimit.GroupSubscriptions), h.dateFormat())
+		crud.POST("/", h.authorize(domain.PermSubscriptionsCreate), h.createSubscription)
+		crud.GET("/", h.authorize(domain.PermSubscriptionsRead), h.listSubscriptions)
+		crud.GET("/upcoming", h.authorize(domain.PermSubscriptionsRead), h.upcoming)
+		crud.GET("/events", h.authorize(domain.PermSubscriptionsRead), h.subscriptionEvents)
+		crud.GET("/:id", h.authorize(domain.PermSubscriptionsRead), h.subscription)
+		crud.PATCH("/:id", h.authorize(domain.PermSubscriptionsUpdate), h.updateSubscription)
+		crud.DELETE("/:id", h.authorize(domain.PermSubscriptionsDelete), h.deleteSubscription)
+		crud.POST("/:id/price-changes", h.authorize(domain.PermSubscriptionsUpdate), h.createPriceChange)
+		crud.GET("/:id/price-changes", h.authorize(domain.PermSubscriptionsRead), h.listPriceChanges)
+		crud.DELETE("/:id/price-changes/:change_id", h.authorize(domain.PermSubscriptionsUpdate), h.deletePriceChange)
+	}
+
+	users := router.Group("/users", h.authenticate(), h.rateLimit(ratelimit.GroupSubscriptions))
+	{
+		users.GET("/:user_id/settings", h.authorize(domain.PermUserSettingsRead), h.userSettings)
+		users.PUT("/:user_id/settings", h.authorize(domain.PermUserSettingsUpdate), h.updateUserSettings)
+	}
+
+	budgets := router.Group("/budgets", h.authenticate(), h.rateLimit(ratelimit.GroupSubscriptions), h.dateFormat())
+	{
+		budgets.POST("/", h.authorize(domain.PermBudgetsWrite), h.createBudget)
+		budgets.GET("/", h.authorize(domain.PermBudgetsRead), h.listBudgets)
+		budgets.GET("/:id", h.authorize(domain.PermBudgetsRead), h.budget)
+		budgets.PATCH("/:id", h.authorize(domain.PermBudgetsWrite), h.updateBudget)
+		budgets.DELETE("/:id", h.authorize(domain.PermBudgetsWrite), h.deleteBudget)
+		budgets.GET("/:id/alerts", h.authorize(domain.PermBudgetsRead), h.budgetAlerts)
+	}
+
+	services := router.Group("/services", h.authenticate(), h.rateLimit(ratelimit.GroupSubscriptions))
+	{
+		services.POST("/", h.authorize(domain.PermServicesWrite), h.createService)
+		services.GET("/", h.authorize(domain.PermServicesRead), h.listServices)
+		services.GET("/:id", h.authorize(domain.PermServicesRead), h.catalogService)
+		services.PATCH("/:id", h.authorize(domain.PermServicesWrite), h.updateService)
+		services.DELETE("/:id", h.authorize(domain.PermServicesWrite), h.deleteService)
+	}
+
+	webhooks := router.Group("/webhooks", h.authenticate(), h.rateLimit(ratelimit.GroupAdmin), h.authorize(domain.PermWebhooksManage))
 	{
-		subscriptions.POST("/", h.createSubscription)
-		subscriptions.GET("/", h.listSubscriptions)
-		subscriptions.GET("/cost", h.subscriptionsCost)
-		subscriptions.GET("/:id", h.subscription)
-		subscriptions.PATCH("/:id", h.updateSubscription)
-		subscriptions.DELETE("/:id", h.deleteSubscription)
+		webhooks.POST("/", h.createWebhook)
+		webhooks.GET("/", h.listWebhooks)
+		webhooks.GET("/:id", h.webhook)
+		webhooks.PATCH("/:id", h.updateWebhook)
+		webhooks.DELETE("/:id", h.deleteWebhook)
+		webhooks.GET("/:id/deliveries", h.webhookDeliveries)
+		webhooks.POST("/:id/deliveries/:delivery_id/retry", h.retryWebhookDelivery)
+	}
+
+	if h.graphql != nil {
+		router.POST("/graphql", h.authenticate(), h.rateLimit(ratelimit.GroupSubscriptions), h.dateFormat(), h.graphQL)
+	}
+
+	admin := router.Group("/admin", h.authenticate(), h.rateLimit(ratelimit.GroupAdmin), h.authorize(domain.PermAPIKeysManage))
+	{
+		admin.POST("/api-keys", h.issueAPIKey)
+		admin.GET("/api-keys", h.listAPIKeys)
+		admin.DELETE("/api-keys/:id", h.revokeAPIKey)
+		admin.GET("/jobs", h.listJobs)
 	}
 }
 
@@ -73,7 +209,9 @@ func (h *SubscriptionHandler) initAPI(router *gin.Engine) {
 // @Tags subscriptions
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param subscription body dto.CreateSubscriptionRequest true "Subscription data"
+// @Param date_format query string false "Date format of the response" Enums(month, day) default(month)
 // @Success 200 {object} dto.SubscriptionOutput
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -83,20 +221,25 @@ func (h *SubscriptionHandler) createSubscription(c *gin.Context) {
 	var req dto.CreateSubscriptionRequest
 	if err := c.BindJSON(&req); err != nil {
 		log.Warn("Failed to bind create subscription request")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		h.errorResponse(c, http.StatusBadRequest, "invalid JSON")
 		return
 	}
 
 	if err := valid.ValidateStruct(req); err != nil {
 		log.Warn("Validation failed for create subscription", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	subscription, err := h.service.CreateSubscription(c.Request.Context(), req)
 	if err != nil {
+		if isInvalidDate(err) || errors.Is(err, domain.ErrInvalidService) || errors.Is(err, domain.ErrInvalidLabel) {
+			log.Warn("Invalid date, service or tags in create subscription", zap.Error(err))
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		log.Error("Failed to create subscription", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -107,10 +250,14 @@ func (h *SubscriptionHandler) createSubscription(c *gin.Context) {
 // @Description Get paginated list of subscriptions with optional filters
 // @Tags subscriptions
 // @Produce json
+// @Security ApiKeyAuth
 // @Param user_id query string false "User ID (UUID)"
 // @Param service_name query string false "Service name"
+// @Param category query string false "Category"
+// @Param tag query []string false "Tags the subscriptions all have" collectionFormat(multi)
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(10) maximum(100)
+// @Param date_format query string false "Date format of the response" Enums(month, day) default(month)
 // @Success 200 {object} dto.SubscriptionsOutput
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -120,7 +267,7 @@ func (h *SubscriptionHandler) listSubscriptions(c *gin.Context) {
 	var filter dto.SubscriptionFilter
 	if err := c.ShouldBindQuery(&filter); err != nil {
 		log.Warn("Failed to bind subscription filter")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		h.errorResponse(c, http.StatusBadRequest, "invalid query parameters")
 		return
 	}
 
@@ -133,8 +280,13 @@ func (h *SubscriptionHandler) listSubscriptions(c *gin.Context) {
 
 	subscriptions, err := h.service.Subscriptions(c.Request.Context(), filter)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidLabel) {
+			log.Warn("Invalid tags in subscription filter", zap.Error(err))
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		log.Error("Failed to list subscriptions", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -145,11 +297,18 @@ func (h *SubscriptionHandler) listSubscriptions(c *gin.Context) {
 // @Description Calculate total cost of subscriptions for a given period and filters
 // @Tags subscriptions
 // @Produce json
+// @Security ApiKeyAuth
 // @Param user_id query string false "User ID (UUID)"
 // @Param service_name query string false "Service name"
-// @Param from query string false "From in MM-YYYY format"
-// @Param to query string false "To in MM-YYYY format"
-// @Success 200 {integer} int
+// @Param from query string false "From in MM-YYYY or YYYY-MM-DD format"
+// @Param to query string false "To in MM-YYYY or YYYY-MM-DD format"
+// @Param end_month_inclusive query bool false "Bill the end month, overrides cost.end_month_inclusive"
+// @Param proration query string false "Billing unit, overrides cost.proration" Enums(month, day)
+// @Param tz query string false "IANA time zone of the current month, overrides the user and cost.time_zone zones"
+// @Param category query string false "Category"
+// @Param tag query []string false "Tags the subscriptions all have" collectionFormat(multi)
+// @Param group_by query string false "Split the total by category or by tag" Enums(category, tag)
+// @Success 200 {object} dto.CostOutput
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /subscriptions/cost [get]
@@ -158,14 +317,26 @@ func (h *SubscriptionHandler) subscriptionsCost(c *gin.Context) {
 	var request dto.CostRequest
 	if err := c.ShouldBindQuery(&request); err != nil {
 		log.Warn("Failed to bind cost request")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		h.errorResponse(c, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+
+	if err := valid.ValidateStruct(request); err != nil {
+		log.Warn("Validation failed for cost request", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	subscriptionsCost, err := h.service.SubscriptionsCost(c.Request.Context(), request)
 	if err != nil {
+		if isInvalidDate(err) || errors.Is(err, domain.ErrInvalidCostPolicy) || errors.Is(err, domain.ErrInvalidTimeZone) ||
+			errors.Is(err, domain.ErrInvalidLabel) {
+			log.Warn("Invalid period in cost request", zap.Error(err))
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		log.Error("Failed to calculate total cost", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -176,7 +347,9 @@ func (h *SubscriptionHandler) subscriptionsCost(c *gin.Context) {
 // @Description Retrieve a single subscription by its UUID
 // @Tags subscriptions
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path string true "Subscription ID (UUID)"
+// @Param date_format query string false "Date format of the response" Enums(month, day) default(month)
 // @Success 200 {object} dto.SubscriptionOutput
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
@@ -187,7 +360,7 @@ func (h *SubscriptionHandler) subscription(c *gin.Context) {
 	id, err := h.parseID(c, "id")
 	if err != nil {
 		log.Warn("Invalid subscription ID", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscription ID"})
+		h.errorResponse(c, http.StatusBadRequest, "invalid subscription ID")
 		return
 	}
 
@@ -195,11 +368,11 @@ func (h *SubscriptionHandler) subscription(c *gin.Context) {
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			log.Warn("Subscription not found", zap.String("id", id.String()))
-			c.JSON(http.StatusNotFound, gin.H{"error": "subscription not found"})
+			h.errorResponse(c, http.StatusNotFound, "subscription not found")
 			return
 		}
 		log.Error("Failed to get subscription", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -211,10 +384,13 @@ func (h *SubscriptionHandler) subscription(c *gin.Context) {
 // @Tags subscriptions
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path string true "Subscription ID (UUID)"
 // @Param subscription body dto.UpdateSubscriptionRequest true "Fields to update"
+// @Param date_format query string false "Date format of the response" Enums(month, day) default(month)
 // @Success 200 {object} dto.SubscriptionOutput
 // @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /subscriptions/{id} [patch]
 func (h *SubscriptionHandler) updateSubscription(c *gin.Context) {
@@ -222,26 +398,35 @@ func (h *SubscriptionHandler) updateSubscription(c *gin.Context) {
 	id, err := h.parseID(c, "id")
 	if err != nil {
 		log.Warn("Invalid subscription ID in update", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscription ID"})
+		h.errorResponse(c, http.StatusBadRequest, "invalid subscription ID")
 		return
 	}
 	var req dto.UpdateSubscriptionRequest
 	if err := c.BindJSON(&req); err != nil {
 		log.Warn("Failed to bind update request")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid JSON"})
+		h.errorResponse(c, http.StatusBadRequest, "invalid JSON")
 		return
 	}
 
 	if err := valid.ValidateStruct(req); err != nil {
 		log.Warn("Validation failed for update request", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	subscription, err := h.service.UpdateSubscription(c.Request.Context(), id, req)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "subscription not found")
+			return
+		}
+		if isInvalidDate(err) || errors.Is(err, domain.ErrInvalidService) || errors.Is(err, domain.ErrInvalidLabel) {
+			log.Warn("Invalid date, service or tags in update subscription", zap.Error(err))
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		log.Error("Failed to update subscription", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -251,6 +436,7 @@ func (h *SubscriptionHandler) updateSubscription(c *gin.Context) {
 // @Summary Delete a subscription
 // @Description Delete a subscription by ID
 // @Tags subscriptions
+// @Security ApiKeyAuth
 // @Param id path string true "Subscription ID (UUID)"
 // @Success 204
 // @Failure 400 {object} map[string]string
@@ -263,13 +449,17 @@ func (h *SubscriptionHandler) deleteSubscription(c *gin.Context) {
 	id, err := h.parseID(c, "id")
 	if err != nil {
 		log.Warn("Invalid subscription ID in delete", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscription ID"})
+		h.errorResponse(c, http.StatusBadRequest, "invalid subscription ID")
 		return
 	}
 
 	if err := h.service.DeleteSubscription(c.Request.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "subscription not found")
+			return
+		}
 		log.Error("Failed to delete subscription", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -287,3 +477,7 @@ func (h *SubscriptionHandler) parseID(c *gin.Context, param string) (uuid.UUID,
 	}
 	return id, nil
 }
+
+func isInvalidDate(err error) bool {
+	return errors.Is(err, domain.ErrInvalidDate) || errors.Is(err, domain.ErrInvalidDateFormat)
+}
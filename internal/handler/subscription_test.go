@@ -0,0 +1,391 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"tz/internal/config"
+	contextkeys "tz/internal/contextkey"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/handler/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func newTestRouter(t *testing.T) (*gin.Engine, *mocks.MockSubscriptionServiceI) {
+	gin.SetMode(gin.TestMode)
+	service := mocks.NewMockSubscriptionServiceI(gomock.NewController(t))
+	return NewHandler(Deps{Service: service}, config.AuthConfig{}, zap.NewNop()).Init(), service
+}
+
+func serve(router *gin.Engine, method, target, body string) *httptest.ResponseRecorder {
+	var req *http.Request
+	if body == "" {
+		req = httptest.NewRequest(method, target, nil)
+	} else {
+		req = httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateSubscription(t *testing.T) {
+	userID := uuid.NewString()
+	valid := fmt.Sprintf(`{"service_name":"Yandex Plus","price":400,"user_id":%q,"start_date":"07-2025"}`, userID)
+
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(s *mocks.MockSubscriptionServiceI)
+		wantStatus int
+	}{
+		{
+			name: "created",
+			body: valid,
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().CreateSubscription(gomock.Any(), dto.CreateSubscriptionRequest{
+					ServiceName: "Yandex Plus", Price: 400, UserID: userID, StartDate: "07-2025",
+				}).Return(dto.SubscriptionOutput{ID: uuid.NewString()}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{name: "malformed json", body: `{"service_name":`, wantStatus: http.StatusBadRequest},
+		{name: "missing fields", body: `{"service_name":"Yandex Plus"}`, wantStatus: http.StatusBadRequest},
+		{
+			name: "invalid date",
+			body: valid,
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).
+					Return(dto.SubscriptionOutput{}, fmt.Errorf("%w: 13-2025", domain.ErrInvalidDate))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service failure",
+			body: valid,
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).
+					Return(dto.SubscriptionOutput{}, errors.New("connection refused"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, service := newTestRouter(t)
+			if tt.setup != nil {
+				tt.setup(service)
+			}
+
+			w := serve(router, http.MethodPost, "/subscriptions/", tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
+
+func TestListSubscriptions_PageDefaults(t *testing.T) {
+	tests := []struct {
+		query              string
+		wantPage, wantSize int
+	}{
+		{query: "", wantPage: 1, wantSize: 10},
+		{query: "?page=3&page_size=50", wantPage: 3, wantSize: 50},
+		{query: "?page=0&page_size=500", wantPage: 1, wantSize: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			router, service := newTestRouter(t)
+			service.EXPECT().
+				Subscriptions(gomock.Any(), dto.SubscriptionFilter{Page: tt.wantPage, PageSize: tt.wantSize}).
+				Return(dto.SubscriptionsOutput{}, nil)
+
+			w := serve(router, http.MethodGet, "/subscriptions/"+tt.query, "")
+			if w.Code != http.StatusOK {
+				t.Errorf("status = %d, body %s", w.Code, w.Body)
+			}
+		})
+	}
+}
+
+func TestDateFormat(t *testing.T) {
+	router, service := newTestRouter(t)
+	service.EXPECT().
+		Subscriptions(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ dto.SubscriptionFilter) (dto.SubscriptionsOutput, error) {
+			if got := contextkeys.DateFormat(ctx); got != domain.DateFormatDay {
+				t.Errorf("date format = %q, want day", got)
+			}
+			return dto.SubscriptionsOutput{}, nil
+		})
+
+	if w := serve(router, http.MethodGet, "/subscriptions/?date_format=day", ""); w.Code != http.StatusOK {
+		t.Errorf("status = %d, body %s", w.Code, w.Body)
+	}
+	if w := serve(router, http.MethodGet, "/subscriptions/?date_format=week", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown format status = %d, want 400", w.Code)
+	}
+}
+
+func TestSubscriptionsCost(t *testing.T) {
+	router, service := newTestRouter(t)
+	service.EXPECT().
+		SubscriptionsCost(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, req dto.CostRequest) (dto.CostOutput, error) {
+			if req.From == nil || *req.From != "01-2025" || req.To == nil || *req.To != "12-2025" {
+				t.Errorf("request = %+v", req)
+			}
+			if req.EndMonthInclusive == nil || !*req.EndMonthInclusive || req.Proration == nil || *req.Proration != "day" {
+				t.Errorf("policy override = %v, %v", req.EndMonthInclusive, req.Proration)
+			}
+			return dto.CostOutput{Total: 4800, Policy: dto.CostPolicyOutput{EndMonthInclusive: true, Proration: "day"}}, nil
+		})
+
+	w := serve(router, http.MethodGet, "/subscriptions/cost?from=01-2025&to=12-2025&end_month_inclusive=true&proration=day", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body)
+	}
+	var out dto.CostOutput
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil || out.Total != 4800 || out.Policy.Proration != "day" {
+		t.Errorf("body = %s", w.Body)
+	}
+
+	service.EXPECT().
+		SubscriptionsCost(gomock.Any(), gomock.Any()).
+		Return(dto.CostOutput{}, fmt.Errorf("invalid start_date: %w", domain.ErrInvalidDateFormat))
+	if w := serve(router, http.MethodGet, "/subscriptions/cost?from=2025", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid period status = %d, want 400", w.Code)
+	}
+
+	if w := serve(router, http.MethodGet, "/subscriptions/cost?proration=week", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid proration status = %d, want 400", w.Code)
+	}
+}
+
+func TestForecast(t *testing.T) {
+	router, service := newTestRouter(t)
+	service.EXPECT().
+		Forecast(gomock.Any(), dto.ForecastRequest{Months: 6, TimeZone: ptr("Europe/Moscow")}).
+		Return(dto.ForecastOutput{Months: []dto.ForecastMonth{{Month: "11-2025", Total: 100}}, Total: 100}, nil)
+
+	if w := serve(router, http.MethodGet, "/subscriptions/forecast?months=6&tz=Europe/Moscow", ""); w.Code != http.StatusOK {
+		t.Errorf("status = %d, body %s", w.Code, w.Body)
+	}
+	if w := serve(router, http.MethodGet, "/subscriptions/forecast?months=61", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("too many months status = %d, want 400", w.Code)
+	}
+
+	service.EXPECT().Forecast(gomock.Any(), gomock.Any()).Return(dto.ForecastOutput{}, domain.ErrInvalidTimeZone)
+	if w := serve(router, http.MethodGet, "/subscriptions/forecast?tz=Mars", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid zone status = %d, want 400", w.Code)
+	}
+}
+
+func TestUpcoming(t *testing.T) {
+	router, service := newTestRouter(t)
+	service.EXPECT().
+		Upcoming(gomock.Any(), dto.UpcomingRequest{Within: 3, ServiceName: ptr("Netflix")}).
+		Return(dto.UpcomingOutput{Subscriptions: []dto.UpcomingSubscription{{Renewals: 3, ExpectedCharge: 300}}, ExpectedTotal: 300}, nil)
+
+	if w := serve(router, http.MethodGet, "/subscriptions/upcoming?within=3&service_name=Netflix", ""); w.Code != http.StatusOK {
+		t.Errorf("status = %d, body %s", w.Code, w.Body)
+	}
+	if w := serve(router, http.MethodGet, "/subscriptions/upcoming?within=25", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("too long window status = %d, want 400", w.Code)
+	}
+}
+
+func TestSubscriptionByID_Routes(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		setup      func(s *mocks.MockSubscriptionServiceI)
+		wantStatus int
+	}{
+		{name: "get invalid id", method: http.MethodGet, target: "/subscriptions/42", wantStatus: http.StatusBadRequest},
+		{
+			name: "get found", method: http.MethodGet, target: "/subscriptions/" + id.String(),
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().SubscriptionByID(gomock.Any(), id).Return(dto.SubscriptionOutput{ID: id.String()}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get missing", method: http.MethodGet, target: "/subscriptions/" + id.String(),
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().SubscriptionByID(gomock.Any(), id).Return(dto.SubscriptionOutput{}, domain.ErrNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "patch missing", method: http.MethodPatch, target: "/subscriptions/" + id.String(), body: `{"price":500}`,
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().UpdateSubscription(gomock.Any(), id, gomock.Any()).
+					Return(dto.SubscriptionOutput{}, fmt.Errorf("subscription not found: %w", domain.ErrNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "patch negative price", method: http.MethodPatch, target: "/subscriptions/" + id.String(), body: `{"price":-1}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "delete", method: http.MethodDelete, target: "/subscriptions/" + id.String(),
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().DeleteSubscription(gomock.Any(), id).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "schedule price change", method: http.MethodPost, target: "/subscriptions/" + id.String() + "/price-changes",
+			body: `{"price":500,"effective_from":"2026-01-01"}`,
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().CreatePriceChange(gomock.Any(), id, dto.CreatePriceChangeRequest{Price: 500, EffectiveFrom: "2026-01-01"}).
+					Return(dto.PriceChangeOutput{}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "price change before start", method: http.MethodPost, target: "/subscriptions/" + id.String() + "/price-changes",
+			body: `{"price":500,"effective_from":"01-2020"}`,
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().CreatePriceChange(gomock.Any(), id, gomock.Any()).Return(dto.PriceChangeOutput{}, domain.ErrInvalidDate)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "price change without price", method: http.MethodPost, target: "/subscriptions/" + id.String() + "/price-changes",
+			body: `{"effective_from":"01-2026"}`, wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "delete missing price change", method: http.MethodDelete, target: "/subscriptions/" + id.String() + "/price-changes/" + id.String(),
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().DeletePriceChange(gomock.Any(), id, id).Return(domain.ErrPriceChangeNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "delete missing", method: http.MethodDelete, target: "/subscriptions/" + id.String(),
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().DeleteSubscription(gomock.Any(), id).Return(fmt.Errorf("subscription not found: %w", domain.ErrNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, service := newTestRouter(t)
+			if tt.setup != nil {
+				tt.setup(service)
+			}
+
+			w := serve(router, tt.method, tt.target, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
+
+func TestSubscriptionLabels_Routes(t *testing.T) {
+	id := uuid.NewString()
+	tooMany := `["` + strings.Repeat(`t","`, domain.MaxTags) + `t"]`
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		setup      func(s *mocks.MockSubscriptionServiceI)
+		wantStatus int
+	}{
+		{
+			name:   "list by category and tags",
+			method: http.MethodGet,
+			target: "/subscriptions/?category=music&tag=work&tag=family",
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().Subscriptions(gomock.Any(), dto.SubscriptionFilter{
+					Category: ptr("music"), Tags: []string{"work", "family"}, Page: 1, PageSize: 10,
+				}).Return(dto.SubscriptionsOutput{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "list by an invalid tag",
+			method: http.MethodGet,
+			target: "/subscriptions/?tag=" + strings.Repeat("t", 60),
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().Subscriptions(gomock.Any(), gomock.Any()).Return(dto.SubscriptionsOutput{}, domain.ErrInvalidLabel)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "cost grouped by tag",
+			method: http.MethodGet,
+			target: "/subscriptions/cost?group_by=tag&tag=work",
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().SubscriptionsCost(gomock.Any(), dto.CostRequest{GroupBy: ptr("tag"), Tags: []string{"work"}}).
+					Return(dto.CostOutput{Groups: []dto.CostGroupOutput{{Key: "work", Total: 100}}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{name: "cost grouped by service", method: http.MethodGet, target: "/subscriptions/cost?group_by=service", wantStatus: http.StatusBadRequest},
+		{
+			name:   "add and remove tags",
+			method: http.MethodPatch,
+			target: "/subscriptions/" + id,
+			body:   `{"category":"music","add_tags":["work"],"remove_tags":["family"]}`,
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any(), dto.UpdateSubscriptionRequest{
+					Category: ptr("music"), AddTags: []string{"work"}, RemoveTags: []string{"family"},
+				}).Return(dto.SubscriptionOutput{ID: id}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "too many tags on the subscription",
+			method: http.MethodPatch,
+			target: "/subscriptions/" + id,
+			body:   `{"add_tags":["work"]}`,
+			setup: func(s *mocks.MockSubscriptionServiceI) {
+				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(dto.SubscriptionOutput{}, fmt.Errorf("%w: a subscription has at most 20 tags", domain.ErrInvalidLabel))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "too many tags to add", method: http.MethodPatch, target: "/subscriptions/" + id, body: `{"add_tags":` + tooMany + `}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, service := newTestRouter(t)
+			if tt.setup != nil {
+				tt.setup(service)
+			}
+
+			w := serve(router, tt.method, tt.target, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
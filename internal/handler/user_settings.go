@@ -0,0 +1,103 @@
+package handler
+
+//go:generate go tool mockgen -source=user_settings.go -destination=mocks/user_settings.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type UserSettingsServiceI interface {
+	UserSettings(ctx context.Context, userID uuid.UUID) (dto.UserSettingsOutput, error)
+	UpdateUserSettings(ctx context.Context, userID uuid.UUID, req dto.UpdateUserSettingsRequest) (dto.UserSettingsOutput, error)
+}
+
+// @Summary Get user settings
+// @Description Get the settings of a user, such as the default time zone of cost calculations
+// @Tags users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id path string true "User ID (UUID)"
+// @Success 200 {object} dto.UserSettingsOutput
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/{user_id}/settings [get]
+func (h *SubscriptionHandler) userSettings(c *gin.Context) {
+	log := h.loggerWith(c)
+	userID, err := h.parseID(c, "user_id")
+	if err != nil {
+		log.Warn("Invalid user ID", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	settings, err := h.users.UserSettings(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserSettingsNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "user settings not found")
+			return
+		}
+		log.Error("Failed to get user settings", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// @Summary Update user settings
+// @Description Set the settings of a user. The time zone is an IANA name used by cost calculations without a tz parameter.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id path string true "User ID (UUID)"
+// @Param settings body dto.UpdateUserSettingsRequest true "User settings"
+// @Success 200 {object} dto.UserSettingsOutput
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/{user_id}/settings [put]
+func (h *SubscriptionHandler) updateUserSettings(c *gin.Context) {
+	log := h.loggerWith(c)
+	userID, err := h.parseID(c, "user_id")
+	if err != nil {
+		log.Warn("Invalid user ID in settings update", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var req dto.UpdateUserSettingsRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Warn("Failed to bind user settings request")
+		h.errorResponse(c, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	if err := valid.ValidateStruct(req); err != nil {
+		log.Warn("Validation failed for user settings", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	settings, err := h.users.UpdateUserSettings(c.Request.Context(), userID, req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTimeZone) {
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Failed to update user settings", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"tz/internal/config"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/handler/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestUserSettings_Routes(t *testing.T) {
+	userID := uuid.New()
+	target := "/users/" + userID.String() + "/settings"
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		setup      func(s *mocks.MockUserSettingsServiceI)
+		wantStatus int
+	}{
+		{name: "get invalid id", method: http.MethodGet, target: "/users/42/settings", wantStatus: http.StatusBadRequest},
+		{
+			name: "get found", method: http.MethodGet, target: target,
+			setup: func(s *mocks.MockUserSettingsServiceI) {
+				s.EXPECT().UserSettings(gomock.Any(), userID).Return(dto.UserSettingsOutput{TimeZone: "Asia/Vladivostok"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get missing", method: http.MethodGet, target: target,
+			setup: func(s *mocks.MockUserSettingsServiceI) {
+				s.EXPECT().UserSettings(gomock.Any(), userID).Return(dto.UserSettingsOutput{}, domain.ErrUserSettingsNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "put", method: http.MethodPut, target: target, body: `{"time_zone":"Asia/Vladivostok"}`,
+			setup: func(s *mocks.MockUserSettingsServiceI) {
+				s.EXPECT().UpdateUserSettings(gomock.Any(), userID, dto.UpdateUserSettingsRequest{TimeZone: "Asia/Vladivostok"}).
+					Return(dto.UserSettingsOutput{TimeZone: "Asia/Vladivostok"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{name: "put without zone", method: http.MethodPut, target: target, body: `{}`, wantStatus: http.StatusBadRequest},
+		{
+			name: "put unknown zone", method: http.MethodPut, target: target, body: `{"time_zone":"Mars/Olympus"}`,
+			setup: func(s *mocks.MockUserSettingsServiceI) {
+				s.EXPECT().UpdateUserSettings(gomock.Any(), userID, gomock.Any()).
+					Return(dto.UserSettingsOutput{}, fmt.Errorf("%w: %q", domain.ErrInvalidTimeZone, "Mars/Olympus"))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			users := mocks.NewMockUserSettingsServiceI(gomock.NewController(t))
+			router := NewHandler(Deps{Users: users}, config.AuthConfig{}, zap.NewNop()).Init()
+			if tt.setup != nil {
+				tt.setup(users)
+			}
+
+			w := serve(router, tt.method, tt.target, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package handler
+
+//go:generate go tool mockgen -source=webhook.go -destination=mocks/webhook.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type WebhookServiceI interface {
+	CreateWebhook(ctx context.Context, req dto.CreateWebhookRequest) (dto.WebhookOutput, error)
+	Webhook(ctx context.Context, id uuid.UUID) (dto.WebhookOutput, error)
+	Webhooks(ctx context.Context) ([]dto.WebhookOutput, error)
+	UpdateWebhook(ctx context.Context, id uuid.UUID, req dto.UpdateWebhookRequest) (dto.WebhookOutput, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	Deliveries(ctx context.Context, webhookID uuid.UUID, filter dto.DeliveryFilter) ([]dto.WebhookDeliveryOutput, error)
+	RetryDelivery(ctx context.Context, webhookID, id uuid.UUID) (dto.WebhookDeliveryOutput, error)
+}
+
+// @Summary Create a webhook
+// @Description Register a URL for subscription lifecycle events. Deliveries are signed with the secret, which is generated when omitted and returned only in this response.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param webhook body dto.CreateWebhookRequest true "Webhook"
+// @Success 200 {object} dto.WebhookOutput
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks [post]
+func (h *SubscriptionHandler) createWebhook(c *gin.Context) {
+	log := h.loggerWith(c)
+	var req dto.CreateWebhookRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Warn("Failed to bind create webhook request")
+		h.errorResponse(c, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	if err := valid.ValidateStruct(req); err != nil {
+		log.Warn("Validation failed for create webhook", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := h.webhooks.CreateWebhook(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidWebhook) {
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Error("Failed to create webhook", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// @Summary List webhooks
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} dto.WebhookOutput
+// @Failure 500 {object} map[string]string
+// @Router /webhooks [get]
+func (h *SubscriptionHandler) listWebhooks(c *gin.Context) {
+	webhooks, err := h.webhooks.Webhooks(c.Request.Context())
+	if err != nil {
+		h.loggerWith(c).Error("Failed to list webhooks", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// @Summary Get a webhook
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook ID (UUID)"
+// @Success 200 {object} dto.WebhookOutput
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id} [get]
+func (h *SubscriptionHandler) webhook(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid webhook ID", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid webhook ID")
+		return
+	}
+
+	webhook, err := h.webhooks.Webhook(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "webhook not found")
+			return
+		}
+		log.Error("Failed to get webhook", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// @Summary Update a webhook
+// @Description Partially update a webhook. The response carries the secret when the request sets a new one.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook ID (UUID)"
+// @Param webhook body dto.UpdateWebhookRequest true "Fields to update"
+// @Success 200 {object} dto.WebhookOutput
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id} [patch]
+func (h *SubscriptionHandler) updateWebhook(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid webhook ID in update", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid webhook ID")
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.BindJSON(&req); err != nil {
+		log.Warn("Failed to bind update webhook request")
+		h.errorResponse(c, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	if err := valid.ValidateStruct(req); err != nil {
+		log.Warn("Validation failed for update webhook", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := h.webhooks.UpdateWebhook(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrWebhookNotFound):
+			h.errorResponse(c, http.StatusNotFound, "webhook not found")
+		case errors.Is(err, domain.ErrInvalidWebhook):
+			h.errorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			log.Error("Failed to update webhook", zap.Error(err))
+			h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// @Summary Delete a webhook
+// @Description Delete a webhook together with its deliveries
+// @Tags webhooks
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook ID (UUID)"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id} [delete]
+func (h *SubscriptionHandler) deleteWebhook(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid webhook ID in delete", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid webhook ID")
+		return
+	}
+
+	if err := h.webhooks.DeleteWebhook(c.Request.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "webhook not found")
+			return
+		}
+		log.Error("Failed to delete webhook", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List webhook deliveries
+// @Description List the deliveries of a webhook, newest first
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook ID (UUID)"
+// @Param status query string false "Delivery status" Enums(pending, delivered, dead)
+// @Param limit query int false "Maximum number of deliveries" default(50) minimum(1) maximum(500)
+// @Success 200 {array} dto.WebhookDeliveryOutput
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id}/deliveries [get]
+func (h *SubscriptionHandler) webhookDeliveries(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid webhook ID in deliveries", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid webhook ID")
+		return
+	}
+
+	var filter dto.DeliveryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		log.Warn("Failed to bind delivery filter")
+		h.errorResponse(c, http.StatusBadRequest, "invalid query parameters")
+		return
+	}
+	if err := valid.ValidateStruct(filter); err != nil {
+		log.Warn("Validation failed for delivery filter", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	deliveries, err := h.webhooks.Deliveries(c.Request.Context(), id, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			h.errorResponse(c, http.StatusNotFound, "webhook not found")
+			return
+		}
+		log.Error("Failed to list webhook deliveries", zap.Error(err))
+		h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// @Summary Retry a webhook delivery
+// @Description Make a pending or dead delivery due now with a fresh set of attempts
+// @Tags webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Webhook ID (UUID)"
+// @Param delivery_id path string true "Delivery ID (UUID)"
+// @Success 200 {object} dto.WebhookDeliveryOutput
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /webhooks/{id}/deliveries/{delivery_id}/retry [post]
+func (h *SubscriptionHandler) retryWebhookDelivery(c *gin.Context) {
+	log := h.loggerWith(c)
+	id, err := h.parseID(c, "id")
+	if err != nil {
+		log.Warn("Invalid webhook ID in retry", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid webhook ID")
+		return
+	}
+	deliveryID, err := h.parseID(c, "delivery_id")
+	if err != nil {
+		log.Warn("Invalid delivery ID in retry", zap.Error(err))
+		h.errorResponse(c, http.StatusBadRequest, "invalid delivery ID")
+		return
+	}
+
+	delivery, err := h.webhooks.RetryDelivery(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrDeliveryNotFound):
+			h.errorResponse(c, http.StatusNotFound, "webhook delivery not found")
+		case errors.Is(err, domain.ErrDeliveryDelivered):
+			h.errorResponse(c, http.StatusConflict, err.Error())
+		default:
+			log.Error("Failed to retry webhook delivery", zap.Error(err))
+			h.errorResponse(c, http.StatusInternalServerError, "internal error")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
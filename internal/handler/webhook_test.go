@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"tz/internal/config"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/handler/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestWebhook_Routes(t *testing.T) {
+	id, deliveryID := uuid.New(), uuid.New()
+	target := "/webhooks/" + id.String()
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		setup      func(s *mocks.MockWebhookServiceI)
+		wantStatus int
+	}{
+		{
+			name: "create", method: http.MethodPost, target: "/webhooks/", body: `{"url":"https://example.com/hook","event_types":["created"]}`,
+			setup: func(s *mocks.MockWebhookServiceI) {
+				s.EXPECT().CreateWebhook(gomock.Any(), dto.CreateWebhookRequest{URL: "https://example.com/hook", EventTypes: []string{"created"}}).
+					Return(dto.WebhookOutput{Secret: "whsec_x"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "create with unknown event type", method: http.MethodPost, target: "/webhooks/", body: `{"url":"https://example.com/hook","event_types":["renewed"]}`,
+			setup: func(s *mocks.MockWebhookServiceI) {
+				s.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).
+					Return(dto.WebhookOutput{}, fmt.Errorf("%w: unknown event type renewed", domain.ErrInvalidWebhook))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "create without event types", method: http.MethodPost, target: "/webhooks/", body: `{"url":"https://example.com/hook"}`, wantStatus: http.StatusBadRequest},
+		{name: "create with short secret", method: http.MethodPost, target: "/webhooks/", body: `{"url":"https://example.com/hook","secret":"x","event_types":["created"]}`, wantStatus: http.StatusBadRequest},
+		{
+			name: "list", method: http.MethodGet, target: "/webhooks/",
+			setup: func(s *mocks.MockWebhookServiceI) {
+				s.EXPECT().Webhooks(gomock.Any()).Return([]dto.WebhookOutput{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{name: "get invalid id", method: http.MethodGet, target: "/webhooks/42", wantStatus: http.StatusBadRequest},
+		{
+			name: "get missing", method: http.MethodGet, target: target,
+			setup: func(s *mocks.MockWebhookServiceI) {
+				s.EXPECT().Webhook(gomock.Any(), id).Return(dto.WebhookOutput{}, domain.ErrWebhookNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "update", method: http.MethodPatch, target: target, body: `{"event_types":["deleted"]}`,
+			setup: func(s *mocks.MockWebhookServiceI) {
+				s.EXPECT().UpdateWebhook(gomock.Any(), id, dto.UpdateWebhookRequest{EventTypes: &[]string{"deleted"}}).Return(dto.WebhookOutput{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "delete", method: http.MethodDelete, target: target,
+			setup: func(s *mocks.MockWebhookServiceI) {
+				s.EXPECT().DeleteWebhook(gomock.Any(), id).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "deliveries", method: http.MethodGet, target: target + "/deliveries?status=dead&limit=10",
+			setup: func(s *mocks.MockWebhookServiceI) {
+				s.EXPECT().Deliveries(gomock.Any(), id, dto.DeliveryFilter{Status: ptr("dead"), Limit: 10}).Return([]dto.WebhookDeliveryOutput{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{name: "deliveries with unknown status", method: http.MethodGet, target: target + "/deliveries?status=lost", wantStatus: http.StatusBadRequest},
+		{
+			name: "retry", method: http.MethodPost, target: target + "/deliveries/" + deliveryID.String() + "/retry",
+			setup: func(s *mocks.MockWebhookServiceI) {
+				s.EXPECT().RetryDelivery(gomock.Any(), id, deliveryID).Return(dto.WebhookDeliveryOutput{Status: "pending"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "retry delivered", method: http.MethodPost, target: target + "/deliveries/" + deliveryID.String() + "/retry",
+			setup: func(s *mocks.MockWebhookServiceI) {
+				s.EXPECT().RetryDelivery(gomock.Any(), id, deliveryID).Return(dto.WebhookDeliveryOutput{}, domain.ErrDeliveryDelivered)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "retry missing", method: http.MethodPost, target: target + "/deliveries/" + deliveryID.String() + "/retry",
+			setup: func(s *mocks.MockWebhookServiceI) {
+				s.EXPECT().RetryDelivery(gomock.Any(), id, deliveryID).Return(dto.WebhookDeliveryOutput{}, domain.ErrDeliveryNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			webhooks := mocks.NewMockWebhookServiceI(gomock.NewController(t))
+			router := NewHandler(Deps{Webhooks: webhooks}, config.AuthConfig{}, zap.NewNop()).Init()
+			if tt.setup != nil {
+				tt.setup(webhooks)
+			}
+
+			w := serve(router, tt.method, tt.target, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChecker_Ready(t *testing.T) {
+	checker := New(50 * time.Millisecond)
+	checker.Register("db", func(context.Context) error { return nil })
+
+	if report := checker.Ready(context.Background()); report.Status != StatusOK {
+		t.Fatalf("Ready() status = %s, want %s: %+v", report.Status, StatusOK, report)
+	}
+
+	checker.Register("cache", func(context.Context) error { return errors.New("connection refused") })
+	report := checker.Ready(context.Background())
+	if report.Status != StatusFail {
+		t.Fatalf("Ready() status = %s, want %s", report.Status, StatusFail)
+	}
+	if got := report.Checks["cache"]; got.Status != StatusFail || got.Error != "connection refused" {
+		t.Errorf("cache check = %+v, want failure with error", got)
+	}
+	if got := report.Checks["db"]; got.Status != StatusOK {
+		t.Errorf("db check = %+v, want ok", got)
+	}
+}
+
+func TestChecker_Timeout(t *testing.T) {
+	checker := New(10 * time.Millisecond)
+	checker.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if report := checker.Ready(context.Background()); report.Status != StatusFail {
+		t.Errorf("Ready() status = %s, want %s", report.Status, StatusFail)
+	}
+}
+
+func TestChecker_ShuttingDown(t *testing.T) {
+	checker := New(time.Second)
+	checker.SetShuttingDown()
+
+	report := checker.Ready(context.Background())
+	if report.Status != StatusFail || report.Checks["shutdown"].Status != StatusFail {
+		t.Errorf("Ready() = %+v, want shutdown failure", report)
+	}
+}
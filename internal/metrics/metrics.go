@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const (
+	namespace    = "subscription"
+	statsTimeout = 2 * time.Second
+)
+
+// StatsSource provides the business figures exported as gauges on every scrape.
+type StatsSource interface {
+	SubscriptionStats(ctx context.Context) (domain.SubscriptionStats, error)
+}
+
+type Metrics struct {
+	registry     *prometheus.Registry
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	costDuration prometheus.Histogram
+	log          *zap.Logger
+}
+
+func New(log *zap.Logger) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		costDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "cost_calculation_duration_seconds",
+			Help:      "Time spent calculating subscription costs.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		log: log,
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.costDuration,
+	)
+
+	return m
+}
+
+// RegisterDB exports connection pool statistics of db.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterStats exports business gauges read from source at scrape time.
+func (m *Metrics) RegisterStats(source StatsSource) {
+	m.registry.MustRegister(newStatsCollector(source, m.log))
+}
+
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	labels := prometheus.Labels{"method": method, "route": route, "status": strconv.Itoa(status)}
+	m.httpRequests.With(labels).Inc()
+	m.httpDuration.With(labels).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveCostCalculation(duration time.Duration) {
+	m.costDuration.Observe(duration.Seconds())
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+type statsCollector struct {
+	source        StatsSource
+	log           *zap.Logger
+	active        *prometheus.Desc
+	distinctUsers *prometheus.Desc
+}
+
+func newStatsCollector(source StatsSource, log *zap.Logger) *statsCollector {
+	return &statsCollector{
+		source: source,
+		log:    log,
+		active: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "active_subscriptions"),
+			"Number of subscriptions active at scrape time.", nil, nil,
+		),
+		distinctUsers: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "distinct_users"),
+			"Number of distinct users with at least one subscription.", nil, nil,
+		),
+	}
+}
+
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.active
+	ch <- c.distinctUsers
+}
+
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), statsTimeout)
+	defer cancel()
+
+	stats, err := c.source.SubscriptionStats(ctx)
+	if err != nil {
+		c.log.Warn("failed to collect subscription stats", zap.Error(err))
+		ch <- prometheus.NewInvalidMetric(c.active, err)
+		ch <- prometheus.NewInvalidMetric(c.distinctUsers, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.active, prometheus.GaugeValue, float64(stats.Active))
+	ch <- prometheus.MustNewConstMetric(c.distinctUsers, prometheus.GaugeValue, float64(stats.DistinctUsers))
+}
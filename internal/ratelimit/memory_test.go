@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	now := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	limit := Limit{Requests: 2, Period: time.Second}
+
+	for i := 0; i < 2; i++ {
+		res, _ := store.Take(context.Background(), "client", limit)
+		if !res.Allowed {
+			t.Fatalf("request %d denied, want allowed", i+1)
+		}
+	}
+
+	res, _ := store.Take(context.Background(), "client", limit)
+	if res.Allowed {
+		t.Fatalf("third request allowed, want denied")
+	}
+	if res.Remaining != 0 || res.RetryAfter != 500*time.Millisecond {
+		t.Errorf("got remaining=%d retry_after=%v, want 0 and 500ms", res.Remaining, res.RetryAfter)
+	}
+
+	if res, _ := store.Take(context.Background(), "other", limit); !res.Allowed {
+		t.Errorf("other key denied, want its own bucket")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if res, _ := store.Take(context.Background(), "client", limit); !res.Allowed {
+		t.Errorf("request after refill denied, want allowed")
+	}
+}
+
+func TestMemoryStore_Burst(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Period: time.Hour, Burst: 3}
+
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if res, _ := store.Take(context.Background(), "client", limit); res.Allowed {
+			allowed++
+		}
+	}
+	if allowed != 3 {
+		t.Errorf("allowed %d requests, want burst of 3", allowed)
+	}
+}
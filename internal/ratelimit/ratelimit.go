@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"tz/internal/domain"
+)
+
+// Groups of the API calls, shared by the REST and gRPC transports.
+const (
+	GroupSubscriptions = "subscriptions"
+	GroupCost          = "cost"
+	GroupAdmin         = "admin"
+	// GroupAuth is spent per client IP before the credentials of a call are checked.
+	GroupAuth = "auth"
+)
+
+// ClientKey is the key of an anonymous caller, identified by its IP.
+func ClientKey(ip string) string {
+	return "ip:" + ip
+}
+
+// PrincipalKey is the key of an authenticated caller.
+func PrincipalKey(principal domain.Principal) string {
+	return principal.Kind + ":" + principal.ID
+}
+
+// Limit describes a token bucket: Burst tokens refilled at Requests per Period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+func (l Limit) capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.Requests)
+}
+
+// interval is the time needed to refill one token.
+func (l Limit) interval() time.Duration {
+	return l.Period / time.Duration(l.Requests)
+}
+
+// Result describes the state of a bucket after a Take.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Store keeps token buckets. Implementations must be safe for concurrent use;
+// a shared store lets several replicas enforce a common budget.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Limiter applies per-group limits to caller keys using a Store.
+type Limiter struct {
+	store  Store
+	limits map[string]Limit
+}
+
+func NewLimiter(store Store, limits map[string]Limit) (*Limiter, error) {
+	for group, limit := range limits {
+		if limit.Requests <= 0 || limit.Period <= 0 {
+			return nil, fmt.Errorf("invalid rate limit for group %s: requests and period must be positive", group)
+		}
+	}
+	return &Limiter{store: store, limits: limits}, nil
+}
+
+// Take consumes a token for key in group. Groups without a configured limit are not limited.
+func (l *Limiter) Take(ctx context.Context, group, key string) (Result, bool, error) {
+	limit, ok := l.limits[group]
+	if !ok {
+		return Result{Allowed: true}, false, nil
+	}
+	res, err := l.store.Take(ctx, group+":"+key, limit)
+	return res, true, err
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type apiKeyRow struct {
+	ID         uuid.UUID      `db:"id"`
+	Name       string         `db:"name"`
+	Prefix     string         `db:"prefix"`
+	KeyHash    string         `db:"key_hash"`
+	Scopes     pq.StringArray `db:"scopes"`
+	Roles      pq.StringArray `db:"roles"`
+	ExpiresAt  *time.Time     `db:"expires_at"`
+	LastUsedAt *time.Time     `db:"last_used_at"`
+	RevokedAt  *time.Time     `db:"revoked_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (r apiKeyRow) toDomain() domain.APIKey {
+	scopes := make([]domain.Scope, len(r.Scopes))
+	for i, s := range r.Scopes {
+		scopes[i] = domain.Scope(s)
+	}
+	return domain.APIKey{
+		ID:         r.ID,
+		Name:       r.Name,
+		Prefix:     r.Prefix,
+		KeyHash:    r.KeyHash,
+		Scopes:     scopes,
+		Roles:      r.Roles,
+		ExpiresAt:  r.ExpiresAt,
+		LastUsedAt: r.LastUsedAt,
+		RevokedAt:  r.RevokedAt,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, roles, expires_at, last_used_at, revoked_at, created_at`
+
+type APIKeyRepository struct {
+	db Query
+}
+
+func NewAPIKeyRepository(db Query) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key domain.APIKey) (domain.APIKey, error) {
+	scopes := make(pq.StringArray, len(key.Scopes))
+	for i, s := range key.Scopes {
+		scopes[i] = string(s)
+	}
+
+	query := `INSERT INTO api_keys (id, name, prefix, key_hash, scopes, roles, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + apiKeyColumns
+
+	var row apiKeyRow
+	err := r.db.GetContext(ctx, &row, query, key.ID, key.Name, key.Prefix, key.KeyHash, scopes, pq.StringArray(key.Roles), key.ExpiresAt)
+	if err != nil {
+		return domain.APIKey{}, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+func (r *APIKeyRepository) APIKeyByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	var row apiKeyRow
+	err := r.db.GetContext(ctx, &row, query, hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.APIKey{}, domain.ErrAPIKeyNotFound
+		}
+		return domain.APIKey{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+func (r *APIKeyRepository) APIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC`
+
+	var rows []apiKeyRow
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	keys := make([]domain.APIKey, len(rows))
+	for i, row := range rows {
+		keys[i] = row.toDomain()
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) TouchAPIKey(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = $1 WHERE id = $2`
+	if _, err := r.db.ExecContext(ctx, query, usedAt, id); err != nil {
+		return fmt.Errorf("failed to update api key usage: %w", err)
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	query := `UPDATE api_keys SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, revokedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
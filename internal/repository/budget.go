@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type budgetRow struct {
+	ID           uuid.UUID      `db:"id"`
+	UserID       uuid.UUID      `db:"user_id"`
+	Period       string         `db:"period"`
+	Amount       int            `db:"amount"`
+	ServiceNames pq.StringArray `db:"service_names"`
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at"`
+}
+
+func (r budgetRow) toDomain() domain.Budget {
+	names := []string(r.ServiceNames)
+	if names == nil {
+		names = []string{}
+	}
+	return domain.Budget{
+		ID:           r.ID,
+		UserID:       r.UserID,
+		Period:       domain.BudgetPeriod(r.Period),
+		Amount:       r.Amount,
+		ServiceNames: names,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+	}
+}
+
+const (
+	budgetColumns      = `id, user_id, period, amount, service_names, created_at, updated_at`
+	budgetAlertColumns = `id, budget_id, user_id, period_start, threshold, kind, spend, amount, created_at`
+)
+
+// BudgetRepository stores budgets and their alerts in PostgreSQL or SQLite.
+type BudgetRepository struct {
+	db Query
+}
+
+func NewBudgetRepository(db Query) *BudgetRepository {
+	return &BudgetRepository{db: db}
+}
+
+func (r *BudgetRepository) CreateBudget(ctx context.Context, b domain.Budget) (domain.Budget, error) {
+	query := `INSERT INTO budgets (id, user_id, period, amount, service_names)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + budgetColumns
+
+	var row budgetRow
+	err := r.db.GetContext(ctx, &row, query, b.ID, b.UserID, string(b.Period), b.Amount, stringArray(b.ServiceNames))
+	if err != nil {
+		return domain.Budget{}, fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+func (r *BudgetRepository) Budget(ctx context.Context, id uuid.UUID) (domain.Budget, error) {
+	query := `SELECT ` + budgetColumns + ` FROM budgets WHERE id = $1`
+
+	var row budgetRow
+	if err := r.db.GetContext(ctx, &row, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Budget{}, domain.ErrBudgetNotFound
+		}
+		return domain.Budget{}, fmt.Errorf("failed to get budget: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+// Budgets returns the budgets of userID, or of all users when userID is nil, oldest first.
+func (r *BudgetRepository) Budgets(ctx context.Context, userID *uuid.UUID) ([]domain.Budget, error) {
+	query := `SELECT ` + budgetColumns + ` FROM budgets`
+	var args []interface{}
+	if userID != nil {
+		query += ` WHERE user_id = $1`
+		args = append(args, *userID)
+	}
+	query += ` ORDER BY created_at, id`
+
+	var rows []budgetRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	budgets := make([]domain.Budget, len(rows))
+	for i, row := range rows {
+		budgets[i] = row.toDomain()
+	}
+	return budgets, nil
+}
+
+func (r *BudgetRepository) UpdateBudget(ctx context.Context, id uuid.UUID, upd domain.UpdateBudget) (domain.Budget, error) {
+	var (
+		set    []string
+		args   []interface{}
+		argIdx = 1
+	)
+
+	if upd.Period != nil {
+		set = append(set, fmt.Sprintf("period = $%d", argIdx))
+		args = append(args, string(*upd.Period))
+		argIdx++
+	}
+	if upd.Amount != nil {
+		set = append(set, fmt.Sprintf("amount = $%d", argIdx))
+		args = append(args, *upd.Amount)
+		argIdx++
+	}
+	if upd.ServiceNames != nil {
+		set = append(set, fmt.Sprintf("service_names = $%d", argIdx))
+		args = append(args, stringArray(*upd.ServiceNames))
+		argIdx++
+	}
+
+	if len(set) == 0 {
+		return r.Budget(ctx, id)
+	}
+
+	set = append(set, fmt.Sprintf("updated_at = $%d", argIdx))
+	args = append(args, time.Now().UTC())
+	argIdx++
+	args = append(args, id)
+
+	query := fmt.Sprintf(`UPDATE budgets SET %s WHERE id = $%d RETURNING %s`, strings.Join(set, ", "), argIdx, budgetColumns)
+
+	var row budgetRow
+	if err := r.db.GetContext(ctx, &row, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Budget{}, domain.ErrBudgetNotFound
+		}
+		return domain.Budget{}, fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+// DeleteBudget removes a budget together with its alerts.
+func (r *BudgetRepository) DeleteBudget(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM budgets WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrBudgetNotFound
+	}
+
+	return nil
+}
+
+// CreateBudgetAlert records an alert unless one with the same budget, period start,
+// threshold and kind exists. The boolean reports whether the alert was recorded.
+func (r *BudgetRepository) CreateBudgetAlert(ctx context.Context, alert domain.BudgetAlert) (domain.BudgetAlert, bool, error) {
+	query := `INSERT INTO budget_alerts (id, budget_id, user_id, period_start, threshold, kind, spend, amount)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (budget_id, period_start, threshold, kind) DO NOTHING
+		RETURNING ` + budgetAlertColumns
+
+	var created domain.BudgetAlert
+	err := r.db.GetContext(ctx, &created, query,
+		alert.ID, alert.BudgetID, alert.UserID, alert.PeriodStart, alert.Threshold, string(alert.Kind), alert.Spend, alert.Amount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.BudgetAlert{}, false, nil
+		}
+		return domain.BudgetAlert{}, false, fmt.Errorf("failed to create budget alert: %w", err)
+	}
+
+	return created, true, nil
+}
+
+// BudgetAlerts returns the alerts of a budget, newest first.
+func (r *BudgetRepository) BudgetAlerts(ctx context.Context, budgetID uuid.UUID) ([]domain.BudgetAlert, error) {
+	query := `SELECT ` + budgetAlertColumns + ` FROM budget_alerts
+		WHERE budget_id = $1
+		ORDER BY period_start DESC, threshold DESC, kind, id`
+
+	alerts := []domain.BudgetAlert{}
+	if err := r.db.SelectContext(ctx, &alerts, query, budgetID); err != nil {
+		return nil, fmt.Errorf("failed to get budget alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// stringArray converts a nil slice to an empty array, which pq.StringArray would store as NULL.
+func stringArray(s []string) pq.StringArray {
+	if s == nil {
+		return pq.StringArray{}
+	}
+	return pq.StringArray(s)
+}
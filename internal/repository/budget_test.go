@@ -0,0 +1,126 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	"tz/internal/config"
+	"tz/internal/db"
+	"tz/internal/domain"
+	"tz/internal/repository"
+	"tz/internal/service"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// budgetBackends lists the BudgetRepositoryI implementations under test. PostgreSQL is
+// added by the integration build tag, see postgres_test.go.
+var budgetBackends = map[string]func(t *testing.T) service.BudgetRepositoryI{
+	"memory": func(t *testing.T) service.BudgetRepositoryI {
+		return repository.NewMemoryBudgetRepository()
+	},
+	"sqlite": func(t *testing.T) service.BudgetRepositoryI {
+		database, err := db.NewSQLite(config.DatabaseConfig{SQLite: config.SQLiteConfig{Path: ":memory:"}}, zap.NewNop())
+		if err != nil {
+			t.Fatalf("NewSQLite() error = %v", err)
+		}
+		t.Cleanup(func() { _ = database.Close() })
+		return repository.NewBudgetRepository(database)
+	},
+}
+
+func TestBudgetRepository(t *testing.T) {
+	for backend, open := range budgetBackends {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			repo := open(t)
+			userID := uuid.New()
+
+			created, err := repo.CreateBudget(ctx, domain.Budget{
+				ID: uuid.New(), UserID: userID, Period: domain.BudgetPeriodMonth, Amount: 2000, ServiceNames: []string{"Netflix", "Spotify"},
+			})
+			if err != nil {
+				t.Fatalf("CreateBudget() error = %v", err)
+			}
+			if created.CreatedAt.IsZero() || !slices.Equal(created.ServiceNames, []string{"Netflix", "Spotify"}) {
+				t.Errorf("CreateBudget() = %+v", created)
+			}
+			other, err := repo.CreateBudget(ctx, domain.Budget{ID: uuid.New(), UserID: uuid.New(), Period: domain.BudgetPeriodYear, Amount: 100})
+			if err != nil {
+				t.Fatalf("CreateBudget() error = %v", err)
+			}
+			if other.ServiceNames == nil || len(other.ServiceNames) != 0 {
+				t.Errorf("ServiceNames = %#v, want empty", other.ServiceNames)
+			}
+
+			if _, err := repo.CreateBudget(ctx, domain.Budget{ID: uuid.New(), UserID: userID, Period: domain.BudgetPeriodMonth}); err == nil {
+				t.Error("CreateBudget() without amount succeeded")
+			}
+			if _, err := repo.CreateBudget(ctx, domain.Budget{ID: uuid.New(), UserID: userID, Period: "week", Amount: 1}); err == nil {
+				t.Error("CreateBudget() with unknown period succeeded")
+			}
+
+			mine, err := repo.Budgets(ctx, &userID)
+			if err != nil || len(mine) != 1 || mine[0].ID != created.ID {
+				t.Errorf("Budgets(user) = %+v, %v", mine, err)
+			}
+			all, err := repo.Budgets(ctx, nil)
+			if err != nil || len(all) != 2 {
+				t.Errorf("Budgets(nil) = %d budgets, %v, want 2", len(all), err)
+			}
+
+			year, amount, names := domain.BudgetPeriodYear, 24000, []string{}
+			updated, err := repo.UpdateBudget(ctx, created.ID, domain.UpdateBudget{Period: &year, Amount: &amount, ServiceNames: &names})
+			if err != nil || updated.Period != year || updated.Amount != amount || len(updated.ServiceNames) != 0 {
+				t.Errorf("UpdateBudget() = %+v, %v", updated, err)
+			}
+			if _, err := repo.UpdateBudget(ctx, uuid.New(), domain.UpdateBudget{Amount: &amount}); !errors.Is(err, domain.ErrBudgetNotFound) {
+				t.Errorf("UpdateBudget(missing) error = %v, want ErrBudgetNotFound", err)
+			}
+
+			period := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+			alert := domain.BudgetAlert{
+				BudgetID: created.ID, UserID: userID, PeriodStart: period,
+				Threshold: 80, Kind: domain.AlertKindActual, Spend: 1700, Amount: 2000,
+			}
+			for i, want := range []bool{true, false} {
+				alert.ID = uuid.New()
+				got, ok, err := repo.CreateBudgetAlert(ctx, alert)
+				if err != nil || ok != want {
+					t.Fatalf("CreateBudgetAlert() #%d = %v, %v, want %v", i, ok, err, want)
+				}
+				if ok && (!got.PeriodStart.Equal(period) || got.CreatedAt.IsZero()) {
+					t.Errorf("CreateBudgetAlert() = %+v", got)
+				}
+			}
+			alert.ID, alert.Kind = uuid.New(), domain.AlertKindForecast
+			if _, ok, err := repo.CreateBudgetAlert(ctx, alert); err != nil || !ok {
+				t.Fatalf("CreateBudgetAlert(forecast) = %v, %v", ok, err)
+			}
+
+			alerts, err := repo.BudgetAlerts(ctx, created.ID)
+			if err != nil || len(alerts) != 2 || alerts[0].Kind != domain.AlertKindActual {
+				t.Errorf("BudgetAlerts() = %+v, %v", alerts, err)
+			}
+
+			if err := repo.DeleteBudget(ctx, created.ID); err != nil {
+				t.Fatalf("DeleteBudget() error = %v", err)
+			}
+			if _, err := repo.Budget(ctx, created.ID); !errors.Is(err, domain.ErrBudgetNotFound) {
+				t.Errorf("Budget(deleted) error = %v, want ErrBudgetNotFound", err)
+			}
+			if alerts, err := repo.BudgetAlerts(ctx, created.ID); err != nil || len(alerts) != 0 {
+				t.Errorf("BudgetAlerts(deleted) = %+v, %v, want none", alerts, err)
+			}
+			if err := repo.DeleteBudget(ctx, created.ID); !errors.Is(err, domain.ErrBudgetNotFound) {
+				t.Errorf("DeleteBudget(deleted) error = %v, want ErrBudgetNotFound", err)
+			}
+			if _, _, err := repo.CreateBudgetAlert(ctx, alert); err == nil {
+				t.Error("CreateBudgetAlert() for a deleted budget succeeded")
+			}
+		})
+	}
+}
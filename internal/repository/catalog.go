@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type serviceRow struct {
+	ID           uuid.UUID `db:"id"`
+	Name         string    `db:"name"`
+	Category     string    `db:"category"`
+	URL          string    `db:"url"`
+	DefaultPrice int       `db:"default_price"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+func (r serviceRow) toDomain(aliases []string) domain.Service {
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return domain.Service{
+		ID:           r.ID,
+		Name:         r.Name,
+		Aliases:      aliases,
+		Category:     r.Category,
+		URL:          r.URL,
+		DefaultPrice: r.DefaultPrice,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+	}
+}
+
+type aliasRow struct {
+	ServiceID uuid.UUID `db:"service_id"`
+	Alias     string    `db:"alias"`
+}
+
+const serviceColumns = `id, name, category, url, default_price, created_at, updated_at`
+
+// CatalogRepository stores the services catalog in PostgreSQL or SQLite. Names and
+// aliases are looked up by their domain.NormalizeServiceName keys. Writes touching
+// aliases run several statements and belong in a transaction.
+type CatalogRepository struct {
+	db Query
+}
+
+func NewCatalogRepository(db Query) *CatalogRepository {
+	return &CatalogRepository{db: db}
+}
+
+func (r *CatalogRepository) CreateService(ctx context.Context, svc domain.Service) (domain.Service, error) {
+	query := `INSERT INTO services (id, name, name_key, category, url, default_price)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		svc.ID, svc.Name, domain.NormalizeServiceName(svc.Name), svc.Category, svc.URL, svc.DefaultPrice)
+	if err != nil {
+		return domain.Service{}, fmt.Errorf("failed to create service: %w", err)
+	}
+	if err := r.insertAliases(ctx, svc.ID, svc.Aliases); err != nil {
+		return domain.Service{}, fmt.Errorf("failed to create service: %w", err)
+	}
+
+	return r.Service(ctx, svc.ID)
+}
+
+func (r *CatalogRepository) Service(ctx context.Context, id uuid.UUID) (domain.Service, error) {
+	return r.service(ctx, `WHERE id = $1`, id)
+}
+
+// ResolveService returns the service whose name or one of whose aliases normalizes
+// like name.
+func (r *CatalogRepository) ResolveService(ctx context.Context, name string) (domain.Service, error) {
+	return r.service(ctx, `WHERE name_key = $1 OR id = (SELECT service_id FROM service_aliases WHERE alias_key = $1)`,
+		domain.NormalizeServiceName(name))
+}
+
+func (r *CatalogRepository) service(ctx context.Context, where string, args ...interface{}) (domain.Service, error) {
+	query := `SELECT ` + serviceColumns + ` FROM services ` + where
+
+	var row serviceRow
+	if err := r.db.GetContext(ctx, &row, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Service{}, domain.ErrServiceNotFound
+		}
+		return domain.Service{}, fmt.Errorf("failed to get service: %w", err)
+	}
+
+	aliases, err := r.aliases(ctx, `WHERE service_id = $1`, row.ID)
+	if err != nil {
+		return domain.Service{}, err
+	}
+	return row.toDomain(aliases[row.ID]), nil
+}
+
+// Services returns the services matching filter ordered by name.
+func (r *CatalogRepository) Services(ctx context.Context, filter domain.ServiceFilter) ([]domain.Service, error) {
+	var (
+		where string
+		args  []interface{}
+	)
+	if filter.Category != nil {
+		where = `WHERE category = $1`
+		args = append(args, *filter.Category)
+	}
+
+	var rows []serviceRow
+	query := `SELECT ` + serviceColumns + ` FROM services ` + where + ` ORDER BY name_key, id`
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get services: %w", err)
+	}
+
+	aliases, err := r.aliases(ctx, `WHERE service_id IN (SELECT id FROM services `+where+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]domain.Service, len(rows))
+	for i, row := range rows {
+		services[i] = row.toDomain(aliases[row.ID])
+	}
+	return services, nil
+}
+
+// aliases returns the aliases matching where by service, in alias order.
+func (r *CatalogRepository) aliases(ctx context.Context, where string, args ...interface{}) (map[uuid.UUID][]string, error) {
+	var rows []aliasRow
+	query := `SELECT service_id, alias FROM service_aliases ` + where + ` ORDER BY alias_key`
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get service aliases: %w", err)
+	}
+
+	aliases := make(map[uuid.UUID][]string)
+	for _, row := range rows {
+		aliases[row.ServiceID] = append(aliases[row.ServiceID], row.Alias)
+	}
+	return aliases, nil
+}
+
+// UpdateService changes the fields set in upd. Aliases, when set, replace the current ones.
+// A new name is written to the subscriptions of the service as well.
+func (r *CatalogRepository) UpdateService(ctx context.Context, id uuid.UUID, upd domain.UpdateService) (domain.Service, error) {
+	var (
+		set    []string
+		args   []interface{}
+		argIdx = 1
+	)
+
+	if upd.Name != nil {
+		set = append(set, fmt.Sprintf("name = $%d", argIdx), fmt.Sprintf("name_key = $%d", argIdx+1))
+		args = append(args, *upd.Name, domain.NormalizeServiceName(*upd.Name))
+		argIdx += 2
+	}
+	if upd.Category != nil {
+		set = append(set, fmt.Sprintf("category = $%d", argIdx))
+		args = append(args, *upd.Category)
+		argIdx++
+	}
+	if upd.URL != nil {
+		set = append(set, fmt.Sprintf("url = $%d", argIdx))
+		args = append(args, *upd.URL)
+		argIdx++
+	}
+	if upd.DefaultPrice != nil {
+		set = append(set, fmt.Sprintf("default_price = $%d", argIdx))
+		args = append(args, *upd.DefaultPrice)
+		argIdx++
+	}
+
+	if len(set) == 0 && upd.Aliases == nil {
+		return r.Service(ctx, id)
+	}
+
+	set = append(set, fmt.Sprintf("updated_at = $%d", argIdx))
+	args = append(args, time.Now().UTC())
+	argIdx++
+	args = append(args, id)
+
+	query := fmt.Sprintf(`UPDATE services SET %s WHERE id = $%d`, strings.Join(set, ", "), argIdx)
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return domain.Service{}, fmt.Errorf("failed to update service: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return domain.Service{}, domain.ErrServiceNotFound
+	}
+
+	if upd.Name != nil {
+		if _, err := r.db.ExecContext(ctx, `UPDATE subscriptions SET service_name = $1 WHERE service_id = $2`, *upd.Name, id); err != nil {
+			return domain.Service{}, fmt.Errorf("failed to rename subscriptions of service: %w", err)
+		}
+	}
+	if upd.Aliases != nil {
+		if _, err := r.db.ExecContext(ctx, `DELETE FROM service_aliases WHERE service_id = $1`, id); err != nil {
+			return domain.Service{}, fmt.Errorf("failed to update service: %w", err)
+		}
+		if err := r.insertAliases(ctx, id, *upd.Aliases); err != nil {
+			return domain.Service{}, fmt.Errorf("failed to update service: %w", err)
+		}
+	}
+
+	return r.Service(ctx, id)
+}
+
+// DeleteService removes a service with its aliases. Its subscriptions keep their
+// service name and lose the link to the catalog.
+func (r *CatalogRepository) DeleteService(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM services WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrServiceNotFound
+	}
+
+	return nil
+}
+
+func (r *CatalogRepository) insertAliases(ctx context.Context, serviceID uuid.UUID, aliases []string) error {
+	for _, alias := range aliases {
+		query := `INSERT INTO service_aliases (alias_key, service_id, alias) VALUES ($1, $2, $3)`
+		if _, err := r.db.ExecContext(ctx, query, domain.NormalizeServiceName(alias), serviceID, alias); err != nil {
+			return fmt.Errorf("failed to add alias %q: %w", alias, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	"tz/internal/config"
+	"tz/internal/db"
+	"tz/internal/domain"
+	"tz/internal/repository"
+	"tz/internal/service"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// catalogBackends lists the CatalogRepositoryI implementations under test together with
+// the subscriptions they are linked to. PostgreSQL is added by the integration build
+// tag, see postgres_test.go.
+var catalogBackends = map[string]func(t *testing.T) (service.CatalogRepositoryI, service.SubscriptionRepositoryI){
+	"memory": func(t *testing.T) (service.CatalogRepositoryI, service.SubscriptionRepositoryI) {
+		subs := repository.NewMemorySubscriptionRepository()
+		return repository.NewMemoryCatalogRepository(subs), subs
+	},
+	"sqlite": func(t *testing.T) (service.CatalogRepositoryI, service.SubscriptionRepositoryI) {
+		database, err := db.NewSQLite(config.DatabaseConfig{SQLite: config.SQLiteConfig{Path: ":memory:"}}, zap.NewNop())
+		if err != nil {
+			t.Fatalf("NewSQLite() error = %v", err)
+		}
+		t.Cleanup(func() { _ = database.Close() })
+		return repository.NewCatalogRepository(database), repository.NewSubscriptionRepository(database)
+	},
+}
+
+func TestCatalogRepository(t *testing.T) {
+	for backend, open := range catalogBackends {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			repo, subs := open(t)
+
+			netflix, err := repo.CreateService(ctx, domain.Service{
+				ID: uuid.New(), Name: "Netflix", Aliases: []string{"Нетфликс", "Netflix Premium"},
+				Category: "streaming", URL: "https://www.netflix.com", DefaultPrice: 799,
+			})
+			if err != nil {
+				t.Fatalf("CreateService() error = %v", err)
+			}
+			if netflix.CreatedAt.IsZero() || !slices.Equal(netflix.Aliases, []string{"Netflix Premium", "Нетфликс"}) || netflix.DefaultPrice != 799 {
+				t.Errorf("CreateService() = %+v", netflix)
+			}
+			spotify, err := repo.CreateService(ctx, domain.Service{ID: uuid.New(), Name: "Spotify", Category: "music"})
+			if err != nil {
+				t.Fatalf("CreateService() error = %v", err)
+			}
+			if spotify.Aliases == nil || len(spotify.Aliases) != 0 {
+				t.Errorf("Aliases = %#v, want empty", spotify.Aliases)
+			}
+
+			if _, err := repo.CreateService(ctx, domain.Service{ID: uuid.New(), Name: " NETFLIX"}); err == nil {
+				t.Error("CreateService() with a taken name succeeded")
+			}
+			if _, err := repo.CreateService(ctx, domain.Service{ID: uuid.New(), Name: "Kion", DefaultPrice: -1}); err == nil {
+				t.Error("CreateService() with a negative price succeeded")
+			}
+
+			for _, name := range []string{"netflix", " Netflix ", "нетфликс", "netflix  premium"} {
+				if got, err := repo.ResolveService(ctx, name); err != nil || got.ID != netflix.ID {
+					t.Errorf("ResolveService(%q) = %v, %v, want Netflix", name, got.ID, err)
+				}
+			}
+			if _, err := repo.ResolveService(ctx, "Kion"); !errors.Is(err, domain.ErrServiceNotFound) {
+				t.Errorf("ResolveService(unknown) error = %v, want ErrServiceNotFound", err)
+			}
+
+			category := "music"
+			music, err := repo.Services(ctx, domain.ServiceFilter{Category: &category})
+			if err != nil || len(music) != 1 || music[0].ID != spotify.ID {
+				t.Errorf("Services(music) = %+v, %v", music, err)
+			}
+			all, err := repo.Services(ctx, domain.ServiceFilter{})
+			if err != nil || len(all) != 2 || all[0].ID != netflix.ID || len(all[0].Aliases) != 2 {
+				t.Errorf("Services() = %+v, %v", all, err)
+			}
+
+			name, price, aliases := "Netflix Standard", 899, []string{"Netflix"}
+			updated, err := repo.UpdateService(ctx, netflix.ID, domain.UpdateService{Name: &name, DefaultPrice: &price, Aliases: &aliases})
+			if err != nil || updated.Name != name || updated.DefaultPrice != price || !slices.Equal(updated.Aliases, aliases) {
+				t.Errorf("UpdateService() = %+v, %v", updated, err)
+			}
+			if got, err := repo.ResolveService(ctx, "Нетфликс"); !errors.Is(err, domain.ErrServiceNotFound) {
+				t.Errorf("ResolveService(removed alias) = %+v, %v, want ErrServiceNotFound", got, err)
+			}
+			if _, err := repo.UpdateService(ctx, uuid.New(), domain.UpdateService{Name: &name}); !errors.Is(err, domain.ErrServiceNotFound) {
+				t.Errorf("UpdateService(missing) error = %v, want ErrServiceNotFound", err)
+			}
+
+			sub, err := subs.CreateSubscription(ctx, domain.Subscription{
+				ID: uuid.New(), UserID: uuid.New(), ServiceID: &netflix.ID, ServiceName: updated.Name, Price: 899,
+				StartDate: time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC), BillingPeriod: 1,
+			})
+			if err != nil || sub.ServiceID == nil || *sub.ServiceID != netflix.ID {
+				t.Fatalf("CreateSubscription() = %+v, %v", sub, err)
+			}
+
+			name = "Netflix Basic"
+			if _, err := repo.UpdateService(ctx, netflix.ID, domain.UpdateService{Name: &name}); err != nil {
+				t.Fatalf("UpdateService() error = %v", err)
+			}
+			if got, err := subs.SubscriptionByID(ctx, sub.ID); err != nil || got.ServiceName != name {
+				t.Errorf("SubscriptionByID() after rename = %+v, %v, want service name %q", got, err, name)
+			}
+			if got, _, err := subs.Subscriptions(ctx, domain.SubscriptionFilter{ServiceID: &netflix.ID, Limit: 10}); err != nil || len(got) != 1 || got[0].ID != sub.ID {
+				t.Errorf("Subscriptions(service id) = %+v, %v, want the subscription", got, err)
+			}
+
+			if err := repo.DeleteService(ctx, netflix.ID); err != nil {
+				t.Fatalf("DeleteService() error = %v", err)
+			}
+			if _, err := repo.Service(ctx, netflix.ID); !errors.Is(err, domain.ErrServiceNotFound) {
+				t.Errorf("Service(deleted) error = %v, want ErrServiceNotFound", err)
+			}
+			if err := repo.DeleteService(ctx, netflix.ID); !errors.Is(err, domain.ErrServiceNotFound) {
+				t.Errorf("DeleteService(deleted) error = %v, want ErrServiceNotFound", err)
+			}
+			if got, err := subs.SubscriptionByID(ctx, sub.ID); err != nil || got.ServiceID != nil || got.ServiceName != name {
+				t.Errorf("SubscriptionByID() after delete = %+v, %v, want the name without the link", got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,648 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	"tz/internal/config"
+	"tz/internal/db"
+	"tz/internal/domain"
+	"tz/internal/repository"
+	"tz/internal/service"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// backends lists the SubscriptionRepositoryI implementations under test. PostgreSQL is
+// added by the integration build tag, see postgres_test.go.
+var backends = map[string]func(t *testing.T) service.SubscriptionRepositoryI{
+	"memory": func(t *testing.T) service.SubscriptionRepositoryI {
+		return repository.NewMemorySubscriptionRepository()
+	},
+	"sqlite": func(t *testing.T) service.SubscriptionRepositoryI {
+		database, err := db.NewSQLite(config.DatabaseConfig{SQLite: config.SQLiteConfig{Path: ":memory:"}}, zap.NewNop())
+		if err != nil {
+			t.Fatalf("NewSQLite() error = %v", err)
+		}
+		t.Cleanup(func() { _ = database.Close() })
+		return repository.NewSubscriptionRepository(database)
+	},
+}
+
+func TestSubscriptionRepositoryConformance(t *testing.T) {
+	tests := map[string]func(t *testing.T, repo service.SubscriptionRepositoryI){
+		"create and get":         testCreateAndGet,
+		"not found":              testNotFound,
+		"day precision":          testDayPrecision,
+		"filter":                 testFilter,
+		"subscriptions of users": testSubscriptionsOfUsers,
+		"pagination":             testPagination,
+		"update":                 testUpdate,
+		"update all fields":      testUpdateAllFields,
+		"constraints":            testConstraints,
+		"delete":                 testDelete,
+		"delete with filter":     testDeleteWithFilter,
+		"cost selection":         testCostSelection,
+		"stats":                  testStats,
+		"concurrent creations":   testConcurrentCreations,
+		"price changes":          testPriceChanges,
+		"upcoming":               testUpcoming,
+		"expiry":                 testExpiry,
+		"labels":                 testLabels,
+	}
+
+	for backend, open := range backends {
+		t.Run(backend, func(t *testing.T) {
+			for name, test := range tests {
+				t.Run(name, func(t *testing.T) {
+					test(t, open(t))
+				})
+			}
+		})
+	}
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func newSubscription(userID uuid.UUID, serviceName string, start time.Time, end *time.Time) domain.Subscription {
+	return domain.Subscription{
+		ID:            uuid.New(),
+		UserID:        userID,
+		ServiceName:   serviceName,
+		Price:         400,
+		StartDate:     start,
+		EndDate:       end,
+		BillingPeriod: 1,
+	}
+}
+
+func mustCreate(t *testing.T, repo service.SubscriptionRepositoryI, sub domain.Subscription) domain.Subscription {
+	t.Helper()
+	created, err := repo.CreateSubscription(context.Background(), sub)
+	if err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+	return created
+}
+
+func testCreateAndGet(t *testing.T, repo service.SubscriptionRepositoryI) {
+	end := month(2025, time.December)
+	sub := newSubscription(uuid.New(), "Yandex Plus", month(2025, time.July), &end)
+
+	created := mustCreate(t, repo, sub)
+	if created.CreatedAt.IsZero() || created.UpdatedAt.IsZero() {
+		t.Errorf("timestamps are not set: %+v", created)
+	}
+
+	got, err := repo.SubscriptionByID(context.Background(), sub.ID)
+	if err != nil {
+		t.Fatalf("SubscriptionByID() error = %v", err)
+	}
+	if got.ID != sub.ID || got.UserID != sub.UserID || got.ServiceName != sub.ServiceName || got.Price != sub.Price {
+		t.Errorf("SubscriptionByID() = %+v, want %+v", got, sub)
+	}
+	if !got.StartDate.Equal(sub.StartDate) {
+		t.Errorf("StartDate = %v, want %v", got.StartDate, sub.StartDate)
+	}
+	if got.EndDate == nil || !got.EndDate.Equal(end) {
+		t.Errorf("EndDate = %v, want %v", got.EndDate, end)
+	}
+}
+
+func testDayPrecision(t *testing.T, repo service.SubscriptionRepositoryI) {
+	start := time.Date(2025, time.July, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.August, 3, 0, 0, 0, 0, time.UTC)
+	sub := mustCreate(t, repo, newSubscription(uuid.New(), "Yandex Plus", start, &end))
+
+	got, err := repo.SubscriptionByID(context.Background(), sub.ID)
+	if err != nil {
+		t.Fatalf("SubscriptionByID() error = %v", err)
+	}
+	if !got.StartDate.Equal(start) || got.EndDate == nil || !got.EndDate.Equal(end) {
+		t.Errorf("dates = %v, %v, want %v, %v", got.StartDate, got.EndDate, start, end)
+	}
+}
+
+func testNotFound(t *testing.T, repo service.SubscriptionRepositoryI) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	if _, err := repo.SubscriptionByID(ctx, id); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("SubscriptionByID() error = %v, want ErrNotFound", err)
+	}
+	price := 100
+	if _, err := repo.UpdateSubscription(ctx, id, domain.UpdateSubscription{Price: &price}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("UpdateSubscription() error = %v, want ErrNotFound", err)
+	}
+	if err := repo.DeleteSubscription(ctx, id); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("DeleteSubscription() error = %v, want ErrNotFound", err)
+	}
+}
+
+func testFilter(t *testing.T, repo service.SubscriptionRepositoryI) {
+	alice, bob := uuid.New(), uuid.New()
+	netflix, spotify := "Netflix", "Spotify"
+	mustCreate(t, repo, newSubscription(alice, netflix, month(2025, time.January), nil))
+	mustCreate(t, repo, newSubscription(alice, spotify, month(2025, time.February), nil))
+	mustCreate(t, repo, newSubscription(bob, netflix, month(2025, time.March), nil))
+
+	tests := []struct {
+		name   string
+		filter domain.SubscriptionFilter
+		want   int
+	}{
+		{"all", domain.SubscriptionFilter{}, 3},
+		{"user", domain.SubscriptionFilter{UserID: &alice}, 2},
+		{"service", domain.SubscriptionFilter{ServiceName: &netflix}, 2},
+		{"user and service", domain.SubscriptionFilter{UserID: &bob, ServiceName: &netflix}, 1},
+		{"no match", domain.SubscriptionFilter{UserID: &bob, ServiceName: &spotify}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.Limit = 10
+			subs, total, err := repo.Subscriptions(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("Subscriptions() error = %v", err)
+			}
+			if total != tt.want || len(subs) != tt.want {
+				t.Errorf("Subscriptions() returned %d of total %d, want %d", len(subs), total, tt.want)
+			}
+			for _, sub := range subs {
+				if tt.filter.UserID != nil && sub.UserID != *tt.filter.UserID {
+					t.Errorf("subscription %s has user %s", sub.ID, sub.UserID)
+				}
+				if tt.filter.ServiceName != nil && sub.ServiceName != *tt.filter.ServiceName {
+					t.Errorf("subscription %s has service %s", sub.ID, sub.ServiceName)
+				}
+			}
+		})
+	}
+}
+
+func testSubscriptionsOfUsers(t *testing.T, repo service.SubscriptionRepositoryI) {
+	ctx := context.Background()
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	first := mustCreate(t, repo, newSubscription(alice, "Netflix", month(2025, time.January), nil))
+	mustCreate(t, repo, newSubscription(bob, "Netflix", month(2025, time.February), nil))
+	mustCreate(t, repo, newSubscription(carol, "Netflix", month(2025, time.March), nil))
+	second := mustCreate(t, repo, newSubscription(alice, "Spotify", month(2025, time.April), nil))
+
+	subs, err := repo.SubscriptionsOfUsers(ctx, []uuid.UUID{alice, bob, uuid.New()})
+	if err != nil {
+		t.Fatalf("SubscriptionsOfUsers() error = %v", err)
+	}
+	var ofAlice []uuid.UUID
+	for _, sub := range subs {
+		if sub.UserID == carol {
+			t.Errorf("subscription %s of another user", sub.ID)
+		}
+		if sub.UserID == alice {
+			ofAlice = append(ofAlice, sub.ID)
+		}
+	}
+	if len(subs) != 3 || len(ofAlice) != 2 || !slices.Contains(ofAlice, first.ID) || !slices.Contains(ofAlice, second.ID) {
+		t.Errorf("SubscriptionsOfUsers() = %d subscriptions, of alice %v, want 3 and %s, %s", len(subs), ofAlice, first.ID, second.ID)
+	}
+
+	if subs, err := repo.SubscriptionsOfUsers(ctx, nil); err != nil || len(subs) != 0 {
+		t.Errorf("SubscriptionsOfUsers(nil) = %v, %v, want none", subs, err)
+	}
+}
+
+func testPagination(t *testing.T, repo service.SubscriptionRepositoryI) {
+	userID := uuid.New()
+	for i := range 5 {
+		mustCreate(t, repo, newSubscription(userID, "Netflix", month(2025, time.Month(i+1)), nil))
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for offset, want := range map[int]int{0: 2, 2: 2, 4: 1, 6: 0} {
+		subs, total, err := repo.Subscriptions(context.Background(), domain.SubscriptionFilter{UserID: &userID, Limit: 2, Offset: offset})
+		if err != nil {
+			t.Fatalf("Subscriptions() error = %v", err)
+		}
+		if total != 5 {
+			t.Errorf("offset %d: total = %d, want 5", offset, total)
+		}
+		if len(subs) != want {
+			t.Errorf("offset %d: got %d subscriptions, want %d", offset, len(subs), want)
+		}
+		for _, sub := range subs {
+			if seen[sub.ID] {
+				t.Errorf("subscription %s returned on more than one page", sub.ID)
+			}
+			seen[sub.ID] = true
+		}
+	}
+	if len(seen) != 5 {
+		t.Errorf("pages covered %d subscriptions, want 5", len(seen))
+	}
+}
+
+func testUpdate(t *testing.T, repo service.SubscriptionRepositoryI) {
+	ctx := context.Background()
+	sub := mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", month(2025, time.January), nil))
+
+	unchanged, err := repo.UpdateSubscription(ctx, sub.ID, domain.UpdateSubscription{})
+	if err != nil {
+		t.Fatalf("empty UpdateSubscription() error = %v", err)
+	}
+	if unchanged.Price != sub.Price || unchanged.EndDate != nil {
+		t.Errorf("empty UpdateSubscription() = %+v, want %+v", unchanged, sub)
+	}
+
+	price := 999
+	end := month(2025, time.June)
+	updated, err := repo.UpdateSubscription(ctx, sub.ID, domain.UpdateSubscription{Price: &price, EndDate: &end})
+	if err != nil {
+		t.Fatalf("UpdateSubscription() error = %v", err)
+	}
+	if updated.Price != price || updated.ServiceName != sub.ServiceName {
+		t.Errorf("UpdateSubscription() = %+v", updated)
+	}
+	if updated.EndDate == nil || !updated.EndDate.Equal(end) {
+		t.Errorf("EndDate = %v, want %v", updated.EndDate, end)
+	}
+	if updated.UpdatedAt.Before(sub.UpdatedAt) {
+		t.Errorf("UpdatedAt went back from %v to %v", sub.UpdatedAt, updated.UpdatedAt)
+	}
+
+	got, err := repo.SubscriptionByID(ctx, sub.ID)
+	if err != nil {
+		t.Fatalf("SubscriptionByID() error = %v", err)
+	}
+	if got.Price != price {
+		t.Errorf("stored price = %d, want %d", got.Price, price)
+	}
+}
+
+func testUpdateAllFields(t *testing.T, repo service.SubscriptionRepositoryI) {
+	sub := mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", month(2025, time.January), nil))
+
+	name, price, period := "Spotify", 599, 12
+	start, end := month(2025, time.March), month(2025, time.September)
+	updated, err := repo.UpdateSubscription(context.Background(), sub.ID, domain.UpdateSubscription{
+		ServiceName:   &name,
+		Price:         &price,
+		StartDate:     &start,
+		EndDate:       &end,
+		BillingPeriod: &period,
+	})
+	if err != nil {
+		t.Fatalf("UpdateSubscription() error = %v", err)
+	}
+	if updated.ServiceName != name || updated.Price != price || !updated.StartDate.Equal(start) || updated.EndDate == nil || !updated.EndDate.Equal(end) || updated.BillingPeriod != period {
+		t.Errorf("UpdateSubscription() = %+v", updated)
+	}
+	if updated.UserID != sub.UserID {
+		t.Errorf("UserID changed from %s to %s", sub.UserID, updated.UserID)
+	}
+}
+
+func testConstraints(t *testing.T, repo service.SubscriptionRepositoryI) {
+	ctx := context.Background()
+
+	yearly := newSubscription(uuid.New(), "Netflix", month(2025, time.January), nil)
+	yearly.BillingPeriod = 0
+	if _, err := repo.CreateSubscription(ctx, yearly); err == nil {
+		t.Error("CreateSubscription() with a zero billing period succeeded")
+	}
+
+	end := month(2024, time.December)
+	if _, err := repo.CreateSubscription(ctx, newSubscription(uuid.New(), "Netflix", month(2025, time.January), &end)); err == nil {
+		t.Error("CreateSubscription() with end before start succeeded")
+	}
+
+	sub := newSubscription(uuid.New(), "Netflix", month(2025, time.January), nil)
+	sub.Price = 0
+	if _, err := repo.CreateSubscription(ctx, sub); err == nil {
+		t.Error("CreateSubscription() with zero price succeeded")
+	}
+
+	created := mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", month(2025, time.January), nil))
+	if _, err := repo.UpdateSubscription(ctx, created.ID, domain.UpdateSubscription{EndDate: &end}); err == nil || errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("UpdateSubscription() with end before start error = %v", err)
+	}
+}
+
+func testDelete(t *testing.T, repo service.SubscriptionRepositoryI) {
+	ctx := context.Background()
+	sub := mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", month(2025, time.January), nil))
+
+	if err := repo.DeleteSubscription(ctx, sub.ID); err != nil {
+		t.Fatalf("DeleteSubscription() error = %v", err)
+	}
+	if _, err := repo.SubscriptionByID(ctx, sub.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("SubscriptionByID() after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func testDeleteWithFilter(t *testing.T, repo service.SubscriptionRepositoryI) {
+	ctx := context.Background()
+	alice, bob := uuid.New(), uuid.New()
+	mustCreate(t, repo, newSubscription(alice, "Netflix", month(2025, time.January), nil))
+	mustCreate(t, repo, newSubscription(alice, "Spotify", month(2025, time.January), nil))
+	mustCreate(t, repo, newSubscription(bob, "Netflix", month(2025, time.January), nil))
+
+	deleted, err := repo.DeleteSubscriptions(ctx, domain.SubscriptionFilter{UserID: &alice})
+	if err != nil {
+		t.Fatalf("DeleteSubscriptions() error = %v", err)
+	}
+	if len(deleted) != 2 || deleted[0].UserID != alice || deleted[1].UserID != alice {
+		t.Errorf("DeleteSubscriptions() = %+v, want the 2 subscriptions of alice", deleted)
+	}
+
+	_, total, err := repo.Subscriptions(ctx, domain.SubscriptionFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("Subscriptions() error = %v", err)
+	}
+	if total != 1 {
+		t.Errorf("remaining subscriptions = %d, want 1", total)
+	}
+}
+
+func testCostSelection(t *testing.T, repo service.SubscriptionRepositoryI) {
+	alice := uuid.New()
+	netflix := "Netflix"
+	mustCreate(t, repo, newSubscription(alice, netflix, month(2025, time.January), nil))
+	mustCreate(t, repo, newSubscription(alice, "Spotify", month(2025, time.January), nil))
+	mustCreate(t, repo, newSubscription(uuid.New(), netflix, month(2025, time.January), nil))
+
+	subs, err := repo.SubscriptionsCost(context.Background(), domain.CostRequest{UserID: &alice, ServiceName: &netflix})
+	if err != nil {
+		t.Fatalf("SubscriptionsCost() error = %v", err)
+	}
+	if len(subs) != 1 || subs[0].UserID != alice || subs[0].ServiceName != netflix {
+		t.Errorf("SubscriptionsCost() = %+v", subs)
+	}
+
+	none := uuid.New()
+	subs, err = repo.SubscriptionsCost(context.Background(), domain.CostRequest{UserID: &none})
+	if err != nil {
+		t.Fatalf("SubscriptionsCost() without matches error = %v", err)
+	}
+	if len(subs) != 0 {
+		t.Errorf("SubscriptionsCost() without matches = %+v", subs)
+	}
+}
+
+func testLabels(t *testing.T, repo service.SubscriptionRepositoryI) {
+	ctx := context.Background()
+	streaming, work, family := "streaming", "work", "family"
+
+	netflix := newSubscription(uuid.New(), "Netflix", month(2025, time.January), nil)
+	netflix.Category, netflix.Tags = streaming, []string{family, work}
+	netflix = mustCreate(t, repo, netflix)
+	if netflix.Category != streaming || !slices.Equal(netflix.Tags, []string{family, work}) {
+		t.Errorf("CreateSubscription() = %+v, want category and tags", netflix)
+	}
+	spotify := newSubscription(uuid.New(), "Spotify", month(2025, time.January), nil)
+	spotify.Tags = []string{work}
+	mustCreate(t, repo, spotify)
+	plain := mustCreate(t, repo, newSubscription(uuid.New(), "iCloud", month(2025, time.January), nil))
+	if plain.Tags == nil || len(plain.Tags) != 0 {
+		t.Errorf("Tags without tags = %#v, want empty", plain.Tags)
+	}
+
+	filters := []struct {
+		name   string
+		filter domain.SubscriptionFilter
+		want   int
+	}{
+		{"category", domain.SubscriptionFilter{Category: &streaming}, 1},
+		{"tag", domain.SubscriptionFilter{Tags: []string{work}}, 2},
+		{"all tags", domain.SubscriptionFilter{Tags: []string{work, family}}, 1},
+		{"category and tag", domain.SubscriptionFilter{Category: &streaming, Tags: []string{work}}, 1},
+		{"unknown tag", domain.SubscriptionFilter{Tags: []string{"travel"}}, 0},
+	}
+	for _, tt := range filters {
+		tt.filter.Limit = 10
+		subs, total, err := repo.Subscriptions(ctx, tt.filter)
+		if err != nil {
+			t.Fatalf("Subscriptions(%s) error = %v", tt.name, err)
+		}
+		if total != tt.want || len(subs) != tt.want {
+			t.Errorf("Subscriptions(%s) returned %d of total %d, want %d", tt.name, len(subs), total, tt.want)
+		}
+	}
+
+	subs, err := repo.SubscriptionsCost(ctx, domain.CostRequest{Tags: []string{work}})
+	if err != nil {
+		t.Fatalf("SubscriptionsCost() error = %v", err)
+	}
+	if len(subs) != 2 || !slices.Contains(subs[0].Tags, work) || !slices.Contains(subs[1].Tags, work) {
+		t.Errorf("SubscriptionsCost() by tag = %+v", subs)
+	}
+
+	music := "music"
+	updated, err := repo.UpdateSubscription(ctx, netflix.ID, domain.UpdateSubscription{
+		Category:   &music,
+		AddTags:    []string{"travel", work},
+		RemoveTags: []string{family, "unknown"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSubscription() error = %v", err)
+	}
+	if updated.Category != music || !slices.Equal(updated.Tags, []string{"travel", work}) {
+		t.Errorf("UpdateSubscription() = %+v, want category music and tags travel, work", updated)
+	}
+
+	updated, err = repo.UpdateSubscription(ctx, netflix.ID, domain.UpdateSubscription{RemoveTags: []string{"travel"}})
+	if err != nil {
+		t.Fatalf("UpdateSubscription() of tags only error = %v", err)
+	}
+	if !slices.Equal(updated.Tags, []string{work}) {
+		t.Errorf("Tags = %v, want [work]", updated.Tags)
+	}
+	if _, err := repo.UpdateSubscription(ctx, uuid.New(), domain.UpdateSubscription{AddTags: []string{work}}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("UpdateSubscription() of a missing subscription error = %v, want ErrNotFound", err)
+	}
+
+	if _, err := repo.CreatePriceChange(ctx, domain.PriceChange{ID: uuid.New(), SubscriptionID: spotify.ID, Price: 500, EffectiveFrom: month(2025, time.June)}); err != nil {
+		t.Fatalf("CreatePriceChange() error = %v", err)
+	}
+	changes, err := repo.ScheduledPriceChanges(ctx, domain.CostRequest{Category: &music})
+	if err != nil {
+		t.Fatalf("ScheduledPriceChanges() error = %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("ScheduledPriceChanges() by category = %+v, want none", changes)
+	}
+
+	deleted, err := repo.DeleteSubscriptions(ctx, domain.SubscriptionFilter{Tags: []string{work}})
+	if err != nil {
+		t.Fatalf("DeleteSubscriptions() error = %v", err)
+	}
+	if len(deleted) != 2 || !slices.Equal(deleted[0].Tags, []string{work}) {
+		t.Errorf("DeleteSubscriptions() by tag = %+v", deleted)
+	}
+	got, err := repo.SubscriptionByID(ctx, plain.ID)
+	if err != nil || len(got.Tags) != 0 {
+		t.Errorf("SubscriptionByID() of the remaining subscription = %+v, %v", got, err)
+	}
+}
+
+func testUpcoming(t *testing.T, repo service.SubscriptionRepositoryI) {
+	alice := uuid.New()
+	ended := month(2025, time.October)
+	ending := time.Date(2025, time.November, 20, 0, 0, 0, 0, time.UTC)
+	open := mustCreate(t, repo, newSubscription(alice, "Netflix", month(2024, time.March), nil))
+	mustCreate(t, repo, newSubscription(alice, "Netflix", month(2025, time.January), &ended))
+	last := mustCreate(t, repo, newSubscription(alice, "Netflix", month(2025, time.February), &ending))
+	mustCreate(t, repo, newSubscription(alice, "Netflix", month(2026, time.March), nil))
+	mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", month(2025, time.January), nil))
+
+	subs, err := repo.UpcomingSubscriptions(context.Background(), domain.UpcomingFilter{
+		UserID: &alice,
+		From:   time.Date(2025, time.November, 5, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("UpcomingSubscriptions() error = %v", err)
+	}
+	if len(subs) != 2 || subs[0].ID != open.ID || subs[1].ID != last.ID {
+		t.Errorf("UpcomingSubscriptions() = %+v, want the open one and the one ending on the 20th", subs)
+	}
+}
+
+func testExpiry(t *testing.T, repo service.SubscriptionRepositoryI) {
+	ctx := context.Background()
+	ended := month(2025, time.October)
+	today := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+	later := month(2025, time.December)
+	expired := mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", month(2025, time.January), &ended))
+	endsToday := mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", month(2025, time.January), &today))
+	active := mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", month(2025, time.January), &later))
+	mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", month(2025, time.January), nil))
+
+	at := time.Date(2025, time.November, 1, 0, 5, 0, 0, time.UTC)
+	marked, err := repo.MarkExpiredSubscriptions(ctx, today, at)
+	if err != nil {
+		t.Fatalf("MarkExpiredSubscriptions() error = %v", err)
+	}
+	if len(marked) != 2 || marked[0].ExpiredAt == nil {
+		t.Errorf("MarkExpiredSubscriptions() = %+v, want 2 marked subscriptions", marked)
+	}
+	if marked, err := repo.MarkExpiredSubscriptions(ctx, today, at.Add(time.Hour)); err != nil || len(marked) != 0 {
+		t.Errorf("second MarkExpiredSubscriptions() = %+v, %v, want none", marked, err)
+	}
+
+	for _, id := range []uuid.UUID{expired.ID, endsToday.ID} {
+		got, err := repo.SubscriptionByID(ctx, id)
+		if err != nil {
+			t.Fatalf("SubscriptionByID() error = %v", err)
+		}
+		if got.ExpiredAt == nil || !got.ExpiredAt.Equal(at) {
+			t.Errorf("ExpiredAt = %v, want %v", got.ExpiredAt, at)
+		}
+	}
+	if got, _ := repo.SubscriptionByID(ctx, active.ID); got.ExpiredAt != nil {
+		t.Errorf("active subscription ExpiredAt = %v, want nil", got.ExpiredAt)
+	}
+
+	extended, err := repo.UpdateSubscription(ctx, expired.ID, domain.UpdateSubscription{EndDate: &later})
+	if err != nil {
+		t.Fatalf("UpdateSubscription() error = %v", err)
+	}
+	if extended.ExpiredAt != nil {
+		t.Errorf("ExpiredAt after a new end date = %v, want nil", extended.ExpiredAt)
+	}
+}
+
+func testStats(t *testing.T, repo service.SubscriptionRepositoryI) {
+	alice := uuid.New()
+	ended := month(2025, time.March)
+	mustCreate(t, repo, newSubscription(alice, "Netflix", month(2025, time.January), nil))
+	mustCreate(t, repo, newSubscription(alice, "Spotify", month(2025, time.January), &ended))
+	mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", month(2025, time.June), nil))
+
+	stats, err := repo.SubscriptionStats(context.Background(), month(2025, time.April))
+	if err != nil {
+		t.Fatalf("SubscriptionStats() error = %v", err)
+	}
+	if stats.Active != 1 || stats.DistinctUsers != 2 {
+		t.Errorf("SubscriptionStats() = %+v, want 1 active and 2 users", stats)
+	}
+}
+
+func testConcurrentCreations(t *testing.T, repo service.SubscriptionRepositoryI) {
+	userID := uuid.New()
+	errs := make(chan error, 20)
+	for range 20 {
+		go func() {
+			_, err := repo.CreateSubscription(context.Background(), newSubscription(userID, "Netflix", month(2025, time.January), nil))
+			errs <- err
+		}()
+	}
+	for range 20 {
+		if err := <-errs; err != nil {
+			t.Errorf("CreateSubscription() error = %v", err)
+		}
+	}
+
+	_, total, err := repo.Subscriptions(context.Background(), domain.SubscriptionFilter{UserID: &userID, Limit: 1})
+	if err != nil {
+		t.Fatalf("Subscriptions() error = %v", err)
+	}
+	if total != 20 {
+		t.Errorf("total = %d, want 20", total)
+	}
+}
+
+func testPriceChanges(t *testing.T, repo service.SubscriptionRepositoryI) {
+	ctx := context.Background()
+	userID := uuid.New()
+	sub := mustCreate(t, repo, newSubscription(userID, "Netflix", month(2025, time.January), nil))
+	other := mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", month(2025, time.January), nil))
+
+	create := func(subID uuid.UUID, price int, from time.Time) domain.PriceChange {
+		t.Helper()
+		change, err := repo.CreatePriceChange(ctx, domain.PriceChange{ID: uuid.New(), SubscriptionID: subID, Price: price, EffectiveFrom: from})
+		if err != nil {
+			t.Fatalf("CreatePriceChange() error = %v", err)
+		}
+		return change
+	}
+	later := create(sub.ID, 600, month(2026, time.March))
+	earlier := create(sub.ID, 500, month(2026, time.January))
+	create(other.ID, 700, month(2026, time.January))
+
+	if _, err := repo.CreatePriceChange(ctx, domain.PriceChange{ID: uuid.New(), SubscriptionID: uuid.New(), Price: 1, EffectiveFrom: month(2026, time.January)}); err == nil {
+		t.Error("CreatePriceChange() for a missing subscription succeeded")
+	}
+	if _, err := repo.CreatePriceChange(ctx, domain.PriceChange{ID: uuid.New(), SubscriptionID: sub.ID, Price: 0, EffectiveFrom: month(2026, time.January)}); err == nil {
+		t.Error("CreatePriceChange() with a zero price succeeded")
+	}
+
+	changes, err := repo.PriceChanges(ctx, sub.ID)
+	if err != nil {
+		t.Fatalf("PriceChanges() error = %v", err)
+	}
+	if len(changes) != 2 || changes[0].ID != earlier.ID || changes[1].ID != later.ID || !changes[0].EffectiveFrom.Equal(month(2026, time.January)) {
+		t.Errorf("PriceChanges() = %+v, want earlier then later", changes)
+	}
+
+	scheduled, err := repo.ScheduledPriceChanges(ctx, domain.CostRequest{UserID: &userID})
+	if err != nil || len(scheduled) != 2 {
+		t.Errorf("ScheduledPriceChanges(user) = %d changes, %v, want 2", len(scheduled), err)
+	}
+
+	if err := repo.DeletePriceChange(ctx, other.ID, earlier.ID); !errors.Is(err, domain.ErrPriceChangeNotFound) {
+		t.Errorf("DeletePriceChange() of another subscription error = %v, want ErrPriceChangeNotFound", err)
+	}
+	if err := repo.DeletePriceChange(ctx, sub.ID, earlier.ID); err != nil {
+		t.Fatalf("DeletePriceChange() error = %v", err)
+	}
+
+	if err := repo.DeleteSubscription(ctx, sub.ID); err != nil {
+		t.Fatalf("DeleteSubscription() error = %v", err)
+	}
+	if changes, err := repo.PriceChanges(ctx, sub.ID); err != nil || len(changes) != 0 {
+		t.Errorf("PriceChanges() after delete = %+v, %v, want none", changes, err)
+	}
+}
@@ -0,0 +1,395 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	errDuplicateID   = errors.New("duplicate id")
+	errInvalidPrice  = errors.New("price must be positive")
+	errEndBeforeFrom = errors.New("end date is before start date")
+	errInvalidPeriod = errors.New("billing period must be positive")
+	errNoParent      = errors.New("subscription does not exist")
+)
+
+// MemorySubscriptionRepository keeps subscriptions in process memory. It enforces the
+// same constraints and ordering as the SQL schema and is safe for concurrent use.
+type MemorySubscriptionRepository struct {
+	mu   sync.RWMutex
+	subs map[uuid.UUID]domain.Subscription
+	// changes holds the price changes of each subscription in PriceChanges order.
+	changes map[uuid.UUID][]domain.PriceChange
+}
+
+func NewMemorySubscriptionRepository() *MemorySubscriptionRepository {
+	return &MemorySubscriptionRepository{
+		subs:    make(map[uuid.UUID]domain.Subscription),
+		changes: make(map[uuid.UUID][]domain.PriceChange),
+	}
+}
+
+func (r *MemorySubscriptionRepository) CreateSubscription(ctx context.Context, sub domain.Subscription) (domain.Subscription, error) {
+	if err := checkSubscription(sub); err != nil {
+		return domain.Subscription{}, fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subs[sub.ID]; ok {
+		return domain.Subscription{}, fmt.Errorf("failed to create subscription: %w", errDuplicateID)
+	}
+
+	now := time.Now().UTC()
+	sub.CreatedAt = now
+	sub.UpdatedAt = now
+	sub.Tags = addTags(nil, sub.Tags)
+	sub = cloneSubscription(sub)
+	r.subs[sub.ID] = sub
+
+	return cloneSubscription(sub), nil
+}
+
+func (r *MemorySubscriptionRepository) SubscriptionByID(ctx context.Context, id uuid.UUID) (domain.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sub, ok := r.subs[id]
+	if !ok {
+		return domain.Subscription{}, domain.ErrNotFound
+	}
+	return cloneSubscription(sub), nil
+}
+
+func (r *MemorySubscriptionRepository) Subscriptions(ctx context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := r.match(filter.UserID, filter.ServiceID, filter.ServiceName, filter.Category, filter.Tags)
+	total := len(matched)
+	if total == 0 {
+		return []domain.Subscription{}, 0, nil
+	}
+
+	slices.SortFunc(matched, func(a, b domain.Subscription) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID.String(), b.ID.String())
+	})
+
+	start := min(max(filter.Offset, 0), total)
+	end := min(start+max(filter.Limit, 0), total)
+
+	return matched[start:end], total, nil
+}
+
+func (r *MemorySubscriptionRepository) SubscriptionsOfUsers(ctx context.Context, userIDs []uuid.UUID) ([]domain.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subs := []domain.Subscription{}
+	for _, sub := range r.subs {
+		if slices.Contains(userIDs, sub.UserID) {
+			subs = append(subs, cloneSubscription(sub))
+		}
+	}
+	slices.SortFunc(subs, func(a, b domain.Subscription) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID.String(), b.ID.String()))
+	})
+	return subs, nil
+}
+
+func (r *MemorySubscriptionRepository) SubscriptionsCost(ctx context.Context, filter domain.CostRequest) ([]domain.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.match(filter.UserID, filter.ServiceID, filter.ServiceName, filter.Category, filter.Tags), nil
+}
+
+func (r *MemorySubscriptionRepository) UpcomingSubscriptions(ctx context.Context, filter domain.UpcomingFilter) ([]domain.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subs := []domain.Subscription{}
+	for _, sub := range r.match(filter.UserID, filter.ServiceID, filter.ServiceName, nil, nil) {
+		if sub.StartDate.Before(filter.To) && (sub.EndDate == nil || !sub.EndDate.Before(filter.From)) {
+			subs = append(subs, sub)
+		}
+	}
+	slices.SortFunc(subs, func(a, b domain.Subscription) int {
+		return cmp.Or(a.StartDate.Compare(b.StartDate), cmp.Compare(a.ID.String(), b.ID.String()))
+	})
+	return subs, nil
+}
+
+func (r *MemorySubscriptionRepository) SubscriptionStats(ctx context.Context, at time.Time) (domain.SubscriptionStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var stats domain.SubscriptionStats
+	users := make(map[uuid.UUID]struct{})
+	for _, sub := range r.subs {
+		users[sub.UserID] = struct{}{}
+		if !sub.StartDate.After(at) && (sub.EndDate == nil || sub.EndDate.After(at)) {
+			stats.Active++
+		}
+	}
+	stats.DistinctUsers = len(users)
+
+	return stats, nil
+}
+
+func (r *MemorySubscriptionRepository) UpdateSubscription(ctx context.Context, id uuid.UUID, upd domain.UpdateSubscription) (domain.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sub, ok := r.subs[id]
+	if !ok {
+		return domain.Subscription{}, domain.ErrNotFound
+	}
+
+	if upd.ServiceName == nil && upd.Category == nil && upd.Price == nil && upd.StartDate == nil && upd.EndDate == nil &&
+		upd.BillingPeriod == nil && len(upd.AddTags) == 0 && len(upd.RemoveTags) == 0 {
+		return cloneSubscription(sub), nil
+	}
+
+	if upd.ServiceName != nil {
+		sub.ServiceName = *upd.ServiceName
+		sub.ServiceID = upd.ServiceID
+	}
+	if upd.Category != nil {
+		sub.Category = *upd.Category
+	}
+	if upd.Price != nil {
+		sub.Price = *upd.Price
+	}
+	if upd.StartDate != nil {
+		sub.StartDate = *upd.StartDate
+	}
+	if upd.EndDate != nil {
+		endDate := *upd.EndDate
+		sub.EndDate = &endDate
+		sub.ExpiredAt = nil
+	}
+	if upd.BillingPeriod != nil {
+		sub.BillingPeriod = *upd.BillingPeriod
+	}
+	sub.Tags = slices.DeleteFunc(addTags(sub.Tags, upd.AddTags), func(tag string) bool {
+		return slices.Contains(upd.RemoveTags, tag)
+	})
+	if err := checkSubscription(sub); err != nil {
+		return domain.Subscription{}, fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	sub.UpdatedAt = time.Now().UTC()
+	r.subs[id] = sub
+
+	return cloneSubscription(sub), nil
+}
+
+// unlinkService clears the service of the subscriptions of a deleted catalog entry, as
+// the foreign key of the SQL schema does.
+func (r *MemorySubscriptionRepository) unlinkService(serviceID uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, sub := range r.subs {
+		if sub.ServiceID != nil && *sub.ServiceID == serviceID {
+			sub.ServiceID = nil
+			r.subs[id] = sub
+		}
+	}
+}
+
+// renameService sets the service name of the subscriptions of a renamed catalog entry.
+func (r *MemorySubscriptionRepository) renameService(serviceID uuid.UUID, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, sub := range r.subs {
+		if sub.ServiceID != nil && *sub.ServiceID == serviceID {
+			sub.ServiceName = name
+			r.subs[id] = sub
+		}
+	}
+}
+
+func (r *MemorySubscriptionRepository) MarkExpiredSubscriptions(ctx context.Context, today, at time.Time) ([]domain.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	marked := []domain.Subscription{}
+	for id, sub := range r.subs {
+		if sub.ExpiredAt != nil || sub.EndDate == nil || sub.EndDate.After(today) {
+			continue
+		}
+		expiredAt := at
+		sub.ExpiredAt = &expiredAt
+		r.subs[id] = sub
+		marked = append(marked, cloneSubscription(sub))
+	}
+	return marked, nil
+}
+
+func (r *MemorySubscriptionRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subs[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.subs, id)
+	delete(r.changes, id)
+
+	return nil
+}
+
+func (r *MemorySubscriptionRepository) DeleteSubscriptions(ctx context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := r.match(filter.UserID, filter.ServiceID, filter.ServiceName, filter.Category, filter.Tags)
+	if deleted == nil {
+		deleted = []domain.Subscription{}
+	}
+	for _, sub := range deleted {
+		delete(r.subs, sub.ID)
+		delete(r.changes, sub.ID)
+	}
+
+	return deleted, nil
+}
+
+func (r *MemorySubscriptionRepository) CreatePriceChange(ctx context.Context, change domain.PriceChange) (domain.PriceChange, error) {
+	if change.Price <= 0 {
+		return domain.PriceChange{}, fmt.Errorf("failed to create price change: %w", errInvalidPrice)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subs[change.SubscriptionID]; !ok {
+		return domain.PriceChange{}, fmt.Errorf("failed to create price change: %w", errNoParent)
+	}
+
+	change.CreatedAt = time.Now().UTC()
+	changes := append(r.changes[change.SubscriptionID], change)
+	slices.SortStableFunc(changes, func(a, b domain.PriceChange) int {
+		return a.EffectiveFrom.Compare(b.EffectiveFrom)
+	})
+	r.changes[change.SubscriptionID] = changes
+
+	return change, nil
+}
+
+func (r *MemorySubscriptionRepository) PriceChanges(ctx context.Context, subscriptionID uuid.UUID) ([]domain.PriceChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]domain.PriceChange{}, r.changes[subscriptionID]...), nil
+}
+
+func (r *MemorySubscriptionRepository) ScheduledPriceChanges(ctx context.Context, filter domain.CostRequest) ([]domain.PriceChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	changes := []domain.PriceChange{}
+	for _, sub := range r.match(filter.UserID, filter.ServiceID, filter.ServiceName, filter.Category, filter.Tags) {
+		changes = append(changes, r.changes[sub.ID]...)
+	}
+	return changes, nil
+}
+
+func (r *MemorySubscriptionRepository) DeletePriceChange(ctx context.Context, subscriptionID, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changes := r.changes[subscriptionID]
+	i := slices.IndexFunc(changes, func(c domain.PriceChange) bool { return c.ID == id })
+	if i < 0 {
+		return domain.ErrPriceChangeNotFound
+	}
+	r.changes[subscriptionID] = slices.Delete(changes, i, i+1)
+
+	return nil
+}
+
+// match returns copies of the subscriptions passing the filter. The caller holds the lock.
+func (r *MemorySubscriptionRepository) match(userID, serviceID *uuid.UUID, serviceName, category *string, tags []string) []domain.Subscription {
+	var subs []domain.Subscription
+	for _, sub := range r.subs {
+		if userID != nil && sub.UserID != *userID {
+			continue
+		}
+		if serviceID != nil && (sub.ServiceID == nil || *sub.ServiceID != *serviceID) {
+			continue
+		}
+		if serviceID == nil && serviceName != nil && sub.ServiceName != *serviceName {
+			continue
+		}
+		if category != nil && sub.Category != *category {
+			continue
+		}
+		if slices.ContainsFunc(tags, func(tag string) bool { return !slices.Contains(sub.Tags, tag) }) {
+			continue
+		}
+		subs = append(subs, cloneSubscription(sub))
+	}
+	return subs
+}
+
+func checkSubscription(sub domain.Subscription) error {
+	if sub.Price <= 0 {
+		return errInvalidPrice
+	}
+	if sub.EndDate != nil && sub.EndDate.Before(sub.StartDate) {
+		return errEndBeforeFrom
+	}
+	if sub.BillingPeriod <= 0 {
+		return errInvalidPeriod
+	}
+	return nil
+}
+
+// addTags returns a copy of tags with the new ones added, in the order the SQL
+// repository returns them.
+func addTags(tags, add []string) []string {
+	tags = slices.Clone(tags)
+	for _, tag := range add {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+func cloneSubscription(sub domain.Subscription) domain.Subscription {
+	sub.Tags = slices.Clone(sub.Tags)
+	if sub.Tags == nil {
+		sub.Tags = []string{}
+	}
+	if sub.ServiceID != nil {
+		serviceID := *sub.ServiceID
+		sub.ServiceID = &serviceID
+	}
+	if sub.EndDate != nil {
+		endDate := *sub.EndDate
+		sub.EndDate = &endDate
+	}
+	if sub.ExpiredAt != nil {
+		expiredAt := *sub.ExpiredAt
+		sub.ExpiredAt = &expiredAt
+	}
+	return sub
+}
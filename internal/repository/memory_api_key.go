@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// MemoryAPIKeyRepository keeps API keys in process memory, so they are lost on restart.
+type MemoryAPIKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[uuid.UUID]domain.APIKey
+}
+
+func NewMemoryAPIKeyRepository() *MemoryAPIKeyRepository {
+	return &MemoryAPIKeyRepository{keys: make(map[uuid.UUID]domain.APIKey)}
+}
+
+func (r *MemoryAPIKeyRepository) CreateAPIKey(ctx context.Context, key domain.APIKey) (domain.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.keys {
+		if existing.ID == key.ID || existing.KeyHash == key.KeyHash {
+			return domain.APIKey{}, fmt.Errorf("failed to create api key: %w", errDuplicateID)
+		}
+	}
+
+	key.Scopes = slices.Clone(key.Scopes)
+	key.Roles = slices.Clone(key.Roles)
+	key.CreatedAt = time.Now().UTC()
+	r.keys[key.ID] = key
+
+	return key, nil
+}
+
+func (r *MemoryAPIKeyRepository) APIKeyByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.KeyHash == hash {
+			return key, nil
+		}
+	}
+	return domain.APIKey{}, domain.ErrAPIKeyNotFound
+}
+
+func (r *MemoryAPIKeyRepository) APIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]domain.APIKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b domain.APIKey) int {
+		return cmp.Compare(b.CreatedAt.UnixNano(), a.CreatedAt.UnixNano())
+	})
+	return keys, nil
+}
+
+func (r *MemoryAPIKeyRepository) TouchAPIKey(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if key, ok := r.keys[id]; ok {
+		key.LastUsedAt = &usedAt
+		r.keys[id] = key
+	}
+	return nil
+}
+
+func (r *MemoryAPIKeyRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok || key.RevokedAt != nil {
+		return domain.ErrAPIKeyNotFound
+	}
+	key.RevokedAt = &revokedAt
+	r.keys[id] = key
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	errInvalidAmount = errors.New("amount must be positive")
+	errUnknownPeriod = errors.New("unknown budget period")
+	errNoBudget      = errors.New("budget does not exist")
+)
+
+type alertKey struct {
+	budgetID    uuid.UUID
+	periodStart time.Time
+	threshold   int
+	kind        domain.AlertKind
+}
+
+// MemoryBudgetRepository keeps budgets and alerts in process memory. It enforces the
+// same constraints as the SQL schema and is safe for concurrent use.
+type MemoryBudgetRepository struct {
+	mu      sync.RWMutex
+	budgets map[uuid.UUID]domain.Budget
+	alerts  map[alertKey]domain.BudgetAlert
+}
+
+func NewMemoryBudgetRepository() *MemoryBudgetRepository {
+	return &MemoryBudgetRepository{
+		budgets: make(map[uuid.UUID]domain.Budget),
+		alerts:  make(map[alertKey]domain.BudgetAlert),
+	}
+}
+
+func (r *MemoryBudgetRepository) CreateBudget(ctx context.Context, b domain.Budget) (domain.Budget, error) {
+	if err := checkBudget(b); err != nil {
+		return domain.Budget{}, fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.budgets[b.ID]; ok {
+		return domain.Budget{}, fmt.Errorf("failed to create budget: %w", errDuplicateID)
+	}
+
+	now := time.Now().UTC()
+	b.CreatedAt, b.UpdatedAt = now, now
+	b.ServiceNames = slices.Clone(b.ServiceNames)
+	if b.ServiceNames == nil {
+		b.ServiceNames = []string{}
+	}
+	r.budgets[b.ID] = b
+	return cloneBudget(b), nil
+}
+
+func (r *MemoryBudgetRepository) Budget(ctx context.Context, id uuid.UUID) (domain.Budget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	b, ok := r.budgets[id]
+	if !ok {
+		return domain.Budget{}, domain.ErrBudgetNotFound
+	}
+	return cloneBudget(b), nil
+}
+
+func (r *MemoryBudgetRepository) Budgets(ctx context.Context, userID *uuid.UUID) ([]domain.Budget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	budgets := []domain.Budget{}
+	for _, b := range r.budgets {
+		if userID == nil || b.UserID == *userID {
+			budgets = append(budgets, cloneBudget(b))
+		}
+	}
+	slices.SortFunc(budgets, func(a, b domain.Budget) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID.String(), b.ID.String()))
+	})
+	return budgets, nil
+}
+
+func (r *MemoryBudgetRepository) UpdateBudget(ctx context.Context, id uuid.UUID, upd domain.UpdateBudget) (domain.Budget, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.budgets[id]
+	if !ok {
+		return domain.Budget{}, domain.ErrBudgetNotFound
+	}
+	if upd.Period == nil && upd.Amount == nil && upd.ServiceNames == nil {
+		return cloneBudget(b), nil
+	}
+
+	if upd.Period != nil {
+		b.Period = *upd.Period
+	}
+	if upd.Amount != nil {
+		b.Amount = *upd.Amount
+	}
+	if upd.ServiceNames != nil {
+		b.ServiceNames = slices.Clone(*upd.ServiceNames)
+		if b.ServiceNames == nil {
+			b.ServiceNames = []string{}
+		}
+	}
+	if err := checkBudget(b); err != nil {
+		return domain.Budget{}, fmt.Errorf("failed to update budget: %w", err)
+	}
+	b.UpdatedAt = time.Now().UTC()
+
+	r.budgets[id] = b
+	return cloneBudget(b), nil
+}
+
+func (r *MemoryBudgetRepository) DeleteBudget(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.budgets[id]; !ok {
+		return domain.ErrBudgetNotFound
+	}
+	delete(r.budgets, id)
+	for key := range r.alerts {
+		if key.budgetID == id {
+			delete(r.alerts, key)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryBudgetRepository) CreateBudgetAlert(ctx context.Context, alert domain.BudgetAlert) (domain.BudgetAlert, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.budgets[alert.BudgetID]; !ok {
+		return domain.BudgetAlert{}, false, fmt.Errorf("failed to create budget alert: %w", errNoBudget)
+	}
+
+	key := alertKey{budgetID: alert.BudgetID, periodStart: alert.PeriodStart, threshold: alert.Threshold, kind: alert.Kind}
+	if _, ok := r.alerts[key]; ok {
+		return domain.BudgetAlert{}, false, nil
+	}
+
+	alert.CreatedAt = time.Now().UTC()
+	r.alerts[key] = alert
+	return alert, true, nil
+}
+
+func (r *MemoryBudgetRepository) BudgetAlerts(ctx context.Context, budgetID uuid.UUID) ([]domain.BudgetAlert, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	alerts := []domain.BudgetAlert{}
+	for key, alert := range r.alerts {
+		if key.budgetID == budgetID {
+			alerts = append(alerts, alert)
+		}
+	}
+	slices.SortFunc(alerts, func(a, b domain.BudgetAlert) int {
+		return cmp.Or(
+			b.PeriodStart.Compare(a.PeriodStart),
+			cmp.Compare(b.Threshold, a.Threshold),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.ID.String(), b.ID.String()),
+		)
+	})
+	return alerts, nil
+}
+
+func checkBudget(b domain.Budget) error {
+	if b.Amount <= 0 {
+		return errInvalidAmount
+	}
+	if b.Period != domain.BudgetPeriodMonth && b.Period != domain.BudgetPeriodYear {
+		return errUnknownPeriod
+	}
+	return nil
+}
+
+func cloneBudget(b domain.Budget) domain.Budget {
+	b.ServiceNames = slices.Clone(b.ServiceNames)
+	return b
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	errDuplicateName  = errors.New("service name is taken")
+	errDuplicateAlias = errors.New("service alias is taken")
+	errNegativePrice  = errors.New("default price must not be negative")
+)
+
+// MemoryCatalogRepository keeps the services catalog in process memory. It enforces the
+// same constraints and ordering as the SQL schema and is safe for concurrent use.
+type MemoryCatalogRepository struct {
+	mu       sync.RWMutex
+	services map[uuid.UUID]domain.Service
+	// subs, when set, has the links of deleted services cleared like the foreign key does
+	// and the names of renamed ones updated like the SQL repository does.
+	subs *MemorySubscriptionRepository
+}
+
+func NewMemoryCatalogRepository(subs *MemorySubscriptionRepository) *MemoryCatalogRepository {
+	return &MemoryCatalogRepository{
+		services: make(map[uuid.UUID]domain.Service),
+		subs:     subs,
+	}
+}
+
+func (r *MemoryCatalogRepository) CreateService(ctx context.Context, svc domain.Service) (domain.Service, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.services[svc.ID]; ok {
+		return domain.Service{}, fmt.Errorf("failed to create service: %w", errDuplicateID)
+	}
+	if err := r.check(svc); err != nil {
+		return domain.Service{}, fmt.Errorf("failed to create service: %w", err)
+	}
+
+	now := time.Now().UTC()
+	svc.CreatedAt, svc.UpdatedAt = now, now
+	svc = cloneService(svc)
+	r.services[svc.ID] = svc
+	return cloneService(svc), nil
+}
+
+func (r *MemoryCatalogRepository) Service(ctx context.Context, id uuid.UUID) (domain.Service, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	svc, ok := r.services[id]
+	if !ok {
+		return domain.Service{}, domain.ErrServiceNotFound
+	}
+	return cloneService(svc), nil
+}
+
+func (r *MemoryCatalogRepository) ResolveService(ctx context.Context, name string) (domain.Service, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key := domain.NormalizeServiceName(name)
+	for _, svc := range r.services {
+		if domain.NormalizeServiceName(svc.Name) == key {
+			return cloneService(svc), nil
+		}
+	}
+	for _, svc := range r.services {
+		for _, alias := range svc.Aliases {
+			if domain.NormalizeServiceName(alias) == key {
+				return cloneService(svc), nil
+			}
+		}
+	}
+	return domain.Service{}, domain.ErrServiceNotFound
+}
+
+func (r *MemoryCatalogRepository) Services(ctx context.Context, filter domain.ServiceFilter) ([]domain.Service, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	services := []domain.Service{}
+	for _, svc := range r.services {
+		if filter.Category == nil || svc.Category == *filter.Category {
+			services = append(services, cloneService(svc))
+		}
+	}
+	slices.SortFunc(services, func(a, b domain.Service) int {
+		return cmp.Or(
+			cmp.Compare(domain.NormalizeServiceName(a.Name), domain.NormalizeServiceName(b.Name)),
+			cmp.Compare(a.ID.String(), b.ID.String()),
+		)
+	})
+	return services, nil
+}
+
+func (r *MemoryCatalogRepository) UpdateService(ctx context.Context, id uuid.UUID, upd domain.UpdateService) (domain.Service, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	svc, ok := r.services[id]
+	if !ok {
+		return domain.Service{}, domain.ErrServiceNotFound
+	}
+	if upd.Name == nil && upd.Aliases == nil && upd.Category == nil && upd.URL == nil && upd.DefaultPrice == nil {
+		return cloneService(svc), nil
+	}
+
+	if upd.Name != nil {
+		svc.Name = *upd.Name
+	}
+	if upd.Aliases != nil {
+		svc.Aliases = *upd.Aliases
+	}
+	if upd.Category != nil {
+		svc.Category = *upd.Category
+	}
+	if upd.URL != nil {
+		svc.URL = *upd.URL
+	}
+	if upd.DefaultPrice != nil {
+		svc.DefaultPrice = *upd.DefaultPrice
+	}
+	if err := r.check(svc); err != nil {
+		return domain.Service{}, fmt.Errorf("failed to update service: %w", err)
+	}
+	svc.UpdatedAt = time.Now().UTC()
+
+	svc = cloneService(svc)
+	r.services[id] = svc
+	if upd.Name != nil && r.subs != nil {
+		r.subs.renameService(id, svc.Name)
+	}
+	return cloneService(svc), nil
+}
+
+func (r *MemoryCatalogRepository) DeleteService(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.services[id]; !ok {
+		return domain.ErrServiceNotFound
+	}
+	delete(r.services, id)
+	if r.subs != nil {
+		r.subs.unlinkService(id)
+	}
+	return nil
+}
+
+// check enforces the unique keys of the SQL schema: names are unique among names and
+// aliases among aliases.
+func (r *MemoryCatalogRepository) check(svc domain.Service) error {
+	if svc.DefaultPrice < 0 {
+		return errNegativePrice
+	}
+
+	aliases := make(map[string]bool)
+	for _, alias := range svc.Aliases {
+		key := domain.NormalizeServiceName(alias)
+		if aliases[key] {
+			return errDuplicateAlias
+		}
+		aliases[key] = true
+	}
+
+	name := domain.NormalizeServiceName(svc.Name)
+	for id, other := range r.services {
+		if id == svc.ID {
+			continue
+		}
+		if domain.NormalizeServiceName(other.Name) == name {
+			return errDuplicateName
+		}
+		for _, alias := range other.Aliases {
+			if aliases[domain.NormalizeServiceName(alias)] {
+				return errDuplicateAlias
+			}
+		}
+	}
+	return nil
+}
+
+// cloneService copies the aliases in the order the SQL repository returns them.
+func cloneService(svc domain.Service) domain.Service {
+	svc.Aliases = slices.Clone(svc.Aliases)
+	if svc.Aliases == nil {
+		svc.Aliases = []string{}
+	}
+	slices.SortFunc(svc.Aliases, func(a, b string) int {
+		return cmp.Compare(domain.NormalizeServiceName(a), domain.NormalizeServiceName(b))
+	})
+	return svc
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// MemoryUserSettingsRepository keeps user settings in process memory, so they are lost on restart.
+type MemoryUserSettingsRepository struct {
+	mu       sync.RWMutex
+	settings map[uuid.UUID]domain.UserSettings
+}
+
+func NewMemoryUserSettingsRepository() *MemoryUserSettingsRepository {
+	return &MemoryUserSettingsRepository{settings: make(map[uuid.UUID]domain.UserSettings)}
+}
+
+func (r *MemoryUserSettingsRepository) UserSettings(ctx context.Context, userID uuid.UUID) (domain.UserSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, ok := r.settings[userID]
+	if !ok {
+		return domain.UserSettings{}, domain.ErrUserSettingsNotFound
+	}
+	return settings, nil
+}
+
+func (r *MemoryUserSettingsRepository) SaveUserSettings(ctx context.Context, settings domain.UserSettings) (domain.UserSettings, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	settings.UpdatedAt = time.Now().UTC()
+	r.settings[settings.UserID] = settings
+	return settings, nil
+}
@@ -0,0 +1,344 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	errNoWebhook         = errors.New("webhook does not exist")
+	errNoEvent           = errors.New("event does not exist")
+	errDuplicateDelivery = errors.New("event is already delivered to the webhook")
+)
+
+// MemoryWebhookRepository keeps webhooks, the event outbox and deliveries in process
+// memory. It enforces the same constraints as the SQL schema and is safe for concurrent use.
+type MemoryWebhookRepository struct {
+	mu         sync.RWMutex
+	webhooks   map[uuid.UUID]domain.Webhook
+	events     []domain.Event
+	eventIndex map[uuid.UUID]int
+	seq        int64
+	deliveries map[uuid.UUID]domain.WebhookDelivery
+}
+
+func NewMemoryWebhookRepository() *MemoryWebhookRepository {
+	return &MemoryWebhookRepository{
+		webhooks:   make(map[uuid.UUID]domain.Webhook),
+		eventIndex: make(map[uuid.UUID]int),
+		deliveries: make(map[uuid.UUID]domain.WebhookDelivery),
+	}
+}
+
+func (r *MemoryWebhookRepository) CreateWebhook(ctx context.Context, w domain.Webhook) (domain.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[w.ID]; ok {
+		return domain.Webhook{}, fmt.Errorf("failed to create webhook: %w", errDuplicateID)
+	}
+
+	now := time.Now().UTC()
+	w.CreatedAt, w.UpdatedAt = now, now
+	w.EventTypes = slices.Clone(w.EventTypes)
+	r.webhooks[w.ID] = w
+	return cloneWebhook(w), nil
+}
+
+func (r *MemoryWebhookRepository) Webhook(ctx context.Context, id uuid.UUID) (domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	w, ok := r.webhooks[id]
+	if !ok {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+	return cloneWebhook(w), nil
+}
+
+func (r *MemoryWebhookRepository) Webhooks(ctx context.Context) ([]domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhooks := make([]domain.Webhook, 0, len(r.webhooks))
+	for _, w := range r.webhooks {
+		webhooks = append(webhooks, cloneWebhook(w))
+	}
+	slices.SortFunc(webhooks, func(a, b domain.Webhook) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID.String(), b.ID.String()))
+	})
+	return webhooks, nil
+}
+
+func (r *MemoryWebhookRepository) UpdateWebhook(ctx context.Context, id uuid.UUID, upd domain.UpdateWebhook) (domain.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.webhooks[id]
+	if !ok {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+	if upd.URL == nil && upd.Secret == nil && upd.EventTypes == nil {
+		return cloneWebhook(w), nil
+	}
+
+	if upd.URL != nil {
+		w.URL = *upd.URL
+	}
+	if upd.Secret != nil {
+		w.Secret = *upd.Secret
+	}
+	if upd.EventTypes != nil {
+		w.EventTypes = slices.Clone(*upd.EventTypes)
+	}
+	w.UpdatedAt = time.Now().UTC()
+
+	r.webhooks[id] = w
+	return cloneWebhook(w), nil
+}
+
+func (r *MemoryWebhookRepository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return domain.ErrWebhookNotFound
+	}
+	delete(r.webhooks, id)
+	for deliveryID, d := range r.deliveries {
+		if d.WebhookID == id {
+			delete(r.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryWebhookRepository) CreateEvent(ctx context.Context, e domain.Event) (domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.eventIndex[e.ID]; ok {
+		return domain.Event{}, fmt.Errorf("failed to create event: %w", errDuplicateID)
+	}
+
+	r.seq++
+	e.Seq = r.seq
+	e.Payload = slices.Clone(e.Payload)
+	e.DispatchedAt = nil
+	r.eventIndex[e.ID] = len(r.events)
+	r.events = append(r.events, e)
+	return cloneEvent(e), nil
+}
+
+func (r *MemoryWebhookRepository) UndispatchedEvents(ctx context.Context, limit int) ([]domain.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := []domain.Event{}
+	for _, e := range r.events {
+		if len(events) == limit {
+			break
+		}
+		if e.DispatchedAt == nil {
+			events = append(events, cloneEvent(e))
+		}
+	}
+	return events, nil
+}
+
+func (r *MemoryWebhookRepository) EventsAfter(ctx context.Context, seq int64, limit int) ([]domain.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Events are stored in sequence order.
+	start, _ := slices.BinarySearchFunc(r.events, seq+1, func(e domain.Event, seq int64) int { return cmp.Compare(e.Seq, seq) })
+	end := min(start+max(limit, 0), len(r.events))
+	events := make([]domain.Event, 0, end-start)
+	for _, e := range r.events[start:end] {
+		events = append(events, cloneEvent(e))
+	}
+	return events, nil
+}
+
+func (r *MemoryWebhookRepository) LastEventSeq(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.events) == 0 {
+		return 0, nil
+	}
+	return r.events[len(r.events)-1].Seq, nil
+}
+
+func (r *MemoryWebhookRepository) MarkEventDispatched(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i, ok := r.eventIndex[id]; ok {
+		r.events[i].DispatchedAt = &at
+	}
+	return nil
+}
+
+func (r *MemoryWebhookRepository) DeleteDispatchedEvents(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	withDeliveries := make(map[uuid.UUID]bool, len(r.deliveries))
+	for _, d := range r.deliveries {
+		withDeliveries[d.EventID] = true
+	}
+
+	n := len(r.events)
+	if n == 0 {
+		return 0, nil
+	}
+	latest := r.events[n-1].Seq
+	r.events = slices.DeleteFunc(r.events, func(e domain.Event) bool {
+		return e.Seq < latest && e.DispatchedAt != nil && e.CreatedAt.Before(before) && !withDeliveries[e.ID]
+	})
+	clear(r.eventIndex)
+	for i, e := range r.events {
+		r.eventIndex[e.ID] = i
+	}
+	return n - len(r.events), nil
+}
+
+func (r *MemoryWebhookRepository) CreateDelivery(ctx context.Context, d domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.webhooks[d.WebhookID]; !ok {
+		return domain.WebhookDelivery{}, fmt.Errorf("failed to create webhook delivery: %w", errNoWebhook)
+	}
+	if _, ok := r.eventIndex[d.EventID]; !ok {
+		return domain.WebhookDelivery{}, fmt.Errorf("failed to create webhook delivery: %w", errNoEvent)
+	}
+	if _, ok := r.deliveries[d.ID]; ok {
+		return domain.WebhookDelivery{}, fmt.Errorf("failed to create webhook delivery: %w", errDuplicateID)
+	}
+	for _, existing := range r.deliveries {
+		if existing.WebhookID == d.WebhookID && existing.EventID == d.EventID {
+			return domain.WebhookDelivery{}, fmt.Errorf("failed to create webhook delivery: %w", errDuplicateDelivery)
+		}
+	}
+
+	now := time.Now().UTC()
+	d.CreatedAt, d.UpdatedAt = now, now
+	d.Attempts, d.LastStatusCode, d.LastError, d.DeliveredAt = 0, nil, "", nil
+	d.Payload = slices.Clone(d.Payload)
+	r.deliveries[d.ID] = d
+	return cloneDelivery(d), nil
+}
+
+func (r *MemoryWebhookRepository) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := []domain.WebhookDelivery{}
+	for _, d := range r.deliveries {
+		if d.Status == domain.DeliveryPending && !d.NextAttemptAt.After(now) {
+			deliveries = append(deliveries, cloneDelivery(d))
+		}
+	}
+	slices.SortFunc(deliveries, func(a, b domain.WebhookDelivery) int {
+		return cmp.Or(a.NextAttemptAt.Compare(b.NextAttemptAt), cmp.Compare(r.eventIndex[a.EventID], r.eventIndex[b.EventID]),
+			cmp.Compare(a.ID.String(), b.ID.String()))
+	})
+	return deliveries[:min(limit, len(deliveries))], nil
+}
+
+func (r *MemoryWebhookRepository) UpdateDelivery(ctx context.Context, d domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.deliveries[d.ID]
+	if !ok {
+		return domain.WebhookDelivery{}, domain.ErrDeliveryNotFound
+	}
+
+	stored.Status = d.Status
+	stored.Attempts = d.Attempts
+	stored.NextAttemptAt = d.NextAttemptAt
+	stored.LastStatusCode = d.LastStatusCode
+	stored.LastError = d.LastError
+	stored.DeliveredAt = d.DeliveredAt
+	stored.UpdatedAt = time.Now().UTC()
+
+	r.deliveries[d.ID] = stored
+	return cloneDelivery(stored), nil
+}
+
+func (r *MemoryWebhookRepository) DeleteFinishedDeliveries(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(r.deliveries)
+	maps.DeleteFunc(r.deliveries, func(_ uuid.UUID, d domain.WebhookDelivery) bool {
+		return d.Status != domain.DeliveryPending && d.UpdatedAt.Before(before)
+	})
+	return n - len(r.deliveries), nil
+}
+
+func (r *MemoryWebhookRepository) Delivery(ctx context.Context, webhookID, id uuid.UUID) (domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d, ok := r.deliveries[id]
+	if !ok || d.WebhookID != webhookID {
+		return domain.WebhookDelivery{}, domain.ErrDeliveryNotFound
+	}
+	return cloneDelivery(d), nil
+}
+
+func (r *MemoryWebhookRepository) Deliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := []domain.WebhookDelivery{}
+	for _, d := range r.deliveries {
+		if d.WebhookID == filter.WebhookID && (filter.Status == nil || d.Status == *filter.Status) {
+			deliveries = append(deliveries, cloneDelivery(d))
+		}
+	}
+	slices.SortFunc(deliveries, func(a, b domain.WebhookDelivery) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(a.ID.String(), b.ID.String()))
+	})
+	return deliveries[:min(max(filter.Limit, 0), len(deliveries))], nil
+}
+
+func cloneWebhook(w domain.Webhook) domain.Webhook {
+	w.EventTypes = slices.Clone(w.EventTypes)
+	return w
+}
+
+func cloneEvent(e domain.Event) domain.Event {
+	e.Payload = slices.Clone(e.Payload)
+	if e.DispatchedAt != nil {
+		at := *e.DispatchedAt
+		e.DispatchedAt = &at
+	}
+	return e
+}
+
+func cloneDelivery(d domain.WebhookDelivery) domain.WebhookDelivery {
+	d.Payload = slices.Clone(d.Payload)
+	if d.LastStatusCode != nil {
+		code := *d.LastStatusCode
+		d.LastStatusCode = &code
+	}
+	if d.DeliveredAt != nil {
+		at := *d.DeliveredAt
+		d.DeliveredAt = &at
+	}
+	return d
+}
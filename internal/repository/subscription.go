@@ -19,6 +19,11 @@ type Query interface {
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 }
 
+// subscriptionColumns are the columns read into domain.Subscription.
+const subscriptionColumns = `id, user_id, service_id, service_name, category, price, start_date, end_date, billing_period_months, expired_at, created_at, updated_at`
+
+// SubscriptionRepository stores subscriptions in PostgreSQL or SQLite. Its statements
+// stay within the SQL both engines understand.
 type SubscriptionRepository struct {
 	db Query
 }
@@ -27,42 +32,32 @@ func NewSubscriptionRepository(db Query) *SubscriptionRepository {
 	return &SubscriptionRepository{db: db}
 }
 
+// CreateSubscription stores a subscription with its tags. Adding the tags takes more
+// statements, so a subscription with tags belongs in a transaction.
 func (s *SubscriptionRepository) CreateSubscription(ctx context.Context, sub domain.Subscription) (domain.Subscription, error) {
-	query := `INSERT INTO subscriptions (id, user_id, service_name, start_date, end_date, price) 
-		VALUES ($1, $2, $3, $4, $5, $6) 
-		RETURNING id, user_id, service_name, start_date, end_date, price, created_at, updated_at`
-
-	err := s.db.QueryRowContext(ctx, query, sub.ID, sub.UserID, sub.ServiceName, sub.StartDate, sub.EndDate, sub.Price).Scan(
-		&sub.ID,
-		&sub.UserID,
-		&sub.ServiceName,
-		&sub.StartDate,
-		&sub.EndDate,
-		&sub.Price,
-		&sub.CreatedAt,
-		&sub.UpdatedAt,
-	)
+	query := `INSERT INTO subscriptions (id, user_id, service_id, service_name, category, start_date, end_date, price, billing_period_months)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + subscriptionColumns
+
+	var created domain.Subscription
+	err := s.db.GetContext(ctx, &created, query, sub.ID, sub.UserID, sub.ServiceID, sub.ServiceName, sub.Category, sub.StartDate, sub.EndDate, sub.Price, sub.BillingPeriod)
 	if err != nil {
 		return domain.Subscription{}, fmt.Errorf("failed to create subscription: %w", err)
 	}
+	if err := s.addTags(ctx, created.ID, sub.Tags); err != nil {
+		return domain.Subscription{}, fmt.Errorf("failed to create subscription: %w", err)
+	}
 
-	return sub, nil
+	subs := []domain.Subscription{created}
+	if err := s.withTags(ctx, subs); err != nil {
+		return domain.Subscription{}, err
+	}
+	return subs[0], nil
 }
 
 func (s *SubscriptionRepository) SubscriptionByID(ctx context.Context, id uuid.UUID) (domain.Subscription, error) {
-	query := `
-		SELECT
-			id,
-			user_id,
-			service_name,
-			start_date,
-			end_date,
-			price,
-			created_at,
-			updated_at
-		FROM subscriptions
-		WHERE id = $1
-	`
+	query := `SELECT ` + subscriptionColumns + ` FROM subscriptions
+		WHERE id = $1`
 
 	var sub domain.Subscription
 	err := s.db.GetContext(ctx, &sub, query, id)
@@ -73,7 +68,11 @@ func (s *SubscriptionRepository) SubscriptionByID(ctx context.Context, id uuid.U
 		return domain.Subscription{}, fmt.Errorf("failed to get subscription: %w", err)
 	}
 
-	return sub, nil
+	subs := []domain.Subscription{sub}
+	if err := s.withTags(ctx, subs); err != nil {
+		return domain.Subscription{}, err
+	}
+	return subs[0], nil
 }
 
 func (s *SubscriptionRepository) Subscriptions(ctx context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, int, error) {
@@ -88,11 +87,8 @@ func (s *SubscriptionRepository) Subscriptions(ctx context.Context, filter domai
 		args = append(args, *filter.UserID)
 		argIdx++
 	}
-	if filter.ServiceName != nil {
-		where = append(where, fmt.Sprintf("service_name = $%d", argIdx))
-		args = append(args, *filter.ServiceName)
-		argIdx++
-	}
+	where, args, argIdx = serviceFilter(where, args, argIdx, "", filter.ServiceID, filter.ServiceName)
+	where, args, argIdx = labelFilters(where, args, argIdx, "", filter.Category, filter.Tags)
 
 	var whereClause string
 	if len(where) > 0 {
@@ -112,28 +108,49 @@ func (s *SubscriptionRepository) Subscriptions(ctx context.Context, filter domai
 
 	args = append(args, filter.Limit, filter.Offset)
 
-	query := fmt.Sprintf(`
-    SELECT 
-		id,
-		user_id,
-		service_name,
-		price,
-		start_date,
-		end_date,
-		created_at,
-		updated_at
-	FROM subscriptions
-	%s
-	LIMIT $%d OFFSET $%d
-    `, whereClause, argIdx, argIdx+1)
+	query := fmt.Sprintf(`SELECT %s FROM subscriptions
+		%s
+		ORDER BY created_at, id
+		LIMIT $%d OFFSET $%d`, subscriptionColumns, whereClause, argIdx, argIdx+1)
 
 	var subs []domain.Subscription
 	err = s.db.SelectContext(ctx, &subs, query, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get subscriptions: %w", err)
 	}
+	if err := s.withTags(ctx, subs); err != nil {
+		return nil, 0, err
+	}
 
-	return subs, total, err
+	return subs, total, nil
+}
+
+// SubscriptionsOfUsers returns the subscriptions of the users ordered by creation.
+func (s *SubscriptionRepository) SubscriptionsOfUsers(ctx context.Context, userIDs []uuid.UUID) ([]domain.Subscription, error) {
+	if len(userIDs) == 0 {
+		return []domain.Subscription{}, nil
+	}
+
+	placeholders := make([]string, len(userIDs))
+	args := make([]interface{}, len(userIDs))
+	for i, id := range userIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	query := `SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE user_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY created_at, id`
+
+	subs := []domain.Subscription{}
+	if err := s.db.SelectContext(ctx, &subs, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions of users: %w", err)
+	}
+	if err := s.withTags(ctx, subs); err != nil {
+		return nil, err
+	}
+	return subs, nil
 }
 
 func (s *SubscriptionRepository) SubscriptionsCost(ctx context.Context, filter domain.CostRequest) ([]domain.Subscription, error) {
@@ -148,18 +165,15 @@ func (s *SubscriptionRepository) SubscriptionsCost(ctx context.Context, filter d
 		args = append(args, *filter.UserID)
 		argIdx++
 	}
-	if filter.ServiceName != nil {
-		where = append(where, fmt.Sprintf("service_name = $%d", argIdx))
-		args = append(args, *filter.ServiceName)
-		argIdx++
-	}
+	where, args, argIdx = serviceFilter(where, args, argIdx, "", filter.ServiceID, filter.ServiceName)
+	where, args, _ = labelFilters(where, args, argIdx, "", filter.Category, filter.Tags)
 
 	var whereClause string
 	if len(where) > 0 {
 		whereClause = fmt.Sprintf("WHERE %v", strings.Join(where, " AND "))
 	}
 
-	query := `SELECT id, user_id, service_name, price, start_date, end_date, created_at, updated_at
+	query := `SELECT ` + subscriptionColumns + `
 		FROM subscriptions ` + whereClause
 
 	var subs []domain.Subscription
@@ -170,10 +184,59 @@ func (s *SubscriptionRepository) SubscriptionsCost(ctx context.Context, filter d
 		}
 		return nil, err
 	}
+	if err := s.withTags(ctx, subs); err != nil {
+		return nil, err
+	}
+
+	return subs, nil
+}
+
+// UpcomingSubscriptions returns the subscriptions that start before filter.To and
+// have not ended before filter.From, ordered by start date.
+func (s *SubscriptionRepository) UpcomingSubscriptions(ctx context.Context, filter domain.UpcomingFilter) ([]domain.Subscription, error) {
+	where := []string{"start_date < $1", "(end_date IS NULL OR end_date >= $2)"}
+	args := []interface{}{filter.To, filter.From}
+	argIdx := 3
+
+	if filter.UserID != nil {
+		where = append(where, fmt.Sprintf("user_id = $%d", argIdx))
+		args = append(args, *filter.UserID)
+		argIdx++
+	}
+	where, args, _ = serviceFilter(where, args, argIdx, "", filter.ServiceID, filter.ServiceName)
+
+	query := `SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY start_date, id`
+
+	subs := []domain.Subscription{}
+	if err := s.db.SelectContext(ctx, &subs, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get upcoming subscriptions: %w", err)
+	}
+	if err := s.withTags(ctx, subs); err != nil {
+		return nil, err
+	}
 
 	return subs, nil
 }
 
+func (s *SubscriptionRepository) SubscriptionStats(ctx context.Context, at time.Time) (domain.SubscriptionStats, error) {
+	query := `SELECT
+			COUNT(*) FILTER (WHERE start_date <= $1 AND (end_date IS NULL OR end_date > $1)) AS active,
+			COUNT(DISTINCT user_id) AS distinct_users
+		FROM subscriptions`
+
+	var stats domain.SubscriptionStats
+	if err := s.db.GetContext(ctx, &stats, query, at.UTC()); err != nil {
+		return domain.SubscriptionStats{}, fmt.Errorf("failed to get subscription stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// UpdateSubscription changes the fields set in sub. Changing tags takes more statements,
+// so such an update belongs in a transaction.
 func (s *SubscriptionRepository) UpdateSubscription(ctx context.Context, id uuid.UUID, sub domain.UpdateSubscription) (domain.Subscription, error) {
 	var (
 		set    []string
@@ -182,8 +245,13 @@ func (s *SubscriptionRepository) UpdateSubscription(ctx context.Context, id uuid
 	)
 
 	if sub.ServiceName != nil {
-		set = append(set, fmt.Sprintf("service_name = $%d", argIdx))
-		args = append(args, *sub.ServiceName)
+		set = append(set, fmt.Sprintf("service_name = $%d", argIdx), fmt.Sprintf("service_id = $%d", argIdx+1))
+		args = append(args, *sub.ServiceName, sub.ServiceID)
+		argIdx += 2
+	}
+	if sub.Category != nil {
+		set = append(set, fmt.Sprintf("category = $%d", argIdx))
+		args = append(args, *sub.Category)
 		argIdx++
 	}
 	if sub.Price != nil {
@@ -197,25 +265,32 @@ func (s *SubscriptionRepository) UpdateSubscription(ctx context.Context, id uuid
 		argIdx++
 	}
 	if sub.EndDate != nil {
-		set = append(set, fmt.Sprintf("end_date = $%d", argIdx))
+		// A new end date is checked again by the next expiry run.
+		set = append(set, fmt.Sprintf("end_date = $%d", argIdx), "expired_at = NULL")
 		args = append(args, *sub.EndDate)
 		argIdx++
 	}
+	if sub.BillingPeriod != nil {
+		set = append(set, fmt.Sprintf("billing_period_months = $%d", argIdx))
+		args = append(args, *sub.BillingPeriod)
+		argIdx++
+	}
 
-	if len(set) == 0 {
+	if len(set) == 0 && len(sub.AddTags) == 0 && len(sub.RemoveTags) == 0 {
 		return s.SubscriptionByID(ctx, id)
 	}
 
 	set = append(set, fmt.Sprintf("updated_at = $%d", argIdx))
-	args = append(args, time.Now())
+	args = append(args, time.Now().UTC())
 	argIdx++
 
 	args = append(args, id)
 
 	query := fmt.Sprintf(
-		"UPDATE subscriptions SET %s WHERE id = $%d RETURNING id, user_id, service_name, price, start_date, end_date, created_at, updated_at",
+		"UPDATE subscriptions SET %s WHERE id = $%d RETURNING %s",
 		strings.Join(set, ", "),
 		argIdx,
+		subscriptionColumns,
 	)
 
 	var subscription domain.Subscription
@@ -227,7 +302,36 @@ func (s *SubscriptionRepository) UpdateSubscription(ctx context.Context, id uuid
 		return domain.Subscription{}, err
 	}
 
-	return subscription, nil
+	if err := s.addTags(ctx, id, sub.AddTags); err != nil {
+		return domain.Subscription{}, fmt.Errorf("failed to update subscription: %w", err)
+	}
+	if err := s.removeTags(ctx, id, sub.RemoveTags); err != nil {
+		return domain.Subscription{}, fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	subs := []domain.Subscription{subscription}
+	if err := s.withTags(ctx, subs); err != nil {
+		return domain.Subscription{}, err
+	}
+	return subs[0], nil
+}
+
+// MarkExpiredSubscriptions sets expired_at to at for the subscriptions whose end date is
+// not after today and that are not marked yet. It returns the newly marked subscriptions.
+func (s *SubscriptionRepository) MarkExpiredSubscriptions(ctx context.Context, today, at time.Time) ([]domain.Subscription, error) {
+	query := `UPDATE subscriptions SET expired_at = $1
+		WHERE expired_at IS NULL AND end_date IS NOT NULL AND end_date <= $2
+		RETURNING ` + subscriptionColumns
+
+	marked := []domain.Subscription{}
+	if err := s.db.SelectContext(ctx, &marked, query, at, today); err != nil {
+		return nil, fmt.Errorf("failed to mark expired subscriptions: %w", err)
+	}
+	if err := s.withTags(ctx, marked); err != nil {
+		return nil, err
+	}
+
+	return marked, nil
 }
 
 func (s *SubscriptionRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
@@ -244,3 +348,232 @@ func (s *SubscriptionRepository) DeleteSubscription(ctx context.Context, id uuid
 
 	return nil
 }
+
+// DeleteSubscriptions deletes the subscriptions matching the filter and returns them.
+func (s *SubscriptionRepository) DeleteSubscriptions(ctx context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, error) {
+	var (
+		where  []string
+		args   []interface{}
+		argIdx = 1
+	)
+
+	if filter.UserID != nil {
+		where = append(where, fmt.Sprintf("user_id = $%d", argIdx))
+		args = append(args, *filter.UserID)
+		argIdx++
+	}
+	where, args, argIdx = serviceFilter(where, args, argIdx, "", filter.ServiceID, filter.ServiceName)
+	where, args, _ = labelFilters(where, args, argIdx, "", filter.Category, filter.Tags)
+
+	var whereClause string
+	if len(where) > 0 {
+		whereClause = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	// The tags go with the subscriptions, so they are read first.
+	tags, err := s.tags(ctx, `WHERE subscription_id IN (SELECT id FROM subscriptions`+whereClause+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "DELETE FROM subscriptions" + whereClause + ` RETURNING ` + subscriptionColumns
+
+	deleted := []domain.Subscription{}
+	if err := s.db.SelectContext(ctx, &deleted, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to delete subscriptions: %w", err)
+	}
+	for i := range deleted {
+		deleted[i].Tags = tagsOf(tags, deleted[i].ID)
+	}
+
+	return deleted, nil
+}
+
+func (s *SubscriptionRepository) CreatePriceChange(ctx context.Context, change domain.PriceChange) (domain.PriceChange, error) {
+	query := `INSERT INTO price_changes (id, subscription_id, price, effective_from)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, subscription_id, price, effective_from, created_at`
+
+	var created domain.PriceChange
+	err := s.db.GetContext(ctx, &created, query, change.ID, change.SubscriptionID, change.Price, change.EffectiveFrom)
+	if err != nil {
+		return domain.PriceChange{}, fmt.Errorf("failed to create price change: %w", err)
+	}
+
+	return created, nil
+}
+
+// PriceChanges returns the price changes of a subscription by effective date. Changes
+// with the same date are ordered by creation, so the last one wins.
+func (s *SubscriptionRepository) PriceChanges(ctx context.Context, subscriptionID uuid.UUID) ([]domain.PriceChange, error) {
+	query := `SELECT id, subscription_id, price, effective_from, created_at
+		FROM price_changes
+		WHERE subscription_id = $1
+		ORDER BY effective_from, created_at, id`
+
+	changes := []domain.PriceChange{}
+	if err := s.db.SelectContext(ctx, &changes, query, subscriptionID); err != nil {
+		return nil, fmt.Errorf("failed to get price changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// ScheduledPriceChanges returns the price changes of the subscriptions matching the
+// filter, ordered like PriceChanges.
+func (s *SubscriptionRepository) ScheduledPriceChanges(ctx context.Context, filter domain.CostRequest) ([]domain.PriceChange, error) {
+	var (
+		where  []string
+		args   []interface{}
+		argIdx = 1
+	)
+
+	if filter.UserID != nil {
+		where = append(where, fmt.Sprintf("s.user_id = $%d", argIdx))
+		args = append(args, *filter.UserID)
+		argIdx++
+	}
+	where, args, argIdx = serviceFilter(where, args, argIdx, "s.", filter.ServiceID, filter.ServiceName)
+	where, args, _ = labelFilters(where, args, argIdx, "s.", filter.Category, filter.Tags)
+
+	var whereClause string
+	if len(where) > 0 {
+		whereClause = "WHERE " + strings.Join(where, " AND ")
+	}
+
+	query := `SELECT pc.id, pc.subscription_id, pc.price, pc.effective_from, pc.created_at
+		FROM price_changes pc
+		JOIN subscriptions s ON s.id = pc.subscription_id
+		` + whereClause + `
+		ORDER BY pc.subscription_id, pc.effective_from, pc.created_at, pc.id`
+
+	changes := []domain.PriceChange{}
+	if err := s.db.SelectContext(ctx, &changes, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get scheduled price changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (s *SubscriptionRepository) DeletePriceChange(ctx context.Context, subscriptionID, id uuid.UUID) error {
+	query := `DELETE FROM price_changes WHERE id = $1 AND subscription_id = $2`
+	result, err := s.db.ExecContext(ctx, query, id, subscriptionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete price change: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrPriceChangeNotFound
+	}
+
+	return nil
+}
+
+// tagsBatch bounds the subscriptions whose tags are read by one statement, keeping it
+// under the parameter limits of both engines.
+const tagsBatch = 500
+
+type tagRow struct {
+	SubscriptionID uuid.UUID `db:"subscription_id"`
+	Tag            string    `db:"tag"`
+}
+
+// serviceFilter appends the condition selecting subscriptions by their catalog service,
+// or by their stored service name when serviceID is nil. prefix qualifies the columns of
+// the subscriptions table.
+func serviceFilter(where []string, args []interface{}, argIdx int, prefix string, serviceID *uuid.UUID, serviceName *string) ([]string, []interface{}, int) {
+	switch {
+	case serviceID != nil:
+		where = append(where, fmt.Sprintf("%sservice_id = $%d", prefix, argIdx))
+		args = append(args, *serviceID)
+		argIdx++
+	case serviceName != nil:
+		where = append(where, fmt.Sprintf("%sservice_name = $%d", prefix, argIdx))
+		args = append(args, *serviceName)
+		argIdx++
+	}
+	return where, args, argIdx
+}
+
+// labelFilters appends the conditions selecting subscriptions by category and by having
+// all of tags. prefix qualifies the columns of the subscriptions table.
+func labelFilters(where []string, args []interface{}, argIdx int, prefix string, category *string, tags []string) ([]string, []interface{}, int) {
+	if category != nil {
+		where = append(where, fmt.Sprintf("%scategory = $%d", prefix, argIdx))
+		args = append(args, *category)
+		argIdx++
+	}
+	for _, tag := range tags {
+		where = append(where, fmt.Sprintf("%sid IN (SELECT subscription_id FROM subscription_tags WHERE tag = $%d)", prefix, argIdx))
+		args = append(args, tag)
+		argIdx++
+	}
+	return where, args, argIdx
+}
+
+// withTags reads the tags of subs into them.
+func (s *SubscriptionRepository) withTags(ctx context.Context, subs []domain.Subscription) error {
+	for start := 0; start < len(subs); start += tagsBatch {
+		batch := subs[start:min(start+tagsBatch, len(subs))]
+
+		placeholders := make([]string, len(batch))
+		args := make([]interface{}, len(batch))
+		for i, sub := range batch {
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+			args[i] = sub.ID
+		}
+
+		tags, err := s.tags(ctx, `WHERE subscription_id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+		if err != nil {
+			return err
+		}
+		for i := range batch {
+			batch[i].Tags = tagsOf(tags, batch[i].ID)
+		}
+	}
+	return nil
+}
+
+// tags returns the tags matching where by subscription, in tag order.
+func (s *SubscriptionRepository) tags(ctx context.Context, where string, args ...interface{}) (map[uuid.UUID][]string, error) {
+	var rows []tagRow
+	query := `SELECT subscription_id, tag FROM subscription_tags ` + where + ` ORDER BY tag`
+	if err := s.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get subscription tags: %w", err)
+	}
+
+	tags := make(map[uuid.UUID][]string)
+	for _, row := range rows {
+		tags[row.SubscriptionID] = append(tags[row.SubscriptionID], row.Tag)
+	}
+	return tags, nil
+}
+
+func tagsOf(tags map[uuid.UUID][]string, id uuid.UUID) []string {
+	if tags[id] == nil {
+		return []string{}
+	}
+	return tags[id]
+}
+
+// addTags adds the tags a subscription does not have yet.
+func (s *SubscriptionRepository) addTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	for _, tag := range tags {
+		query := `INSERT INTO subscription_tags (subscription_id, tag) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+		if _, err := s.db.ExecContext(ctx, query, id, tag); err != nil {
+			return fmt.Errorf("failed to add tag %q: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+func (s *SubscriptionRepository) removeTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	for _, tag := range tags {
+		query := `DELETE FROM subscription_tags WHERE subscription_id = $1 AND tag = $2`
+		if _, err := s.db.ExecContext(ctx, query, id, tag); err != nil {
+			return fmt.Errorf("failed to remove tag %q: %w", tag, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"tz/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracedQuery struct {
+	db     Query
+	system attribute.KeyValue
+}
+
+// WithTracing wraps db so that every statement runs in its own client span.
+// The driver name selects the db.system.name attribute.
+func WithTracing(db Query, driver string) Query {
+	system := semconv.DBSystemNamePostgreSQL
+	if driver == "sqlite" {
+		system = semconv.DBSystemNameSQLite
+	}
+	return &tracedQuery{db: db, system: system}
+}
+
+func (q *tracedQuery) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := q.start(ctx, "QueryRow", query)
+	defer span.End()
+	row := q.db.QueryRowContext(ctx, query, args...)
+	q.finish(span, row.Err())
+	return row
+}
+
+func (q *tracedQuery) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := q.start(ctx, "Get", query)
+	defer span.End()
+	err := q.db.GetContext(ctx, dest, query, args...)
+	q.finish(span, err)
+	return err
+}
+
+func (q *tracedQuery) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, span := q.start(ctx, "Select", query)
+	defer span.End()
+	err := q.db.SelectContext(ctx, dest, query, args...)
+	q.finish(span, err)
+	return err
+}
+
+func (q *tracedQuery) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := q.start(ctx, "Exec", query)
+	defer span.End()
+	res, err := q.db.ExecContext(ctx, query, args...)
+	q.finish(span, err)
+	return res, err
+}
+
+func (q *tracedQuery) start(ctx context.Context, operation, query string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "sql."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(q.system, semconv.DBQueryText(query)),
+	)
+}
+
+func (q *tracedQuery) finish(span trace.Span, err error) {
+	if err == nil || errors.Is(err, sql.ErrNoRows) {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
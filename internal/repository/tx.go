@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type txKey struct{}
+
+// Transactor runs functions in a database transaction. Repositories built on the Query
+// returned by WithTransactions take part in it through the context.
+type Transactor struct {
+	db *sqlx.DB
+}
+
+func NewTransactor(db *sqlx.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// InTx commits when fn succeeds and rolls back when it fails. A call within a running
+// transaction joins it.
+func (t *Transactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+type txQuery struct {
+	db *sqlx.DB
+}
+
+// WithTransactions returns a Query that runs statements in the transaction started by
+// Transactor.InTx on the context, and on db otherwise.
+func WithTransactions(db *sqlx.DB) Query {
+	return &txQuery{db: db}
+}
+
+func (q *txQuery) conn(ctx context.Context) Query {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return q.db
+}
+
+func (q *txQuery) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return q.conn(ctx).QueryRowContext(ctx, query, args...)
+}
+
+func (q *txQuery) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return q.conn(ctx).GetContext(ctx, dest, query, args...)
+}
+
+func (q *txQuery) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return q.conn(ctx).SelectContext(ctx, dest, query, args...)
+}
+
+func (q *txQuery) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return q.conn(ctx).ExecContext(ctx, query, args...)
+}
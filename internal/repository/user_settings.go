@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type userSettingsRow struct {
+	UserID    uuid.UUID `db:"user_id"`
+	TimeZone  string    `db:"time_zone"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (r userSettingsRow) toDomain() domain.UserSettings {
+	return domain.UserSettings{UserID: r.UserID, TimeZone: r.TimeZone, UpdatedAt: r.UpdatedAt}
+}
+
+type UserSettingsRepository struct {
+	db Query
+}
+
+func NewUserSettingsRepository(db Query) *UserSettingsRepository {
+	return &UserSettingsRepository{db: db}
+}
+
+func (r *UserSettingsRepository) UserSettings(ctx context.Context, userID uuid.UUID) (domain.UserSettings, error) {
+	query := `SELECT user_id, time_zone, updated_at FROM user_settings WHERE user_id = $1`
+
+	var row userSettingsRow
+	if err := r.db.GetContext(ctx, &row, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.UserSettings{}, domain.ErrUserSettingsNotFound
+		}
+		return domain.UserSettings{}, fmt.Errorf("failed to get user settings: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+// SaveUserSettings creates or replaces the settings of settings.UserID.
+func (r *UserSettingsRepository) SaveUserSettings(ctx context.Context, settings domain.UserSettings) (domain.UserSettings, error) {
+	query := `INSERT INTO user_settings (user_id, time_zone, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET time_zone = excluded.time_zone, updated_at = excluded.updated_at
+		RETURNING user_id, time_zone, updated_at`
+
+	var row userSettingsRow
+	if err := r.db.GetContext(ctx, &row, query, settings.UserID, settings.TimeZone, time.Now().UTC()); err != nil {
+		return domain.UserSettings{}, fmt.Errorf("failed to save user settings: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
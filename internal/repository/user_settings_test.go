@@ -0,0 +1,60 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"tz/internal/config"
+	"tz/internal/db"
+	"tz/internal/domain"
+	"tz/internal/repository"
+	"tz/internal/service"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// userSettingsBackends lists the UserSettingsRepositoryI implementations under test.
+// PostgreSQL is added by the integration build tag, see postgres_test.go.
+var userSettingsBackends = map[string]func(t *testing.T) service.UserSettingsRepositoryI{
+	"memory": func(t *testing.T) service.UserSettingsRepositoryI {
+		return repository.NewMemoryUserSettingsRepository()
+	},
+	"sqlite": func(t *testing.T) service.UserSettingsRepositoryI {
+		database, err := db.NewSQLite(config.DatabaseConfig{SQLite: config.SQLiteConfig{Path: ":memory:"}}, zap.NewNop())
+		if err != nil {
+			t.Fatalf("NewSQLite() error = %v", err)
+		}
+		t.Cleanup(func() { _ = database.Close() })
+		return repository.NewUserSettingsRepository(database)
+	},
+}
+
+func TestUserSettingsRepository(t *testing.T) {
+	for backend, open := range userSettingsBackends {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			repo := open(t)
+			userID := uuid.New()
+
+			if _, err := repo.UserSettings(ctx, userID); !errors.Is(err, domain.ErrUserSettingsNotFound) {
+				t.Fatalf("UserSettings() error = %v, want ErrUserSettingsNotFound", err)
+			}
+
+			for _, zone := range []string{"Europe/Moscow", "Asia/Vladivostok"} {
+				saved, err := repo.SaveUserSettings(ctx, domain.UserSettings{UserID: userID, TimeZone: zone})
+				if err != nil {
+					t.Fatalf("SaveUserSettings(%s) error = %v", zone, err)
+				}
+				if saved.TimeZone != zone || saved.UpdatedAt.IsZero() {
+					t.Errorf("SaveUserSettings(%s) = %+v", zone, saved)
+				}
+			}
+
+			got, err := repo.UserSettings(ctx, userID)
+			if err != nil || got.UserID != userID || got.TimeZone != "Asia/Vladivostok" {
+				t.Errorf("UserSettings() = %+v, %v", got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type webhookRow struct {
+	ID         uuid.UUID      `db:"id"`
+	URL        string         `db:"url"`
+	Secret     string         `db:"secret"`
+	EventTypes pq.StringArray `db:"event_types"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+func (r webhookRow) toDomain() domain.Webhook {
+	types := make([]domain.EventType, len(r.EventTypes))
+	for i, t := range r.EventTypes {
+		types[i] = domain.EventType(t)
+	}
+	return domain.Webhook{
+		ID:         r.ID,
+		URL:        r.URL,
+		Secret:     r.Secret,
+		EventTypes: types,
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+	}
+}
+
+const (
+	webhookColumns  = `id, url, secret, event_types, created_at, updated_at`
+	eventColumns    = `seq, id, type, subscription_id, payload, created_at, dispatched_at`
+	deliveryColumns = `id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+		last_status_code, last_error, delivered_at, created_at, updated_at`
+)
+
+// WebhookRepository stores webhooks, the event outbox and webhook deliveries in
+// PostgreSQL or SQLite.
+type WebhookRepository struct {
+	db Query
+}
+
+func NewWebhookRepository(db Query) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, w domain.Webhook) (domain.Webhook, error) {
+	query := `INSERT INTO webhooks (id, url, secret, event_types)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + webhookColumns
+
+	var row webhookRow
+	if err := r.db.GetContext(ctx, &row, query, w.ID, w.URL, w.Secret, eventTypeArray(w.EventTypes)); err != nil {
+		return domain.Webhook{}, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+func (r *WebhookRepository) Webhook(ctx context.Context, id uuid.UUID) (domain.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	var row webhookRow
+	if err := r.db.GetContext(ctx, &row, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Webhook{}, domain.ErrWebhookNotFound
+		}
+		return domain.Webhook{}, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+// Webhooks returns all webhooks, oldest first.
+func (r *WebhookRepository) Webhooks(ctx context.Context) ([]domain.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks ORDER BY created_at, id`
+
+	var rows []webhookRow
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	webhooks := make([]domain.Webhook, len(rows))
+	for i, row := range rows {
+		webhooks[i] = row.toDomain()
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) UpdateWebhook(ctx context.Context, id uuid.UUID, upd domain.UpdateWebhook) (domain.Webhook, error) {
+	var (
+		set    []string
+		args   []interface{}
+		argIdx = 1
+	)
+
+	if upd.URL != nil {
+		set = append(set, fmt.Sprintf("url = $%d", argIdx))
+		args = append(args, *upd.URL)
+		argIdx++
+	}
+	if upd.Secret != nil {
+		set = append(set, fmt.Sprintf("secret = $%d", argIdx))
+		args = append(args, *upd.Secret)
+		argIdx++
+	}
+	if upd.EventTypes != nil {
+		set = append(set, fmt.Sprintf("event_types = $%d", argIdx))
+		args = append(args, eventTypeArray(*upd.EventTypes))
+		argIdx++
+	}
+
+	if len(set) == 0 {
+		return r.Webhook(ctx, id)
+	}
+
+	set = append(set, fmt.Sprintf("updated_at = $%d", argIdx))
+	args = append(args, time.Now().UTC())
+	argIdx++
+	args = append(args, id)
+
+	query := fmt.Sprintf(`UPDATE webhooks SET %s WHERE id = $%d RETURNING %s`, strings.Join(set, ", "), argIdx, webhookColumns)
+
+	var row webhookRow
+	if err := r.db.GetContext(ctx, &row, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Webhook{}, domain.ErrWebhookNotFound
+		}
+		return domain.Webhook{}, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+// DeleteWebhook removes a webhook together with its deliveries.
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// CreateEvent writes an event to the outbox.
+func (r *WebhookRepository) CreateEvent(ctx context.Context, e domain.Event) (domain.Event, error) {
+	query := `INSERT INTO outbox_events (id, type, subscription_id, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + eventColumns
+
+	var created domain.Event
+	err := r.db.GetContext(ctx, &created, query, e.ID, string(e.Type), e.SubscriptionID, string(e.Payload), e.CreatedAt)
+	if err != nil {
+		return domain.Event{}, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	return created, nil
+}
+
+// UndispatchedEvents returns up to limit events without deliveries, oldest first.
+func (r *WebhookRepository) UndispatchedEvents(ctx context.Context, limit int) ([]domain.Event, error) {
+	query := `SELECT ` + eventColumns + ` FROM outbox_events
+		WHERE dispatched_at IS NULL
+		ORDER BY seq
+		LIMIT $1`
+
+	events := []domain.Event{}
+	if err := r.db.SelectContext(ctx, &events, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to get undispatched events: %w", err)
+	}
+
+	return events, nil
+}
+
+// EventsAfter returns up to limit events with a sequence number above seq, in order.
+func (r *WebhookRepository) EventsAfter(ctx context.Context, seq int64, limit int) ([]domain.Event, error) {
+	query := `SELECT ` + eventColumns + ` FROM outbox_events
+		WHERE seq > $1
+		ORDER BY seq
+		LIMIT $2`
+
+	events := []domain.Event{}
+	if err := r.db.SelectContext(ctx, &events, query, seq, limit); err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	return events, nil
+}
+
+// LastEventSeq returns the sequence number of the latest event, 0 when there are none.
+func (r *WebhookRepository) LastEventSeq(ctx context.Context) (int64, error) {
+	var seq int64
+	if err := r.db.GetContext(ctx, &seq, `SELECT COALESCE(MAX(seq), 0) FROM outbox_events`); err != nil {
+		return 0, fmt.Errorf("failed to get last event seq: %w", err)
+	}
+	return seq, nil
+}
+
+func (r *WebhookRepository) MarkEventDispatched(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE outbox_events SET dispatched_at = $1 WHERE id = $2`, at, id); err != nil {
+		return fmt.Errorf("failed to mark event dispatched: %w", err)
+	}
+	return nil
+}
+
+// DeleteDispatchedEvents deletes the dispatched events created before before that have no
+// deliveries left and returns their number. The latest event is kept, so LastEventSeq
+// stays where the event streams left off.
+func (r *WebhookRepository) DeleteDispatchedEvents(ctx context.Context, before time.Time) (int, error) {
+	query := `DELETE FROM outbox_events
+		WHERE dispatched_at IS NOT NULL AND created_at < $1
+			AND seq < (SELECT MAX(seq) FROM outbox_events)
+			AND NOT EXISTS (SELECT 1 FROM webhook_deliveries WHERE webhook_deliveries.event_id = outbox_events.id)`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete dispatched events: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, d domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	query := `INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, status, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + deliveryColumns
+
+	var created domain.WebhookDelivery
+	err := r.db.GetContext(ctx, &created, query,
+		d.ID, d.WebhookID, d.EventID, string(d.EventType), string(d.Payload), string(d.Status), d.NextAttemptAt)
+	if err != nil {
+		return domain.WebhookDelivery{}, fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	return created, nil
+}
+
+// DueDeliveries returns up to limit pending deliveries whose next attempt is not after now,
+// the longest waiting first and deliveries due at the same time in event order.
+func (r *WebhookRepository) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries
+		WHERE status = $1 AND next_attempt_at <= $2
+		ORDER BY next_attempt_at, (SELECT seq FROM outbox_events WHERE outbox_events.id = event_id), id
+		LIMIT $3`
+
+	deliveries := []domain.WebhookDelivery{}
+	if err := r.db.SelectContext(ctx, &deliveries, query, string(domain.DeliveryPending), now, limit); err != nil {
+		return nil, fmt.Errorf("failed to get due webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// UpdateDelivery stores the outcome of an attempt: status, attempts, next attempt, last
+// status code, last error and delivery time.
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, d domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	query := `UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, next_attempt_at = $3, last_status_code = $4, last_error = $5,
+			delivered_at = $6, updated_at = $7
+		WHERE id = $8
+		RETURNING ` + deliveryColumns
+
+	var updated domain.WebhookDelivery
+	err := r.db.GetContext(ctx, &updated, query,
+		string(d.Status), d.Attempts, d.NextAttemptAt, d.LastStatusCode, d.LastError, d.DeliveredAt, time.Now().UTC(), d.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookDelivery{}, domain.ErrDeliveryNotFound
+		}
+		return domain.WebhookDelivery{}, fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteFinishedDeliveries deletes the delivered and dead deliveries last updated before
+// before and returns their number.
+func (r *WebhookRepository) DeleteFinishedDeliveries(ctx context.Context, before time.Time) (int, error) {
+	query := `DELETE FROM webhook_deliveries WHERE status <> $1 AND updated_at < $2`
+
+	result, err := r.db.ExecContext(ctx, query, string(domain.DeliveryPending), before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished webhook deliveries: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+func (r *WebhookRepository) Delivery(ctx context.Context, webhookID, id uuid.UUID) (domain.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE webhook_id = $1 AND id = $2`
+
+	var d domain.WebhookDelivery
+	if err := r.db.GetContext(ctx, &d, query, webhookID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookDelivery{}, domain.ErrDeliveryNotFound
+		}
+		return domain.WebhookDelivery{}, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return d, nil
+}
+
+// Deliveries returns the deliveries of a webhook, newest first.
+func (r *WebhookRepository) Deliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE webhook_id = $1`
+	args := []interface{}{filter.WebhookID}
+	if filter.Status != nil {
+		query += ` AND status = $2`
+		args = append(args, string(*filter.Status))
+	}
+	query += fmt.Sprintf(` ORDER BY created_at DESC, id LIMIT $%d`, len(args)+1)
+	args = append(args, filter.Limit)
+
+	deliveries := []domain.WebhookDelivery{}
+	if err := r.db.SelectContext(ctx, &deliveries, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func eventTypeArray(types []domain.EventType) pq.StringArray {
+	arr := make(pq.StringArray, len(types))
+	for i, t := range types {
+		arr[i] = string(t)
+	}
+	return arr
+}
@@ -0,0 +1,305 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	"tz/internal/config"
+	"tz/internal/db"
+	"tz/internal/domain"
+	"tz/internal/repository"
+	"tz/internal/service"
+	"tz/internal/stream"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// webhookBackends lists the WebhookRepositoryI implementations under test. PostgreSQL is
+// added by the integration build tag, see postgres_test.go.
+var webhookBackends = map[string]func(t *testing.T) service.WebhookRepositoryI{
+	"memory": func(t *testing.T) service.WebhookRepositoryI {
+		return repository.NewMemoryWebhookRepository()
+	},
+	"sqlite": func(t *testing.T) service.WebhookRepositoryI {
+		database, err := db.NewSQLite(config.DatabaseConfig{SQLite: config.SQLiteConfig{Path: ":memory:"}}, zap.NewNop())
+		if err != nil {
+			t.Fatalf("NewSQLite() error = %v", err)
+		}
+		t.Cleanup(func() { _ = database.Close() })
+		return repository.NewWebhookRepository(database)
+	},
+}
+
+func TestWebhookRepository(t *testing.T) {
+	for backend, open := range webhookBackends {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			repo := open(t)
+
+			created, err := repo.CreateWebhook(ctx, domain.Webhook{
+				ID: uuid.New(), URL: "https://example.com/hook", Secret: "s3cret",
+				EventTypes: []domain.EventType{domain.EventCreated, domain.EventDeleted},
+			})
+			if err != nil {
+				t.Fatalf("CreateWebhook() error = %v", err)
+			}
+			if created.CreatedAt.IsZero() || !slices.Equal(created.EventTypes, []domain.EventType{domain.EventCreated, domain.EventDeleted}) {
+				t.Errorf("CreateWebhook() = %+v", created)
+			}
+			if got, err := repo.Webhook(ctx, created.ID); err != nil || got.Secret != "s3cret" || got.URL != created.URL {
+				t.Errorf("Webhook() = %+v, %v", got, err)
+			}
+			if _, err := repo.Webhook(ctx, uuid.New()); !errors.Is(err, domain.ErrWebhookNotFound) {
+				t.Errorf("Webhook(unknown) error = %v, want ErrWebhookNotFound", err)
+			}
+
+			url, types := "https://example.com/other", []domain.EventType{domain.EventPriceChanged}
+			updated, err := repo.UpdateWebhook(ctx, created.ID, domain.UpdateWebhook{URL: &url, EventTypes: &types})
+			if err != nil || updated.URL != url || !slices.Equal(updated.EventTypes, types) || updated.Secret != "s3cret" {
+				t.Errorf("UpdateWebhook() = %+v, %v", updated, err)
+			}
+			if _, err := repo.UpdateWebhook(ctx, uuid.New(), domain.UpdateWebhook{URL: &url}); !errors.Is(err, domain.ErrWebhookNotFound) {
+				t.Errorf("UpdateWebhook(unknown) error = %v, want ErrWebhookNotFound", err)
+			}
+			if all, err := repo.Webhooks(ctx); err != nil || len(all) != 1 {
+				t.Errorf("Webhooks() = %+v, %v, want 1", all, err)
+			}
+
+			at := time.Date(2025, time.November, 8, 12, 0, 0, 0, time.UTC)
+			var events []domain.Event
+			for range 3 {
+				e, err := repo.CreateEvent(ctx, domain.Event{
+					ID: uuid.New(), Type: domain.EventCreated, SubscriptionID: uuid.New(), Payload: []byte(`{"type":"created"}`), CreatedAt: at,
+				})
+				if err != nil {
+					t.Fatalf("CreateEvent() error = %v", err)
+				}
+				events = append(events, e)
+			}
+			if events[0].Seq >= events[1].Seq || events[1].Seq >= events[2].Seq {
+				t.Errorf("event seqs = %d, %d, %d, want increasing", events[0].Seq, events[1].Seq, events[2].Seq)
+			}
+
+			log := repo.(stream.Log)
+			if last, err := log.LastEventSeq(ctx); err != nil || last != events[2].Seq {
+				t.Errorf("LastEventSeq() = %d, %v, want %d", last, err, events[2].Seq)
+			}
+			if after, err := log.EventsAfter(ctx, events[0].Seq, 1); err != nil || len(after) != 1 || after[0].ID != events[1].ID || after[0].Seq != events[1].Seq {
+				t.Errorf("EventsAfter(first, 1) = %+v, %v, want the second event", after, err)
+			}
+			if after, err := log.EventsAfter(ctx, events[2].Seq, 10); err != nil || len(after) != 0 {
+				t.Errorf("EventsAfter(last) = %+v, %v, want none", after, err)
+			}
+
+			pending, err := repo.UndispatchedEvents(ctx, 2)
+			if err != nil || len(pending) != 2 || pending[0].ID != events[0].ID || string(pending[0].Payload) != `{"type":"created"}` {
+				t.Errorf("UndispatchedEvents(2) = %+v, %v", pending, err)
+			}
+			if err := repo.MarkEventDispatched(ctx, events[0].ID, at); err != nil {
+				t.Fatalf("MarkEventDispatched() error = %v", err)
+			}
+			if pending, err := repo.UndispatchedEvents(ctx, 10); err != nil || len(pending) != 2 || pending[0].ID != events[1].ID {
+				t.Errorf("UndispatchedEvents() after dispatch = %+v, %v", pending, err)
+			}
+
+			delivery := domain.WebhookDelivery{
+				ID: uuid.New(), WebhookID: created.ID, EventID: events[0].ID, EventType: domain.EventCreated,
+				Payload: events[0].Payload, Status: domain.DeliveryPending, NextAttemptAt: at,
+			}
+			stored, err := repo.CreateDelivery(ctx, delivery)
+			if err != nil || stored.Attempts != 0 || stored.Status != domain.DeliveryPending || string(stored.Payload) != `{"type":"created"}` {
+				t.Fatalf("CreateDelivery() = %+v, %v", stored, err)
+			}
+			again := delivery
+			again.ID = uuid.New()
+			if _, err := repo.CreateDelivery(ctx, again); err == nil {
+				t.Error("CreateDelivery() for the same webhook and event succeeded")
+			}
+			later := delivery
+			later.ID, later.EventID, later.NextAttemptAt = uuid.New(), events[1].ID, at.Add(time.Hour)
+			if _, err := repo.CreateDelivery(ctx, later); err != nil {
+				t.Fatalf("CreateDelivery() error = %v", err)
+			}
+
+			// Deliveries due at the same time come in event order.
+			third := delivery
+			third.ID, third.EventID = uuid.New(), events[2].ID
+			if _, err := repo.CreateDelivery(ctx, third); err != nil {
+				t.Fatalf("CreateDelivery() error = %v", err)
+			}
+			due, err := repo.DueDeliveries(ctx, at, 10)
+			if err != nil || len(due) != 2 || due[0].ID != delivery.ID || due[1].ID != third.ID {
+				t.Errorf("DueDeliveries() = %+v, %v, want the first and the third delivery", due, err)
+			}
+			if due, err := repo.DueDeliveries(ctx, at, 1); err != nil || len(due) != 1 || due[0].ID != delivery.ID {
+				t.Errorf("DueDeliveries(limit 1) = %+v, %v, want the first delivery", due, err)
+			}
+			third.Status = domain.DeliveryDead
+			if _, err := repo.UpdateDelivery(ctx, third); err != nil {
+				t.Fatalf("UpdateDelivery() error = %v", err)
+			}
+
+			code := 200
+			stored.Status, stored.Attempts, stored.LastStatusCode, stored.DeliveredAt = domain.DeliveryDelivered, 1, &code, &at
+			updated2, err := repo.UpdateDelivery(ctx, stored)
+			if err != nil || updated2.Status != domain.DeliveryDelivered || updated2.Attempts != 1 ||
+				updated2.LastStatusCode == nil || *updated2.LastStatusCode != 200 || updated2.DeliveredAt == nil {
+				t.Errorf("UpdateDelivery() = %+v, %v", updated2, err)
+			}
+			if due, err := repo.DueDeliveries(ctx, at.Add(2*time.Hour), 10); err != nil || len(due) != 1 || due[0].ID != later.ID {
+				t.Errorf("DueDeliveries() after delivery = %+v, %v, want the later delivery", due, err)
+			}
+			if _, err := repo.UpdateDelivery(ctx, domain.WebhookDelivery{ID: uuid.New(), Status: domain.DeliveryDead}); !errors.Is(err, domain.ErrDeliveryNotFound) {
+				t.Errorf("UpdateDelivery(unknown) error = %v, want ErrDeliveryNotFound", err)
+			}
+
+			if got, err := repo.Delivery(ctx, created.ID, delivery.ID); err != nil || got.Status != domain.DeliveryDelivered {
+				t.Errorf("Delivery() = %+v, %v", got, err)
+			}
+			if _, err := repo.Delivery(ctx, uuid.New(), delivery.ID); !errors.Is(err, domain.ErrDeliveryNotFound) {
+				t.Errorf("Delivery(other webhook) error = %v, want ErrDeliveryNotFound", err)
+			}
+
+			status := domain.DeliveryPending
+			list, err := repo.Deliveries(ctx, domain.DeliveryFilter{WebhookID: created.ID, Status: &status, Limit: 10})
+			if err != nil || len(list) != 1 || list[0].ID != later.ID {
+				t.Errorf("Deliveries(pending) = %+v, %v", list, err)
+			}
+			if list, err := repo.Deliveries(ctx, domain.DeliveryFilter{WebhookID: created.ID, Limit: 1}); err != nil || len(list) != 1 {
+				t.Errorf("Deliveries(limit 1) = %+v, %v", list, err)
+			}
+
+			if err := repo.DeleteWebhook(ctx, created.ID); err != nil {
+				t.Fatalf("DeleteWebhook() error = %v", err)
+			}
+			if err := repo.DeleteWebhook(ctx, created.ID); !errors.Is(err, domain.ErrWebhookNotFound) {
+				t.Errorf("second DeleteWebhook() error = %v, want ErrWebhookNotFound", err)
+			}
+			if list, err := repo.Deliveries(ctx, domain.DeliveryFilter{WebhookID: created.ID, Limit: 10}); err != nil || len(list) != 0 {
+				t.Errorf("Deliveries() after delete = %+v, %v, want none", list, err)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_Purge(t *testing.T) {
+	for backend, open := range webhookBackends {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			repo := open(t)
+
+			hook, err := repo.CreateWebhook(ctx, domain.Webhook{ID: uuid.New(), URL: "https://example.com/hook", Secret: "s3cret"})
+			if err != nil {
+				t.Fatalf("CreateWebhook() error = %v", err)
+			}
+
+			// Delivered, pending, without deliveries and the latest one, all dispatched.
+			at := time.Date(2025, time.November, 8, 12, 0, 0, 0, time.UTC)
+			events := make([]domain.Event, 4)
+			for i := range events {
+				e, err := repo.CreateEvent(ctx, domain.Event{
+					ID: uuid.New(), Type: domain.EventCreated, SubscriptionID: uuid.New(), Payload: []byte(`{}`), CreatedAt: at,
+				})
+				if err != nil {
+					t.Fatalf("CreateEvent() error = %v", err)
+				}
+				if err := repo.MarkEventDispatched(ctx, e.ID, at); err != nil {
+					t.Fatalf("MarkEventDispatched() error = %v", err)
+				}
+				events[i] = e
+			}
+			for i, status := range []domain.DeliveryStatus{domain.DeliveryDelivered, domain.DeliveryPending} {
+				d, err := repo.CreateDelivery(ctx, domain.WebhookDelivery{
+					ID: uuid.New(), WebhookID: hook.ID, EventID: events[i].ID, EventType: domain.EventCreated,
+					Payload: []byte(`{}`), Status: domain.DeliveryPending, NextAttemptAt: at,
+				})
+				if err != nil {
+					t.Fatalf("CreateDelivery() error = %v", err)
+				}
+				d.Status = status
+				if _, err := repo.UpdateDelivery(ctx, d); err != nil {
+					t.Fatalf("UpdateDelivery() error = %v", err)
+				}
+			}
+
+			if n, err := repo.DeleteFinishedDeliveries(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+				t.Errorf("DeleteFinishedDeliveries(an hour ago) = %d, %v, want 0", n, err)
+			}
+			if n, err := repo.DeleteFinishedDeliveries(ctx, time.Now().Add(time.Hour)); err != nil || n != 1 {
+				t.Errorf("DeleteFinishedDeliveries() = %d, %v, want 1", n, err)
+			}
+			if n, err := repo.DeleteDispatchedEvents(ctx, at); err != nil || n != 0 {
+				t.Errorf("DeleteDispatchedEvents(creation time) = %d, %v, want 0", n, err)
+			}
+			if n, err := repo.DeleteDispatchedEvents(ctx, at.Add(time.Hour)); err != nil || n != 2 {
+				t.Errorf("DeleteDispatchedEvents() = %d, %v, want 2", n, err)
+			}
+
+			log := repo.(stream.Log)
+			left, err := log.EventsAfter(ctx, 0, 10)
+			if err != nil || len(left) != 2 || left[0].ID != events[1].ID || left[1].ID != events[3].ID {
+				t.Errorf("EventsAfter(0) = %+v, %v, want the pending and the latest event", left, err)
+			}
+			if last, err := log.LastEventSeq(ctx); err != nil || last != events[3].Seq {
+				t.Errorf("LastEventSeq() = %d, %v, want %d", last, err, events[3].Seq)
+			}
+			next, err := repo.CreateEvent(ctx, domain.Event{ID: uuid.New(), Type: domain.EventCreated, SubscriptionID: uuid.New(), Payload: []byte(`{}`), CreatedAt: at})
+			if err != nil || next.Seq != events[3].Seq+1 {
+				t.Errorf("CreateEvent() after purge = %+v, %v, want seq %d", next, err, events[3].Seq+1)
+			}
+		})
+	}
+}
+
+func TestTransactor(t *testing.T) {
+	database, err := db.NewSQLite(config.DatabaseConfig{SQLite: config.SQLiteConfig{Path: ":memory:"}}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewSQLite() error = %v", err)
+	}
+	t.Cleanup(func() { _ = database.Close() })
+
+	ctx := context.Background()
+	query := repository.WithTransactions(database)
+	subs := repository.NewSubscriptionRepository(query)
+	outbox := repository.NewWebhookRepository(query)
+	tx := repository.NewTransactor(database)
+
+	write := func(ctx context.Context, fail error) error {
+		return tx.InTx(ctx, func(ctx context.Context) error {
+			sub, err := subs.CreateSubscription(ctx, newSubscription(uuid.New(), "Netflix", month(2025, time.January), nil))
+			if err != nil {
+				return err
+			}
+			if _, err := outbox.CreateEvent(ctx, domain.Event{
+				ID: uuid.New(), Type: domain.EventCreated, SubscriptionID: sub.ID, Payload: []byte(`{}`), CreatedAt: time.Now(),
+			}); err != nil {
+				return err
+			}
+			return fail
+		})
+	}
+
+	boom := errors.New("boom")
+	if err := write(ctx, boom); !errors.Is(err, boom) {
+		t.Fatalf("InTx() error = %v, want boom", err)
+	}
+	if _, n, _ := subs.Subscriptions(ctx, domain.SubscriptionFilter{Limit: 10}); n != 0 {
+		t.Errorf("subscriptions after rollback = %d, want 0", n)
+	}
+	if events, _ := outbox.UndispatchedEvents(ctx, 10); len(events) != 0 {
+		t.Errorf("events after rollback = %d, want 0", len(events))
+	}
+
+	if err := tx.InTx(ctx, func(ctx context.Context) error { return write(ctx, nil) }); err != nil {
+		t.Fatalf("nested InTx() error = %v", err)
+	}
+	if _, n, _ := subs.Subscriptions(ctx, domain.SubscriptionFilter{Limit: 10}); n != 1 {
+		t.Errorf("subscriptions after commit = %d, want 1", n)
+	}
+	if events, _ := outbox.UndispatchedEvents(ctx, 10); len(events) != 1 {
+		t.Errorf("events after commit = %d, want 1", len(events))
+	}
+}
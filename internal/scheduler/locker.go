@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LocalLocker serializes jobs within one process. It is enough for the memory and
+// SQLite drivers, which cannot be shared between replicas.
+type LocalLocker struct {
+	mu   sync.Mutex
+	held map[string]bool
+	// last is the latest run claimed of each job.
+	last map[string]time.Time
+}
+
+func NewLocalLocker() *LocalLocker {
+	return &LocalLocker{held: make(map[string]bool), last: make(map[string]time.Time)}
+}
+
+func (l *LocalLocker) TryLock(ctx context.Context, name string, scheduledAt time.Time) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.held[name] || !scheduledAt.After(l.last[name]) {
+		return nil, false, nil
+	}
+	l.held[name] = true
+	l.last[name] = scheduledAt
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, name)
+	}, true, nil
+}
@@ -0,0 +1,235 @@
+// Package scheduler runs periodic jobs inside the service process.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// Locker grants the run of a job scheduled at scheduledAt to one process, and runs of a
+// job to one process at a time. TryLock does not wait: when another process holds the
+// lock or has already claimed the run it returns false and the run is skipped.
+type Locker interface {
+	TryLock(ctx context.Context, name string, scheduledAt time.Time) (unlock func(), acquired bool, err error)
+}
+
+// Func is the work of a job. Its context is cancelled when Stop gives up waiting.
+type Func func(ctx context.Context) error
+
+// Status describes a registered job.
+type Status struct {
+	Name      string
+	Spec      string
+	NextRunAt time.Time
+	Running   bool
+	// LastRunAt is nil until the job has run in this process.
+	LastRunAt    *time.Time
+	LastDuration time.Duration
+	LastError    string
+	Runs         int
+	Failures     int
+	// Skipped counts the runs left to another process holding the lock or the run.
+	Skipped int
+}
+
+type job struct {
+	name     string
+	spec     string
+	schedule cron.Schedule
+	run      Func
+	status   Status
+}
+
+// Scheduler runs each job at the times of its cron spec. A job never overlaps itself:
+// a run that is due while the previous one is still going is dropped.
+type Scheduler struct {
+	locker Locker
+	log    *zap.Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	jobs    []*job
+	started bool
+
+	stop       context.CancelFunc
+	cancelRuns context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+func New(locker Locker, log *zap.Logger) *Scheduler {
+	return &Scheduler{locker: locker, log: log.Named("scheduler"), now: time.Now}
+}
+
+// Add registers a job. The spec is a standard five field cron expression, optionally
+// prefixed with CRON_TZ=<zone>, or a descriptor such as @hourly or @every 15m.
+// Times without a zone are UTC.
+func (s *Scheduler) Add(name, spec string, run Func) error {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return fmt.Errorf("invalid spec %q of job %s: %w", spec, name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return errors.New("scheduler is already started")
+	}
+	for _, j := range s.jobs {
+		if j.name == name {
+			return fmt.Errorf("job %s is already registered", name)
+		}
+	}
+	s.jobs = append(s.jobs, &job{name: name, spec: spec, schedule: schedule, run: run, status: Status{Name: name, Spec: spec}})
+	return nil
+}
+
+// Start runs the jobs in the background until Stop.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	ctx, stop := context.WithCancel(context.Background())
+	runCtx, cancelRuns := context.WithCancel(context.Background())
+	s.stop, s.cancelRuns = stop, cancelRuns
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, runCtx, j)
+	}
+	s.log.Info("scheduler started", zap.Int("jobs", len(s.jobs)))
+}
+
+// Stop stops scheduling and waits for running jobs until ctx is done, then cancels them.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.started {
+		s.mu.Unlock()
+		return nil
+	}
+	s.started = false
+	s.stop()
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.cancelRuns()
+		s.log.Info("scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		s.cancelRuns()
+		<-done
+		return fmt.Errorf("jobs were cancelled: %w", ctx.Err())
+	}
+}
+
+// Statuses returns the state of the registered jobs in registration order.
+func (s *Scheduler) Statuses() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]Status, len(s.jobs))
+	for i, j := range s.jobs {
+		statuses[i] = j.status
+		if j.status.NextRunAt.IsZero() {
+			statuses[i].NextRunAt = nextRun(j.schedule, s.now())
+		}
+	}
+	return statuses
+}
+
+func (s *Scheduler) loop(ctx, runCtx context.Context, j *job) {
+	defer s.wg.Done()
+
+	for {
+		next := nextRun(j.schedule, s.now())
+		s.mu.Lock()
+		j.status.NextRunAt = next
+		s.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.runJob(runCtx, j, next)
+		}
+	}
+}
+
+// nextRun returns the next time of schedule after now. Intervals of @every are counted
+// from a fixed origin instead of from now, so replicas agree on the times of a run.
+func nextRun(schedule cron.Schedule, now time.Time) time.Time {
+	if every, ok := schedule.(cron.ConstantDelaySchedule); ok {
+		return now.Truncate(every.Delay).Add(every.Delay)
+	}
+	return schedule.Next(now)
+}
+
+// runJob runs the run of j scheduled at scheduledAt if it is granted to this process
+// and records the outcome.
+func (s *Scheduler) runJob(ctx context.Context, j *job, scheduledAt time.Time) {
+	log := s.log.With(zap.String("job", j.name))
+
+	unlock, acquired, err := s.locker.TryLock(ctx, j.name, scheduledAt)
+	if err != nil {
+		log.Error("failed to acquire job lock", zap.Error(err))
+		s.record(j, func(st *Status) {
+			st.Failures++
+			st.LastError = err.Error()
+		})
+		return
+	}
+	if !acquired {
+		log.Debug("job run is taken elsewhere, skipped")
+		s.record(j, func(st *Status) { st.Skipped++ })
+		return
+	}
+	defer unlock()
+
+	start := s.now()
+	s.record(j, func(st *Status) { st.Running = true })
+	err = j.run(ctx)
+	duration := time.Since(start)
+
+	s.record(j, func(st *Status) {
+		st.Running = false
+		st.LastRunAt = &start
+		st.LastDuration = duration
+		st.Runs++
+		st.LastError = ""
+		if err != nil {
+			st.Failures++
+			st.LastError = err.Error()
+		}
+	})
+	if err != nil {
+		log.Error("job failed", zap.Duration("duration", duration), zap.Error(err))
+		return
+	}
+	log.Info("job finished", zap.Duration("duration", duration))
+}
+
+func (s *Scheduler) record(j *job, update func(*Status)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update(&j.status)
+}
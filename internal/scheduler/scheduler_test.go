@@ -0,0 +1,167 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type lockerFunc func(ctx context.Context, name string) (func(), bool, error)
+
+func (f lockerFunc) TryLock(ctx context.Context, name string, _ time.Time) (func(), bool, error) {
+	return f(ctx, name)
+}
+
+func TestAdd(t *testing.T) {
+	s := New(NewLocalLocker(), zap.NewNop())
+	noop := func(context.Context) error { return nil }
+
+	for _, spec := range []string{"*/15 * * * *", "@every 1h", "CRON_TZ=Europe/Moscow 0 3 * * *"} {
+		if err := s.Add(spec, spec, noop); err != nil {
+			t.Errorf("Add(%q) error = %v", spec, err)
+		}
+	}
+	if err := s.Add("invalid", "every minute", noop); err == nil {
+		t.Error("Add() with an invalid spec succeeded")
+	}
+	if err := s.Add("@every 1h", "@hourly", noop); err == nil {
+		t.Error("Add() with a duplicate name succeeded")
+	}
+
+	statuses := s.Statuses()
+	if len(statuses) != 3 || statuses[0].Name != "*/15 * * * *" || statuses[0].NextRunAt.IsZero() {
+		t.Errorf("Statuses() = %+v", statuses)
+	}
+}
+
+func TestRunJob(t *testing.T) {
+	held := false
+	unlocked := 0
+	locker := lockerFunc(func(ctx context.Context, name string) (func(), bool, error) {
+		switch {
+		case name == "broken":
+			return nil, false, errors.New("connection refused")
+		case held:
+			return nil, false, nil
+		}
+		return func() { unlocked++ }, true, nil
+	})
+	s := New(locker, zap.NewNop())
+
+	runs := 0
+	fail := false
+	if err := s.Add("job", "@hourly", func(context.Context) error {
+		runs++
+		if fail {
+			return errors.New("boom")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add("broken", "@hourly", func(context.Context) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	job, broken := s.jobs[0], s.jobs[1]
+	ctx := context.Background()
+	tick := time.Date(2025, time.November, 7, 10, 0, 0, 0, time.UTC)
+
+	s.runJob(ctx, job, tick)
+	fail = true
+	s.runJob(ctx, job, tick.Add(time.Hour))
+	held = true
+	s.runJob(ctx, job, tick.Add(2*time.Hour))
+	s.runJob(ctx, broken, tick)
+
+	st := s.Statuses()
+	if runs != 2 || unlocked != 2 {
+		t.Errorf("runs = %d, unlocks = %d, want 2 and 2", runs, unlocked)
+	}
+	if st[0].Runs != 2 || st[0].Failures != 1 || st[0].Skipped != 1 || st[0].LastError != "boom" || st[0].LastRunAt == nil || st[0].Running {
+		t.Errorf("job status = %+v", st[0])
+	}
+	if st[1].Runs != 0 || st[1].Failures != 1 || st[1].LastError == "" {
+		t.Errorf("broken status = %+v", st[1])
+	}
+}
+
+func TestStop_CancelsJobsAfterDeadline(t *testing.T) {
+	s := New(NewLocalLocker(), zap.NewNop())
+	started := make(chan struct{})
+	var once sync.Once
+	if err := s.Add("slow", "@every 10ms", func(ctx context.Context) error {
+		once.Do(func() { close(started) })
+		<-ctx.Done()
+		return ctx.Err()
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Start()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := s.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Stop() error = %v, want DeadlineExceeded", err)
+	}
+	if st := s.Statuses()[0]; st.Running || st.Runs == 0 || st.Failures == 0 {
+		t.Errorf("status after Stop() = %+v", st)
+	}
+	if err := s.Stop(context.Background()); err != nil {
+		t.Errorf("second Stop() error = %v", err)
+	}
+}
+
+func TestLocalLocker(t *testing.T) {
+	l := NewLocalLocker()
+	ctx := context.Background()
+	tick := time.Date(2025, time.November, 7, 10, 0, 0, 0, time.UTC)
+
+	unlock, ok, err := l.TryLock(ctx, "job", tick)
+	if err != nil || !ok {
+		t.Fatalf("TryLock() = %v, %v", ok, err)
+	}
+	if _, ok, _ := l.TryLock(ctx, "job", tick.Add(time.Minute)); ok {
+		t.Error("TryLock() acquired a held lock")
+	}
+	if _, ok, _ := l.TryLock(ctx, "other", tick); !ok {
+		t.Error("TryLock() of another name failed")
+	}
+	unlock()
+	if _, ok, _ := l.TryLock(ctx, "job", tick); ok {
+		t.Error("TryLock() claimed a run twice")
+	}
+	if _, ok, _ := l.TryLock(ctx, "job", tick.Add(time.Minute)); !ok {
+		t.Error("TryLock() of the next run after unlock failed")
+	}
+}
+
+func TestNextRun(t *testing.T) {
+	every, err := cron.ParseStandard("@every 15m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hourly, err := cron.ParseStandard("0 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Replicas started at different times agree on the next run.
+	for _, now := range []time.Time{
+		time.Date(2025, time.November, 7, 10, 1, 2, 0, time.UTC),
+		time.Date(2025, time.November, 7, 10, 14, 59, 0, time.UTC),
+	} {
+		if got, want := nextRun(every, now), time.Date(2025, time.November, 7, 10, 15, 0, 0, time.UTC); !got.Equal(want) {
+			t.Errorf("nextRun(@every 15m, %s) = %s, want %s", now, got, want)
+		}
+		if got, want := nextRun(hourly, now), time.Date(2025, time.November, 7, 11, 0, 0, 0, time.UTC); !got.Equal(want) {
+			t.Errorf("nextRun(hourly, %s) = %s, want %s", now, got, want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"net"
+	"tz/internal/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// GRPCServer serves the gRPC API on cfg.GRPCPort.
+type GRPCServer struct {
+	server *grpc.Server
+	addr   string
+}
+
+// NewGRPC builds a gRPC server with reflection. register adds the services to it.
+func NewGRPC(cfg config.ServerCfg, register func(*grpc.Server), opts ...grpc.ServerOption) *GRPCServer {
+	opts = append([]grpc.ServerOption{
+		grpc.ConnectionTimeout(cfg.ReadTimeout),
+	}, opts...)
+	s := grpc.NewServer(opts...)
+	register(s)
+	reflection.Register(s)
+
+	return &GRPCServer{server: s, addr: ":" + cfg.GRPCPort}
+}
+
+func (s *GRPCServer) Run() error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	return s.server.Serve(lis)
+}
+
+// Shutdown waits for the running calls to finish, and cancels them when ctx is done.
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
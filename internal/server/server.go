@@ -23,6 +23,19 @@ func New(cfg config.ServerCfg, handler http.Handler) *Server {
 	}
 }
 
+// NewAdmin builds a server for operational endpoints on cfg.AdminPort.
+func NewAdmin(cfg config.ServerCfg, handler http.Handler) *Server {
+	return &Server{
+		server: &http.Server{
+			Addr:         ":" + cfg.AdminPort,
+			Handler:      handler,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+			IdleTimeout:  cfg.IdleTimeout,
+		},
+	}
+}
+
 func (s *Server) Run() error {
 	return s.server.ListenAndServe()
 }
@@ -0,0 +1,214 @@
+package service
+
+//go:generate go tool mockgen -source=api_key.go -destination=mocks/api_key.go -package=mocks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	apiKeyPrefix    = "tzk_"
+	apiKeySecretLen = 32
+	apiKeyPrefixLen = len(apiKeyPrefix) + 8
+
+	// apiKeyTouchInterval limits how often the last use of a key is written,
+	// so authenticated requests do not each cost a database write.
+	apiKeyTouchInterval = time.Minute
+)
+
+type APIKeyRepositoryI interface {
+	CreateAPIKey(ctx context.Context, key domain.APIKey) (domain.APIKey, error)
+	APIKeyByHash(ctx context.Context, hash string) (domain.APIKey, error)
+	APIKeys(ctx context.Context) ([]domain.APIKey, error)
+	TouchAPIKey(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+	RevokeAPIKey(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+}
+
+type APIKeyService struct {
+	repo APIKeyRepositoryI
+	log  *zap.Logger
+}
+
+func NewAPIKeyService(repo APIKeyRepositoryI, log *zap.Logger) *APIKeyService {
+	return &APIKeyService{repo: repo, log: log}
+}
+
+func (s *APIKeyService) IssueAPIKey(ctx context.Context, req dto.CreateAPIKeyRequest) (dto.IssuedAPIKeyOutput, error) {
+	ctx, span := startSpan(ctx, "APIKeyService.IssueAPIKey")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("name", req.Name))
+
+	scopes := make([]domain.Scope, 0, len(req.Scopes))
+	for _, raw := range req.Scopes {
+		scope, err := domain.ParseScope(raw)
+		if err != nil {
+			log.Warn("Invalid scope", zap.String("scope", raw))
+			return dto.IssuedAPIKeyOutput{}, fmt.Errorf("%w: %s", err, raw)
+		}
+		scopes = append(scopes, scope)
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		ea, err := time.Parse(time.RFC3339, *req.ExpiresAt)
+		if err != nil {
+			log.Warn("Invalid expires_at format", zap.String("expires_at", *req.ExpiresAt))
+			return dto.IssuedAPIKeyOutput{}, fmt.Errorf("%w: %s", domain.ErrInvalidDate, *req.ExpiresAt)
+		}
+		if !ea.After(time.Now()) {
+			log.Warn("expires_at in the past", zap.String("expires_at", *req.ExpiresAt))
+			return dto.IssuedAPIKeyOutput{}, fmt.Errorf("%w: expires_at must be in the future", domain.ErrInvalidDate)
+		}
+		expiresAt = &ea
+	}
+
+	rawKey, err := generateAPIKey()
+	if err != nil {
+		log.Error("Failed to generate api key", zap.Error(err))
+		return dto.IssuedAPIKeyOutput{}, fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	key, err := s.repo.CreateAPIKey(ctx, domain.APIKey{
+		ID:        uuid.New(),
+		Name:      req.Name,
+		Prefix:    rawKey[:apiKeyPrefixLen],
+		KeyHash:   hashAPIKey(rawKey),
+		Scopes:    scopes,
+		Roles:     req.Roles,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		log.Error("Failed to create api key", zap.Error(err))
+		return dto.IssuedAPIKeyOutput{}, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	log.Info("API key issued", zap.String("api_key_id", key.ID.String()), zap.String("prefix", key.Prefix))
+	return dto.IssuedAPIKeyOutput{APIKeyOutput: apiKeyToDto(key), Key: rawKey}, nil
+}
+
+func (s *APIKeyService) APIKeys(ctx context.Context) ([]dto.APIKeyOutput, error) {
+	ctx, span := startSpan(ctx, "APIKeyService.APIKeys")
+	defer span.End()
+
+	log := s.loggerWith(ctx)
+
+	keys, err := s.repo.APIKeys(ctx)
+	if err != nil {
+		log.Error("Failed to fetch api keys", zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch api keys: %w", err)
+	}
+
+	output := make([]dto.APIKeyOutput, len(keys))
+	for i, key := range keys {
+		output[i] = apiKeyToDto(key)
+	}
+	return output, nil
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "APIKeyService.RevokeAPIKey")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("api_key_id", id.String()))
+
+	if err := s.repo.RevokeAPIKey(ctx, id, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			log.Warn("API key not found")
+			return domain.ErrAPIKeyNotFound
+		}
+		log.Error("Failed to revoke api key", zap.Error(err))
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	log.Info("API key revoked")
+	return nil
+}
+
+// Authenticate resolves a plain API key to its stored record and records its usage
+// at most once per apiKeyTouchInterval.
+// Unknown, revoked and expired keys are reported as domain.ErrUnauthorized.
+func (s *APIKeyService) Authenticate(ctx context.Context, rawKey string) (domain.APIKey, error) {
+	ctx, span := startSpan(ctx, "APIKeyService.Authenticate")
+	defer span.End()
+
+	log := s.loggerWith(ctx)
+
+	key, err := s.repo.APIKeyByHash(ctx, hashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return domain.APIKey{}, domain.ErrUnauthorized
+		}
+		log.Error("Failed to look up api key", zap.Error(err))
+		return domain.APIKey{}, fmt.Errorf("failed to look up api key: %w", err)
+	}
+
+	now := time.Now()
+	if !key.Active(now) {
+		log.Warn("Inactive api key used", zap.String("api_key_id", key.ID.String()))
+		return domain.APIKey{}, domain.ErrUnauthorized
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.repo.TouchAPIKey(ctx, key.ID, now); err != nil {
+			log.Warn("Failed to record api key usage", zap.String("api_key_id", key.ID.String()), zap.Error(err))
+		}
+		key.LastUsedAt = &now
+	}
+
+	return key, nil
+}
+
+func (s *APIKeyService) loggerWith(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	return s.log.With(append(contextFields(ctx), fields...)...)
+}
+
+func generateAPIKey() (string, error) {
+	secret := make([]byte, apiKeySecretLen)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(secret), nil
+}
+
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func apiKeyToDto(k domain.APIKey) dto.APIKeyOutput {
+	scopes := make([]string, len(k.Scopes))
+	for i, s := range k.Scopes {
+		scopes[i] = string(s)
+	}
+	return dto.APIKeyOutput{
+		ID:         k.ID.String(),
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		Roles:      k.Roles,
+		ExpiresAt:  formatOptionalTime(k.ExpiresAt),
+		LastUsedAt: formatOptionalTime(k.LastUsedAt),
+		RevokedAt:  formatOptionalTime(k.RevokedAt),
+		CreatedAt:  k.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestAuthenticate_TouchThrottle(t *testing.T) {
+	recent := time.Now().Add(-10 * time.Second)
+	stale := time.Now().Add(-2 * apiKeyTouchInterval)
+
+	tests := []struct {
+		name      string
+		lastUsed  *time.Time
+		wantTouch bool
+	}{
+		{name: "never used", wantTouch: true},
+		{name: "used long ago", lastUsed: &stale, wantTouch: true},
+		{name: "used recently", lastUsed: &recent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockAPIKeyRepositoryI(gomock.NewController(t))
+			svc := NewAPIKeyService(repo, zap.NewNop())
+			key := domain.APIKey{ID: uuid.New(), LastUsedAt: tt.lastUsed}
+
+			repo.EXPECT().APIKeyByHash(gomock.Any(), hashAPIKey("tzk_secret")).Return(key, nil)
+			if tt.wantTouch {
+				repo.EXPECT().TouchAPIKey(gomock.Any(), key.ID, gomock.Any()).Return(nil)
+			}
+
+			got, err := svc.Authenticate(context.Background(), "tzk_secret")
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if got.LastUsedAt == nil || tt.lastUsed != nil && !tt.wantTouch && !got.LastUsedAt.Equal(*tt.lastUsed) {
+				t.Errorf("LastUsedAt = %v, want %v kept", got.LastUsedAt, tt.lastUsed)
+			}
+		})
+	}
+}
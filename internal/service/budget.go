@@ -0,0 +1,370 @@
+package service
+
+//go:generate go tool mockgen -source=budget.go -destination=mocks/budget.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"tz/internal/contextkey"
+	"tz/internal/domain"
+	"tz/internal/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type BudgetRepositoryI interface {
+	CreateBudget(ctx context.Context, b domain.Budget) (domain.Budget, error)
+	Budget(ctx context.Context, id uuid.UUID) (domain.Budget, error)
+	Budgets(ctx context.Context, userID *uuid.UUID) ([]domain.Budget, error)
+	UpdateBudget(ctx context.Context, id uuid.UUID, upd domain.UpdateBudget) (domain.Budget, error)
+	DeleteBudget(ctx context.Context, id uuid.UUID) error
+	CreateBudgetAlert(ctx context.Context, alert domain.BudgetAlert) (domain.BudgetAlert, bool, error)
+	BudgetAlerts(ctx context.Context, budgetID uuid.UUID) ([]domain.BudgetAlert, error)
+}
+
+// SpendI computes the spend a budget is compared with. SubscriptionService implements it.
+type SpendI interface {
+	Spend(ctx context.Context, userID uuid.UUID, serviceNames []string, period domain.BudgetPeriod) (domain.Spend, error)
+}
+
+type BudgetService struct {
+	repo   BudgetRepositoryI
+	spend  SpendI
+	outbox OutboxI
+	tx     TransactorI
+	log    *zap.Logger
+}
+
+// NewBudgetService records each new alert with a budget.alert event in outbox, in one
+// transaction of tx, so webhooks deliver it. A nil outbox records alerts only.
+func NewBudgetService(repo BudgetRepositoryI, spend SpendI, outbox OutboxI, tx TransactorI, log *zap.Logger) *BudgetService {
+	return &BudgetService{repo: repo, spend: spend, outbox: outbox, tx: tx, log: log}
+}
+
+func (s *BudgetService) CreateBudget(ctx context.Context, req dto.CreateBudgetRequest) (dto.BudgetOutput, error) {
+	ctx, span := startSpan(ctx, "BudgetService.CreateBudget")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("user_id", req.UserID))
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		log.Warn("Invalid user_id format", zap.String("user_id", req.UserID))
+		return dto.BudgetOutput{}, fmt.Errorf("%w: invalid user_id %q", domain.ErrInvalidBudget, req.UserID)
+	}
+
+	period := domain.BudgetPeriodMonth
+	if req.Period != "" {
+		if period, err = domain.ParseBudgetPeriod(req.Period); err != nil {
+			log.Warn("Invalid budget period", zap.String("period", req.Period))
+			return dto.BudgetOutput{}, err
+		}
+	}
+	if req.Amount <= 0 {
+		return dto.BudgetOutput{}, fmt.Errorf("%w: amount must be positive", domain.ErrInvalidBudget)
+	}
+
+	budget, err := s.repo.CreateBudget(ctx, domain.Budget{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Period:       period,
+		Amount:       req.Amount,
+		ServiceNames: serviceNames(req.ServiceNames),
+	})
+	if err != nil {
+		log.Error("Failed to create budget", zap.Error(err))
+		return dto.BudgetOutput{}, fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	log.Info("Budget created", zap.String("budget_id", budget.ID.String()), zap.Int("amount", budget.Amount))
+	return budgetToDto(budget), nil
+}
+
+func (s *BudgetService) Budget(ctx context.Context, id uuid.UUID) (dto.BudgetOutput, error) {
+	ctx, span := startSpan(ctx, "BudgetService.Budget")
+	defer span.End()
+
+	budget, err := s.repo.Budget(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrBudgetNotFound) {
+			return dto.BudgetOutput{}, err
+		}
+		s.loggerWith(ctx, zap.String("budget_id", id.String())).Error("Failed to get budget", zap.Error(err))
+		return dto.BudgetOutput{}, fmt.Errorf("failed to get budget: %w", err)
+	}
+
+	return budgetToDto(budget), nil
+}
+
+func (s *BudgetService) Budgets(ctx context.Context, filter dto.BudgetFilter) ([]dto.BudgetOutput, error) {
+	ctx, span := startSpan(ctx, "BudgetService.Budgets")
+	defer span.End()
+
+	log := s.loggerWith(ctx)
+
+	var userID *uuid.UUID
+	if filter.UserID != nil {
+		uid, err := uuid.Parse(*filter.UserID)
+		if err != nil {
+			log.Warn("Invalid user_id in filter", zap.String("user_id", *filter.UserID))
+			return nil, fmt.Errorf("%w: invalid user_id %q", domain.ErrInvalidBudget, *filter.UserID)
+		}
+		userID = &uid
+	}
+
+	budgets, err := s.repo.Budgets(ctx, userID)
+	if err != nil {
+		log.Error("Failed to get budgets", zap.Error(err))
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	out := make([]dto.BudgetOutput, len(budgets))
+	for i, b := range budgets {
+		out[i] = budgetToDto(b)
+	}
+	return out, nil
+}
+
+func (s *BudgetService) UpdateBudget(ctx context.Context, id uuid.UUID, req dto.UpdateBudgetRequest) (dto.BudgetOutput, error) {
+	ctx, span := startSpan(ctx, "BudgetService.UpdateBudget")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("budget_id", id.String()))
+
+	upd := domain.UpdateBudget{Amount: req.Amount}
+	if req.Period != nil {
+		period, err := domain.ParseBudgetPeriod(*req.Period)
+		if err != nil {
+			log.Warn("Invalid budget period", zap.String("period", *req.Period))
+			return dto.BudgetOutput{}, err
+		}
+		upd.Period = &period
+	}
+	if req.Amount != nil && *req.Amount <= 0 {
+		return dto.BudgetOutput{}, fmt.Errorf("%w: amount must be positive", domain.ErrInvalidBudget)
+	}
+	if req.ServiceNames != nil {
+		names := serviceNames(*req.ServiceNames)
+		upd.ServiceNames = &names
+	}
+
+	budget, err := s.repo.UpdateBudget(ctx, id, upd)
+	if err != nil {
+		if errors.Is(err, domain.ErrBudgetNotFound) {
+			log.Warn("Budget not found")
+			return dto.BudgetOutput{}, err
+		}
+		log.Error("Failed to update budget", zap.Error(err))
+		return dto.BudgetOutput{}, fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	log.Info("Budget updated")
+	return budgetToDto(budget), nil
+}
+
+func (s *BudgetService) DeleteBudget(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "BudgetService.DeleteBudget")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("budget_id", id.String()))
+
+	if err := s.repo.DeleteBudget(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrBudgetNotFound) {
+			log.Warn("Budget not found")
+			return err
+		}
+		log.Error("Failed to delete budget", zap.Error(err))
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	log.Info("Budget deleted")
+	return nil
+}
+
+func (s *BudgetService) BudgetAlerts(ctx context.Context, budgetID uuid.UUID) ([]dto.BudgetAlertOutput, error) {
+	ctx, span := startSpan(ctx, "BudgetService.BudgetAlerts")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("budget_id", budgetID.String()))
+
+	if _, err := s.repo.Budget(ctx, budgetID); err != nil {
+		if errors.Is(err, domain.ErrBudgetNotFound) {
+			return nil, err
+		}
+		log.Error("Failed to get budget", zap.Error(err))
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	}
+
+	alerts, err := s.repo.BudgetAlerts(ctx, budgetID)
+	if err != nil {
+		log.Error("Failed to get budget alerts", zap.Error(err))
+		return nil, fmt.Errorf("failed to get budget alerts: %w", err)
+	}
+
+	format := contextkeys.DateFormat(ctx)
+	out := make([]dto.BudgetAlertOutput, len(alerts))
+	for i, a := range alerts {
+		out[i] = budgetAlertToDto(a, format)
+	}
+	return out, nil
+}
+
+// EvaluateBudgets compares the actual and forecast spend of every budget with its
+// thresholds and records an alert for each newly crossed one. Each alert is written
+// with a budget.alert outbox event in the same transaction; the webhook deliveries of
+// the event are retried and end up dead like those of any other event. It returns the
+// number of new alerts and the errors of the budgets that could not be evaluated.
+func (s *BudgetService) EvaluateBudgets(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "BudgetService.EvaluateBudgets")
+	defer span.End()
+
+	log := s.loggerWith(ctx)
+
+	budgets, err := s.repo.Budgets(ctx, nil)
+	if err != nil {
+		log.Error("Failed to get budgets for evaluation", zap.Error(err))
+		return 0, fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	var (
+		created int
+		errs    []error
+	)
+	for _, b := range budgets {
+		n, err := s.evaluateBudget(ctx, b)
+		created += n
+		if err != nil {
+			log.Error("Failed to evaluate budget", zap.String("budget_id", b.ID.String()), zap.Error(err))
+			errs = append(errs, fmt.Errorf("budget %s: %w", b.ID, err))
+		}
+	}
+
+	log.Info("Budgets evaluated", zap.Int("budgets", len(budgets)), zap.Int("alerts", created))
+	return created, errors.Join(errs...)
+}
+
+func (s *BudgetService) evaluateBudget(ctx context.Context, b domain.Budget) (int, error) {
+	spend, err := s.spend.Spend(ctx, b.UserID, b.ServiceNames, b.Period)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, kind := range []domain.AlertKind{domain.AlertKindActual, domain.AlertKindForecast} {
+		value := spend.Actual
+		if kind == domain.AlertKindForecast {
+			value = spend.Forecast
+		}
+		for _, threshold := range domain.BudgetThresholds {
+			if value*100 < threshold*b.Amount {
+				continue
+			}
+			var isNew bool
+			err := inTx(ctx, s.tx, func(ctx context.Context) error {
+				alert, ok, err := s.repo.CreateBudgetAlert(ctx, domain.BudgetAlert{
+					ID:          uuid.New(),
+					BudgetID:    b.ID,
+					UserID:      b.UserID,
+					PeriodStart: spend.PeriodStart,
+					Threshold:   threshold,
+					Kind:        kind,
+					Spend:       value,
+					Amount:      b.Amount,
+				})
+				if err != nil || !ok {
+					return err
+				}
+				isNew = true
+				return s.emitAlert(ctx, alert)
+			})
+			if err != nil {
+				return created, fmt.Errorf("failed to create budget alert: %w", err)
+			}
+			if !isNew {
+				continue
+			}
+			created++
+
+			log := s.loggerWith(ctx, zap.String("budget_id", b.ID.String()), zap.String("kind", string(kind)), zap.Int("threshold", threshold))
+			log.Info("Budget threshold crossed", zap.Int("spend", value), zap.Int("amount", b.Amount))
+		}
+	}
+	return created, nil
+}
+
+// emitAlert writes a budget.alert event about alert to the outbox. It does nothing
+// without an outbox.
+func (s *BudgetService) emitAlert(ctx context.Context, alert domain.BudgetAlert) error {
+	if s.outbox == nil {
+		return nil
+	}
+
+	id := uuid.New()
+	now := time.Now().UTC()
+	payload, err := json.Marshal(dto.BudgetAlertEvent{
+		ID:         id.String(),
+		Type:       string(domain.EventBudgetAlert),
+		OccurredAt: now.Format(time.RFC3339),
+		Alert:      budgetAlertToDto(alert, domain.DateFormatDay),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", domain.EventBudgetAlert, err)
+	}
+
+	_, err = s.outbox.CreateEvent(ctx, domain.Event{
+		ID:        id,
+		Type:      domain.EventBudgetAlert,
+		Payload:   payload,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record %s event: %w", domain.EventBudgetAlert, err)
+	}
+	return nil
+}
+
+func (s *BudgetService) loggerWith(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	return s.log.With(append(contextFields(ctx), fields...)...)
+}
+
+// serviceNames drops empty and repeated names.
+func serviceNames(names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if name != "" && !slices.Contains(out, name) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func budgetToDto(b domain.Budget) dto.BudgetOutput {
+	return dto.BudgetOutput{
+		ID:           b.ID.String(),
+		UserID:       b.UserID.String(),
+		Period:       string(b.Period),
+		Amount:       b.Amount,
+		ServiceNames: b.ServiceNames,
+		CreatedAt:    b.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    b.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func budgetAlertToDto(a domain.BudgetAlert, format domain.DateFormat) dto.BudgetAlertOutput {
+	return dto.BudgetAlertOutput{
+		ID:          a.ID.String(),
+		BudgetID:    a.BudgetID.String(),
+		UserID:      a.UserID.String(),
+		PeriodStart: formatDate(a.PeriodStart, format),
+		Threshold:   a.Threshold,
+		Kind:        string(a.Kind),
+		Spend:       a.Spend,
+		Amount:      a.Amount,
+		CreatedAt:   a.CreatedAt.Format(time.RFC3339),
+	}
+}
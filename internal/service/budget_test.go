@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestSpend(t *testing.T) {
+	// Half of November 2025 has passed.
+	now := time.Date(2025, time.November, 16, 0, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	netflix := domain.Subscription{ID: uuid.New(), ServiceName: "Netflix", Price: 300, StartDate: month(2025, time.October), BillingPeriod: 1}
+	spotify := domain.Subscription{ID: uuid.New(), ServiceName: "Spotify", Price: 600, StartDate: month(2025, time.October), BillingPeriod: 1}
+	raise := domain.PriceChange{SubscriptionID: netflix.ID, Price: 400, EffectiveFrom: month(2025, time.November)}
+	day := CostPolicy{Proration: ProrationDay}
+
+	tests := []struct {
+		name      string
+		policy    CostPolicy
+		services  []string
+		changes   []domain.PriceChange
+		period    domain.BudgetPeriod
+		wantStart time.Time
+		want      [2]int
+	}{
+		{name: "month", policy: day, period: domain.BudgetPeriodMonth, wantStart: month(2025, time.November), want: [2]int{450, 900}},
+		{name: "month of one service", policy: day, services: []string{"Netflix"}, period: domain.BudgetPeriodMonth, wantStart: month(2025, time.November), want: [2]int{150, 300}},
+		{name: "year", policy: day, services: []string{"Netflix"}, period: domain.BudgetPeriodYear, wantStart: month(2025, time.January), want: [2]int{450, 900}},
+		{name: "price change", policy: day, services: []string{"Netflix"}, changes: []domain.PriceChange{raise}, period: domain.BudgetPeriodMonth, wantStart: month(2025, time.November), want: [2]int{200, 400}},
+		{name: "whole months", services: []string{"Netflix"}, period: domain.BudgetPeriodMonth, wantStart: month(2025, time.November), want: [2]int{0, 300}},
+		{name: "whole months with end month", policy: CostPolicy{EndMonthInclusive: true}, services: []string{"Netflix"}, period: domain.BudgetPeriodMonth, wantStart: month(2025, time.November), want: [2]int{300, 300}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockSubscriptionRepositoryI(gomock.NewController(t))
+			svc := NewSubscriptionService(repo, nil, nil, tt.policy, zap.NewNop(), WithClock(func() time.Time { return now }))
+			repo.EXPECT().SubscriptionsCost(gomock.Any(), domain.CostRequest{UserID: &userID}).Return([]domain.Subscription{netflix, spotify}, nil)
+			repo.EXPECT().ScheduledPriceChanges(gomock.Any(), domain.CostRequest{UserID: &userID}).Return(tt.changes, nil)
+
+			got, err := svc.Spend(context.Background(), userID, tt.services, tt.period)
+			if err != nil {
+				t.Fatalf("Spend() error = %v", err)
+			}
+			if !got.PeriodStart.Equal(tt.wantStart) || got.Actual != tt.want[0] || got.Forecast != tt.want[1] {
+				t.Errorf("Spend() = %+v, want start %s, actual %d, forecast %d", got, tt.wantStart, tt.want[0], tt.want[1])
+			}
+		})
+	}
+}
+
+func TestSpend_ResolvesServiceNames(t *testing.T) {
+	now := time.Date(2025, time.November, 16, 0, 0, 0, 0, time.UTC)
+	userID, netflixID := uuid.New(), uuid.New()
+	netflix := domain.Subscription{ID: uuid.New(), ServiceID: &netflixID, ServiceName: "Netflix", Price: 300, StartDate: month(2025, time.October), BillingPeriod: 1}
+	kion := domain.Subscription{ID: uuid.New(), ServiceName: "Kion", Price: 100, StartDate: month(2025, time.October), BillingPeriod: 1}
+	spotify := domain.Subscription{ID: uuid.New(), ServiceName: "Spotify", Price: 600, StartDate: month(2025, time.October), BillingPeriod: 1}
+
+	ctrl := gomock.NewController(t)
+	repo, catalog := mocks.NewMockSubscriptionRepositoryI(ctrl), mocks.NewMockCatalogRepositoryI(ctrl)
+	svc := NewSubscriptionService(repo, nil, nil, CostPolicy{}, zap.NewNop(), WithCatalog(catalog), WithClock(func() time.Time { return now }))
+	catalog.EXPECT().ResolveService(gomock.Any(), "нетфликс").Return(domain.Service{ID: netflixID, Name: "Netflix"}, nil)
+	catalog.EXPECT().ResolveService(gomock.Any(), "Kion").Return(domain.Service{}, domain.ErrServiceNotFound)
+	repo.EXPECT().SubscriptionsCost(gomock.Any(), gomock.Any()).Return([]domain.Subscription{netflix, kion, spotify}, nil)
+	repo.EXPECT().ScheduledPriceChanges(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	got, err := svc.Spend(context.Background(), userID, []string{"нетфликс", "Kion"}, domain.BudgetPeriodMonth)
+	if err != nil || got.Forecast != 400 {
+		t.Errorf("Spend() = %+v, %v, want forecast 400", got, err)
+	}
+}
+
+func TestEvaluateBudgets(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockBudgetRepositoryI(ctrl)
+	spend := mocks.NewMockSpendI(ctrl)
+	outbox := mocks.NewMockOutboxI(ctrl)
+	svc := NewBudgetService(repo, spend, outbox, nil, zap.NewNop())
+
+	period := month(2025, time.November)
+	within := domain.Budget{ID: uuid.New(), UserID: uuid.New(), Period: domain.BudgetPeriodMonth, Amount: 1000}
+	over := domain.Budget{ID: uuid.New(), UserID: uuid.New(), Period: domain.BudgetPeriodMonth, Amount: 1000, ServiceNames: []string{"Netflix"}}
+	failing := domain.Budget{ID: uuid.New(), UserID: uuid.New(), Period: domain.BudgetPeriodYear, Amount: 1000}
+
+	repo.EXPECT().Budgets(gomock.Any(), nil).Return([]domain.Budget{within, over, failing}, nil)
+	spend.EXPECT().Spend(gomock.Any(), within.UserID, within.ServiceNames, within.Period).
+		Return(domain.Spend{PeriodStart: period, Actual: 300, Forecast: 799}, nil)
+	spend.EXPECT().Spend(gomock.Any(), over.UserID, over.ServiceNames, over.Period).
+		Return(domain.Spend{PeriodStart: period, Actual: 850, Forecast: 1200}, nil)
+	spend.EXPECT().Spend(gomock.Any(), failing.UserID, failing.ServiceNames, failing.Period).
+		Return(domain.Spend{}, errors.New("connection refused"))
+
+	// The forecast 80% alert was raised by an earlier evaluation.
+	recorded := map[domain.AlertKind][]int{}
+	repo.EXPECT().CreateBudgetAlert(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ context.Context, a domain.BudgetAlert) (domain.BudgetAlert, bool, error) {
+			if a.BudgetID != over.ID || a.UserID != over.UserID || !a.PeriodStart.Equal(period) || a.Amount != 1000 {
+				t.Errorf("CreateBudgetAlert(%+v)", a)
+			}
+			recorded[a.Kind] = append(recorded[a.Kind], a.Threshold)
+			return a, !(a.Kind == domain.AlertKindForecast && a.Threshold == 80), nil
+		})
+	outbox.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, e domain.Event) (domain.Event, error) {
+			var event dto.BudgetAlertEvent
+			if err := json.Unmarshal(e.Payload, &event); err != nil || e.Type != domain.EventBudgetAlert || event.Type != "budget.alert" {
+				t.Fatalf("CreateEvent(%s, %s) = %v", e.Type, e.Payload, err)
+			}
+			a := event.Alert
+			if a.BudgetID != over.ID.String() || a.PeriodStart != "2025-11-01" {
+				t.Errorf("alert event %+v", a)
+			}
+			if a.Kind == "actual" && (a.Threshold != 80 || a.Spend != 850) {
+				t.Errorf("alert event %+v", a)
+			}
+			if a.Kind == "forecast" && (a.Threshold != 100 || a.Spend != 1200) {
+				t.Errorf("alert event %+v", a)
+			}
+			return e, nil
+		})
+
+	created, err := svc.EvaluateBudgets(ctx)
+	if created != 2 {
+		t.Errorf("EvaluateBudgets() created = %d, want 2", created)
+	}
+	if err == nil {
+		t.Error("EvaluateBudgets() error = nil, want the failing budget")
+	}
+	if len(recorded[domain.AlertKindActual]) != 1 || len(recorded[domain.AlertKindForecast]) != 2 {
+		t.Errorf("recorded alerts = %v", recorded)
+	}
+}
+
+func TestCreateBudget(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("defaults", func(t *testing.T) {
+		repo := mocks.NewMockBudgetRepositoryI(gomock.NewController(t))
+		svc := NewBudgetService(repo, nil, nil, nil, zap.NewNop())
+		repo.EXPECT().CreateBudget(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, b domain.Budget) (domain.Budget, error) {
+				if b.UserID != userID || b.Period != domain.BudgetPeriodMonth || len(b.ServiceNames) != 1 {
+					t.Errorf("CreateBudget(%+v)", b)
+				}
+				return b, nil
+			})
+
+		out, err := svc.CreateBudget(context.Background(), dto.CreateBudgetRequest{
+			UserID:       userID.String(),
+			Amount:       2000,
+			ServiceNames: []string{"Netflix", "", "Netflix"},
+		})
+		if err != nil || out.Period != "month" || len(out.ServiceNames) != 1 {
+			t.Errorf("CreateBudget() = %+v, %v", out, err)
+		}
+	})
+
+	for name, req := range map[string]dto.CreateBudgetRequest{
+		"invalid user":   {UserID: "42", Amount: 2000},
+		"invalid period": {UserID: userID.String(), Period: "week", Amount: 2000},
+		"zero amount":    {UserID: userID.String()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc := NewBudgetService(mocks.NewMockBudgetRepositoryI(gomock.NewController(t)), nil, nil, nil, zap.NewNop())
+			if _, err := svc.CreateBudget(context.Background(), req); !errors.Is(err, domain.ErrInvalidBudget) {
+				t.Errorf("CreateBudget() error = %v, want ErrInvalidBudget", err)
+			}
+		})
+	}
+}
+
+func TestBudgetAlerts_NotFound(t *testing.T) {
+	repo := mocks.NewMockBudgetRepositoryI(gomock.NewController(t))
+	svc := NewBudgetService(repo, nil, nil, nil, zap.NewNop())
+	id := uuid.New()
+	repo.EXPECT().Budget(gomock.Any(), id).Return(domain.Budget{}, domain.ErrBudgetNotFound)
+
+	if _, err := svc.BudgetAlerts(context.Background(), id); !errors.Is(err, domain.ErrBudgetNotFound) {
+		t.Errorf("BudgetAlerts() error = %v, want ErrBudgetNotFound", err)
+	}
+}
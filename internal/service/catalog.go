@@ -0,0 +1,346 @@
+package service
+
+//go:generate go tool mockgen -source=catalog.go -destination=mocks/catalog.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type CatalogRepositoryI interface {
+	CreateService(ctx context.Context, svc domain.Service) (domain.Service, error)
+	Service(ctx context.Context, id uuid.UUID) (domain.Service, error)
+	Services(ctx context.Context, filter domain.ServiceFilter) ([]domain.Service, error)
+	UpdateService(ctx context.Context, id uuid.UUID, upd domain.UpdateService) (domain.Service, error)
+	DeleteService(ctx context.Context, id uuid.UUID) error
+	// ResolveService finds the service named name by its name or one of its aliases.
+	ResolveService(ctx context.Context, name string) (domain.Service, error)
+}
+
+// WithCatalog resolves the service names of subscriptions through the catalog.
+func WithCatalog(catalog CatalogRepositoryI) Option {
+	return func(s *SubscriptionService) {
+		s.catalog = catalog
+	}
+}
+
+// resolveService returns the catalog service of a subscription, picked by serviceID or
+// resolved from name. A name the catalog does not know gives a service without ID.
+func (s *SubscriptionService) resolveService(ctx context.Context, serviceID *string, name string) (domain.Service, error) {
+	name = strings.TrimSpace(name)
+	if serviceID == nil {
+		if name == "" {
+			return domain.Service{}, fmt.Errorf("%w: service_name is required", domain.ErrInvalidService)
+		}
+		if s.catalog == nil {
+			return domain.Service{Name: name}, nil
+		}
+		svc, err := s.catalog.ResolveService(ctx, name)
+		if errors.Is(err, domain.ErrServiceNotFound) {
+			return domain.Service{Name: name}, nil
+		}
+		return svc, err
+	}
+
+	id, err := uuid.Parse(*serviceID)
+	if err != nil {
+		return domain.Service{}, fmt.Errorf("%w: invalid service_id %q", domain.ErrInvalidService, *serviceID)
+	}
+	if s.catalog == nil {
+		return domain.Service{}, fmt.Errorf("%w: the services catalog is disabled", domain.ErrInvalidService)
+	}
+	svc, err := s.catalog.Service(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrServiceNotFound) {
+			return domain.Service{}, fmt.Errorf("%w: service %s not found", domain.ErrInvalidService, id)
+		}
+		return domain.Service{}, err
+	}
+	if name != "" && !svc.Named(name) {
+		return domain.Service{}, fmt.Errorf("%w: service_name %q is not a name of service %s", domain.ErrInvalidService, name, id)
+	}
+	return svc, nil
+}
+
+// serviceFilter resolves a service_name filter through the catalog, see resolveServiceFilter.
+func (s *SubscriptionService) serviceFilter(ctx context.Context, name *string) (*uuid.UUID, *string, error) {
+	return resolveServiceFilter(ctx, s.catalog, name)
+}
+
+// resolveServiceFilter resolves a service_name filter through catalog. A name the
+// catalog knows selects the subscriptions of that service by ID, whatever spelling they
+// were stored with. Any other name is matched as stored, trimmed.
+func resolveServiceFilter(ctx context.Context, catalog CatalogRepositoryI, name *string) (*uuid.UUID, *string, error) {
+	if name == nil {
+		return nil, nil, nil
+	}
+	trimmed := strings.TrimSpace(*name)
+	if catalog == nil {
+		return nil, &trimmed, nil
+	}
+	svc, err := catalog.ResolveService(ctx, trimmed)
+	if err != nil {
+		if errors.Is(err, domain.ErrServiceNotFound) {
+			return nil, &trimmed, nil
+		}
+		return nil, nil, fmt.Errorf("failed to resolve service_name: %w", err)
+	}
+	return &svc.ID, nil, nil
+}
+
+// serviceMatcher reports whether a subscription belongs to one of the named services,
+// each name resolved like serviceFilter does. Empty names match every subscription.
+func (s *SubscriptionService) serviceMatcher(ctx context.Context, names []string) (func(domain.Subscription) bool, error) {
+	if len(names) == 0 {
+		return func(domain.Subscription) bool { return true }, nil
+	}
+
+	ids := make(map[uuid.UUID]bool, len(names))
+	var unknown []string
+	for _, name := range names {
+		id, stored, err := s.serviceFilter(ctx, &name)
+		if err != nil {
+			return nil, err
+		}
+		if id != nil {
+			ids[*id] = true
+		} else {
+			unknown = append(unknown, *stored)
+		}
+	}
+	return func(sub domain.Subscription) bool {
+		return sub.ServiceID != nil && ids[*sub.ServiceID] || slices.Contains(unknown, sub.ServiceName)
+	}, nil
+}
+
+// serviceIDOf returns the ID of a catalog service, nil for a service not in the catalog.
+func serviceIDOf(svc domain.Service) *uuid.UUID {
+	if svc.ID == uuid.Nil {
+		return nil
+	}
+	return &svc.ID
+}
+
+// CatalogService manages the services catalog.
+type CatalogService struct {
+	repo CatalogRepositoryI
+	tx   TransactorI
+	log  *zap.Logger
+}
+
+// NewCatalogService builds the service. A nil tx runs the statements of a write one by one.
+func NewCatalogService(repo CatalogRepositoryI, tx TransactorI, log *zap.Logger) *CatalogService {
+	return &CatalogService{repo: repo, tx: tx, log: log}
+}
+
+func (s *CatalogService) CreateService(ctx context.Context, req dto.CreateServiceRequest) (dto.ServiceOutput, error) {
+	ctx, span := startSpan(ctx, "CatalogService.CreateService")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("name", req.Name))
+
+	svc := domain.Service{
+		ID:           uuid.New(),
+		Name:         strings.TrimSpace(req.Name),
+		Category:     domain.NormalizeLabel(req.Category),
+		URL:          req.URL,
+		DefaultPrice: req.DefaultPrice,
+	}
+	svc.Aliases = aliases(svc.Name, req.Aliases)
+
+	var created domain.Service
+	err := inTx(ctx, s.tx, func(ctx context.Context) error {
+		if err := s.checkNames(ctx, svc); err != nil {
+			return err
+		}
+		var err error
+		created, err = s.repo.CreateService(ctx, svc)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidService) {
+			log.Warn("Invalid service", zap.Error(err))
+			return dto.ServiceOutput{}, err
+		}
+		log.Error("Failed to create service", zap.Error(err))
+		return dto.ServiceOutput{}, fmt.Errorf("failed to create service: %w", err)
+	}
+
+	log.Info("Service created", zap.String("service_id", created.ID.String()))
+	return serviceToDto(created), nil
+}
+
+func (s *CatalogService) Service(ctx context.Context, id uuid.UUID) (dto.ServiceOutput, error) {
+	ctx, span := startSpan(ctx, "CatalogService.Service")
+	defer span.End()
+
+	svc, err := s.repo.Service(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrServiceNotFound) {
+			return dto.ServiceOutput{}, err
+		}
+		s.loggerWith(ctx, zap.String("service_id", id.String())).Error("Failed to get service", zap.Error(err))
+		return dto.ServiceOutput{}, fmt.Errorf("failed to get service: %w", err)
+	}
+
+	return serviceToDto(svc), nil
+}
+
+func (s *CatalogService) Services(ctx context.Context, filter dto.ServiceFilter) ([]dto.ServiceOutput, error) {
+	ctx, span := startSpan(ctx, "CatalogService.Services")
+	defer span.End()
+
+	services, err := s.repo.Services(ctx, domain.ServiceFilter{Category: normalizeCategory(filter.Category)})
+	if err != nil {
+		s.loggerWith(ctx).Error("Failed to get services", zap.Error(err))
+		return nil, fmt.Errorf("failed to get services: %w", err)
+	}
+
+	out := make([]dto.ServiceOutput, len(services))
+	for i, svc := range services {
+		out[i] = serviceToDto(svc)
+	}
+	return out, nil
+}
+
+// UpdateService changes a service. A renamed service keeps its old name as an alias and
+// its subscriptions take the new name in the same transaction.
+func (s *CatalogService) UpdateService(ctx context.Context, id uuid.UUID, req dto.UpdateServiceRequest) (dto.ServiceOutput, error) {
+	ctx, span := startSpan(ctx, "CatalogService.UpdateService")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("service_id", id.String()))
+
+	upd := domain.UpdateService{URL: req.URL, DefaultPrice: req.DefaultPrice}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return dto.ServiceOutput{}, fmt.Errorf("%w: name must not be empty", domain.ErrInvalidService)
+		}
+		upd.Name = &name
+	}
+	upd.Category = normalizeCategory(req.Category)
+
+	var updated domain.Service
+	err := inTx(ctx, s.tx, func(ctx context.Context) error {
+		svc, err := s.repo.Service(ctx, id)
+		if err != nil {
+			return err
+		}
+		oldName := svc.Name
+		if upd.Name != nil {
+			svc.Name = *upd.Name
+		}
+		if req.Aliases != nil || upd.Name != nil {
+			if req.Aliases != nil {
+				svc.Aliases = *req.Aliases
+			}
+			if upd.Name != nil {
+				svc.Aliases = slices.Concat(svc.Aliases, []string{oldName})
+			}
+			svc.Aliases = aliases(svc.Name, svc.Aliases)
+			upd.Aliases = &svc.Aliases
+		}
+		if err := s.checkNames(ctx, svc); err != nil {
+			return err
+		}
+
+		updated, err = s.repo.UpdateService(ctx, id, upd)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrServiceNotFound):
+			log.Warn("Service not found")
+			return dto.ServiceOutput{}, err
+		case errors.Is(err, domain.ErrInvalidService):
+			log.Warn("Invalid service", zap.Error(err))
+			return dto.ServiceOutput{}, err
+		}
+		log.Error("Failed to update service", zap.Error(err))
+		return dto.ServiceOutput{}, fmt.Errorf("failed to update service: %w", err)
+	}
+
+	log.Info("Service updated")
+	return serviceToDto(updated), nil
+}
+
+// DeleteService removes a service from the catalog. Its subscriptions keep their service name.
+func (s *CatalogService) DeleteService(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "CatalogService.DeleteService")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("service_id", id.String()))
+
+	if err := s.repo.DeleteService(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrServiceNotFound) {
+			log.Warn("Service not found")
+			return err
+		}
+		log.Error("Failed to delete service", zap.Error(err))
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+
+	log.Info("Service deleted")
+	return nil
+}
+
+// checkNames rejects a name or alias of svc that already resolves to another service.
+func (s *CatalogService) checkNames(ctx context.Context, svc domain.Service) error {
+	for _, name := range append([]string{svc.Name}, svc.Aliases...) {
+		other, err := s.repo.ResolveService(ctx, name)
+		if errors.Is(err, domain.ErrServiceNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if other.ID != svc.ID {
+			return fmt.Errorf("%w: %q is already a name of service %s", domain.ErrInvalidService, name, other.ID)
+		}
+	}
+	return nil
+}
+
+func (s *CatalogService) loggerWith(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	return s.log.With(append(contextFields(ctx), fields...)...)
+}
+
+// aliases trims the aliases and drops empty ones, repeated ones and those naming the
+// service itself.
+func aliases(name string, aliases []string) []string {
+	seen := map[string]bool{domain.NormalizeServiceName(name): true}
+	out := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		key := domain.NormalizeServiceName(alias)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, alias)
+	}
+	return out
+}
+
+func serviceToDto(svc domain.Service) dto.ServiceOutput {
+	return dto.ServiceOutput{
+		ID:           svc.ID.String(),
+		Name:         svc.Name,
+		Aliases:      svc.Aliases,
+		Category:     svc.Category,
+		URL:          svc.URL,
+		DefaultPrice: svc.DefaultPrice,
+		CreatedAt:    svc.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    svc.UpdatedAt.Format(time.RFC3339),
+	}
+}
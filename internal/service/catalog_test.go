@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCreateSubscription_ResolvesService(t *testing.T) {
+	userID := uuid.New()
+	netflix := domain.Service{ID: uuid.New(), Name: "Netflix", Aliases: []string{"Нетфликс"}, DefaultPrice: 799}
+
+	tests := []struct {
+		name      string
+		req       dto.CreateSubscriptionRequest
+		setup     func(catalog *mocks.MockCatalogRepositoryI)
+		wantName  string
+		wantID    *uuid.UUID
+		wantPrice int
+		wantErr   error
+	}{
+		{
+			name: "alias with the default price",
+			req:  dto.CreateSubscriptionRequest{ServiceName: " нетфликс "},
+			setup: func(catalog *mocks.MockCatalogRepositoryI) {
+				catalog.EXPECT().ResolveService(gomock.Any(), "нетфликс").Return(netflix, nil)
+			},
+			wantName:  "Netflix",
+			wantID:    &netflix.ID,
+			wantPrice: 799,
+		},
+		{
+			name: "unknown name is kept",
+			req:  dto.CreateSubscriptionRequest{ServiceName: "Kion ", Price: 300},
+			setup: func(catalog *mocks.MockCatalogRepositoryI) {
+				catalog.EXPECT().ResolveService(gomock.Any(), "Kion").Return(domain.Service{}, domain.ErrServiceNotFound)
+			},
+			wantName:  "Kion",
+			wantPrice: 300,
+		},
+		{
+			name: "service id with a matching name",
+			req:  dto.CreateSubscriptionRequest{ServiceID: ptr(netflix.ID.String()), ServiceName: "NETFLIX", Price: 500},
+			setup: func(catalog *mocks.MockCatalogRepositoryI) {
+				catalog.EXPECT().Service(gomock.Any(), netflix.ID).Return(netflix, nil)
+			},
+			wantName:  "Netflix",
+			wantID:    &netflix.ID,
+			wantPrice: 500,
+		},
+		{
+			name: "service id with another name",
+			req:  dto.CreateSubscriptionRequest{ServiceID: ptr(netflix.ID.String()), ServiceName: "Spotify", Price: 500},
+			setup: func(catalog *mocks.MockCatalogRepositoryI) {
+				catalog.EXPECT().Service(gomock.Any(), netflix.ID).Return(netflix, nil)
+			},
+			wantErr: domain.ErrInvalidService,
+		},
+		{
+			name: "unknown service id",
+			req:  dto.CreateSubscriptionRequest{ServiceID: ptr(uuid.NewString()), Price: 500},
+			setup: func(catalog *mocks.MockCatalogRepositoryI) {
+				catalog.EXPECT().Service(gomock.Any(), gomock.Any()).Return(domain.Service{}, domain.ErrServiceNotFound)
+			},
+			wantErr: domain.ErrInvalidService,
+		},
+		{
+			name: "no price and no default price",
+			req:  dto.CreateSubscriptionRequest{ServiceName: "Kion"},
+			setup: func(catalog *mocks.MockCatalogRepositoryI) {
+				catalog.EXPECT().ResolveService(gomock.Any(), "Kion").Return(domain.Service{}, domain.ErrServiceNotFound)
+			},
+			wantErr: domain.ErrInvalidService,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, catalog := mocks.NewMockSubscriptionRepositoryI(ctrl), mocks.NewMockCatalogRepositoryI(ctrl)
+			svc := NewSubscriptionService(repo, nil, nil, CostPolicy{}, zap.NewNop(), WithCatalog(catalog))
+			tt.setup(catalog)
+			if tt.wantErr == nil {
+				repo.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, sub domain.Subscription) (domain.Subscription, error) {
+						if sub.ServiceName != tt.wantName || sub.Price != tt.wantPrice {
+							t.Errorf("ServiceName, Price = %q, %d, want %q, %d", sub.ServiceName, sub.Price, tt.wantName, tt.wantPrice)
+						}
+						if (sub.ServiceID == nil) != (tt.wantID == nil) || sub.ServiceID != nil && *sub.ServiceID != *tt.wantID {
+							t.Errorf("ServiceID = %v, want %v", sub.ServiceID, tt.wantID)
+						}
+						return sub, nil
+					})
+			}
+
+			tt.req.UserID, tt.req.StartDate = userID.String(), "07-2025"
+			_, err := svc.CreateSubscription(context.Background(), tt.req)
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) || tt.wantErr == nil && err != nil {
+				t.Errorf("CreateSubscription() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateSubscription_ResolvesService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo, catalog := mocks.NewMockSubscriptionRepositoryI(ctrl), mocks.NewMockCatalogRepositoryI(ctrl)
+	svc := NewSubscriptionService(repo, nil, nil, CostPolicy{}, zap.NewNop(), WithCatalog(catalog))
+	id, netflix := uuid.New(), domain.Service{ID: uuid.New(), Name: "Netflix"}
+
+	catalog.EXPECT().ResolveService(gomock.Any(), "netflix").Return(netflix, nil)
+	repo.EXPECT().UpdateSubscription(gomock.Any(), id, domain.UpdateSubscription{ServiceName: &netflix.Name, ServiceID: &netflix.ID}).
+		Return(domain.Subscription{ID: id, ServiceID: &netflix.ID, ServiceName: netflix.Name}, nil)
+
+	out, err := svc.UpdateSubscription(context.Background(), id, dto.UpdateSubscriptionRequest{ServiceName: ptr("netflix")})
+	if err != nil || out.ServiceName != "Netflix" || out.ServiceID == nil || *out.ServiceID != netflix.ID.String() {
+		t.Errorf("UpdateSubscription() = %+v, %v", out, err)
+	}
+}
+
+func TestCatalogService_CreateService(t *testing.T) {
+	spotify := domain.Service{ID: uuid.New(), Name: "Spotify"}
+
+	t.Run("aliases are cleaned up", func(t *testing.T) {
+		repo := mocks.NewMockCatalogRepositoryI(gomock.NewController(t))
+		svc := NewCatalogService(repo, nil, zap.NewNop())
+		repo.EXPECT().ResolveService(gomock.Any(), gomock.Any()).Return(domain.Service{}, domain.ErrServiceNotFound).Times(2)
+		repo.EXPECT().CreateService(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, s domain.Service) (domain.Service, error) {
+				if s.Name != "Netflix" || !slices.Equal(s.Aliases, []string{"Нетфликс"}) {
+					t.Errorf("CreateService(%+v)", s)
+				}
+				return s, nil
+			})
+
+		req := dto.CreateServiceRequest{Name: " Netflix", Aliases: []string{"netflix", " Нетфликс", "нетфликс", ""}}
+		if _, err := svc.CreateService(context.Background(), req); err != nil {
+			t.Errorf("CreateService() error = %v", err)
+		}
+	})
+
+	t.Run("alias of another service", func(t *testing.T) {
+		repo := mocks.NewMockCatalogRepositoryI(gomock.NewController(t))
+		svc := NewCatalogService(repo, nil, zap.NewNop())
+		repo.EXPECT().ResolveService(gomock.Any(), "Spotify Premium").Return(domain.Service{}, domain.ErrServiceNotFound)
+		repo.EXPECT().ResolveService(gomock.Any(), "spotify").Return(spotify, nil)
+
+		req := dto.CreateServiceRequest{Name: "Spotify Premium", Aliases: []string{"spotify"}}
+		if _, err := svc.CreateService(context.Background(), req); !errors.Is(err, domain.ErrInvalidService) {
+			t.Errorf("CreateService() error = %v, want ErrInvalidService", err)
+		}
+	})
+}
+
+func TestCatalogService_UpdateService_Rename(t *testing.T) {
+	netflix := domain.Service{ID: uuid.New(), Name: "Netflix", Aliases: []string{"Нетфликс"}}
+
+	repo := mocks.NewMockCatalogRepositoryI(gomock.NewController(t))
+	svc := NewCatalogService(repo, nil, zap.NewNop())
+	repo.EXPECT().Service(gomock.Any(), netflix.ID).Return(netflix, nil)
+	repo.EXPECT().ResolveService(gomock.Any(), gomock.Any()).Return(netflix, nil).Times(3)
+	repo.EXPECT().UpdateService(gomock.Any(), netflix.ID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ uuid.UUID, upd domain.UpdateService) (domain.Service, error) {
+			if upd.Name == nil || *upd.Name != "Netflix Standard" || upd.Aliases == nil || !slices.Equal(*upd.Aliases, []string{"Нетфликс", "Netflix"}) {
+				t.Errorf("UpdateService(%+v)", upd)
+			}
+			return netflix, nil
+		})
+
+	if _, err := svc.UpdateService(context.Background(), netflix.ID, dto.UpdateServiceRequest{Name: ptr("Netflix Standard")}); err != nil {
+		t.Errorf("UpdateService() error = %v", err)
+	}
+}
+
+func TestSubscriptions_ServiceFilter(t *testing.T) {
+	netflix := domain.Service{ID: uuid.New(), Name: "Netflix"}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   domain.SubscriptionFilter
+	}{
+		{name: "catalog service", filter: " netflix", want: domain.SubscriptionFilter{ServiceID: &netflix.ID, Limit: 10}},
+		{name: "unknown service", filter: "Kion ", want: domain.SubscriptionFilter{ServiceName: ptr("Kion"), Limit: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, catalog := mocks.NewMockSubscriptionRepositoryI(ctrl), mocks.NewMockCatalogRepositoryI(ctrl)
+			svc := NewSubscriptionService(repo, nil, nil, CostPolicy{}, zap.NewNop(), WithCatalog(catalog))
+			catalog.EXPECT().ResolveService(gomock.Any(), "netflix").Return(netflix, nil).AnyTimes()
+			catalog.EXPECT().ResolveService(gomock.Any(), "Kion").Return(domain.Service{}, domain.ErrServiceNotFound).AnyTimes()
+			repo.EXPECT().Subscriptions(gomock.Any(), tt.want).Return(nil, 0, nil)
+
+			if _, err := svc.Subscriptions(context.Background(), dto.SubscriptionFilter{ServiceName: &tt.filter, Page: 1, PageSize: 10}); err != nil {
+				t.Errorf("Subscriptions() error = %v", err)
+			}
+		})
+	}
+}
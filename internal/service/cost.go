@@ -0,0 +1,232 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+)
+
+// Proration selects the unit in which active time is billed.
+type Proration string
+
+const (
+	// ProrationMonth bills whole calendar months.
+	ProrationMonth Proration = "month"
+	// ProrationDay bills each month by the share of its days the subscription was active.
+	ProrationDay Proration = "day"
+)
+
+// CostPolicy defines how the time a subscription was active is billed.
+//
+// By default the end month is excluded: a subscription from 01-2025 to 03-2025 costs
+// two months, one from 07-2025 to 07-2025 costs nothing, and an open subscription is
+// billed up to, but not including, the current month. The same rule applies to the
+// "to" boundary of a cost period.
+//
+// With day proration an excluded end stops at the end date itself, and an open
+// subscription is billed for the days of the current month that have fully passed.
+//
+// The price of a subscription with a billing period longer than a month is spread
+// evenly over the months of the period, so a yearly plan of 1200 costs 100 a month.
+// Scheduled price changes apply from their effective month on.
+//
+// Dates and period boundaries are calendar days. The current month and day are read
+// from the clock in Location, so they change at midnight of the caller's zone.
+type CostPolicy struct {
+	// EndMonthInclusive bills the end month too, so 01-2025..03-2025 costs three months.
+	EndMonthInclusive bool
+	// Proration is ProrationMonth when empty.
+	Proration Proration
+	// Location is UTC when nil.
+	Location *time.Location
+}
+
+// Validate reports an unknown proration.
+func (p CostPolicy) Validate() error {
+	switch p.Proration {
+	case "", ProrationMonth, ProrationDay:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown proration %q", domain.ErrInvalidCostPolicy, p.Proration)
+	}
+}
+
+// Override returns the policy with the fields set in the request replaced.
+func (p CostPolicy) Override(req dto.CostRequest) CostPolicy {
+	if req.EndMonthInclusive != nil {
+		p.EndMonthInclusive = *req.EndMonthInclusive
+	}
+	if req.Proration != nil {
+		p.Proration = Proration(*req.Proration)
+	}
+	return p
+}
+
+func (p CostPolicy) toDto() dto.CostPolicyOutput {
+	proration := p.Proration
+	if proration == "" {
+		proration = ProrationMonth
+	}
+	return dto.CostPolicyOutput{
+		EndMonthInclusive: p.EndMonthInclusive,
+		Proration:         string(proration),
+		TimeZone:          p.location().String(),
+	}
+}
+
+func (p CostPolicy) location() *time.Location {
+	if p.Location == nil {
+		return time.UTC
+	}
+	return p.Location
+}
+
+// SubscriptionCost returns the cost of sub within the optional [from, to] period.
+// Open subscriptions are cut off at now, read in the policy location. Each billed month
+// is priced like Charge prices it, at the price in effect after changes, which must be
+// sorted by effective date.
+func (p CostPolicy) SubscriptionCost(sub domain.Subscription, changes []domain.PriceChange, from, to *time.Time, now time.Time) int {
+	now = wallClock(now.In(p.location()))
+
+	start := sub.StartDate
+	if from != nil {
+		start = maxTime(start, *from)
+	}
+
+	rate := func(month time.Time) float64 {
+		return monthlyPrice(sub, priceAt(sub, changes, month))
+	}
+
+	if p.Proration == ProrationDay {
+		end := p.dayBoundary(now, true)
+		if sub.EndDate != nil {
+			end = p.dayBoundary(*sub.EndDate, false)
+		}
+		if to != nil {
+			end = minTime(end, p.dayBoundary(*to, false))
+		}
+		return int(math.Round(proratedCost(start, end, rate)))
+	}
+
+	end := now
+	if sub.EndDate != nil {
+		end = *sub.EndDate
+	}
+	if to != nil {
+		end = minTime(end, *to)
+	}
+
+	var total float64
+	month := monthStart(start)
+	for range p.months(start, end) {
+		total += rate(month)
+		month = month.AddDate(0, 1, 0)
+	}
+	return int(math.Round(total))
+}
+
+// monthlyPrice spreads the price of one billing period over its months.
+func monthlyPrice(sub domain.Subscription, price int) float64 {
+	return float64(price) / float64(max(sub.BillingPeriod, 1))
+}
+
+// Charge returns what sub is charged in the month starting at month: the price in
+// effect on each renewal month and nothing in between. A renewal month is a billed
+// month whose distance from the start month is a multiple of the billing period.
+// changes must be sorted by effective date; the last one not after month applies.
+func (p CostPolicy) Charge(sub domain.Subscription, changes []domain.PriceChange, month time.Time) int {
+	if month.Before(monthStart(sub.StartDate)) {
+		return 0
+	}
+	if sub.EndDate != nil {
+		last := monthStart(*sub.EndDate)
+		if month.After(last) || (!p.EndMonthInclusive && month.Equal(last)) {
+			return 0
+		}
+	}
+	if monthsBetween(sub.StartDate, month)%max(sub.BillingPeriod, 1) != 0 {
+		return 0
+	}
+	return priceAt(sub, changes, month)
+}
+
+// priceAt returns the price of sub in effect in the month starting at month: the last
+// of the sorted changes effective in or before it, the subscription price otherwise.
+func priceAt(sub domain.Subscription, changes []domain.PriceChange, month time.Time) int {
+	price := sub.Price
+	for _, change := range changes {
+		if monthStart(change.EffectiveFrom).After(monthStart(month)) {
+			break
+		}
+		price = change.Price
+	}
+	return price
+}
+
+// months counts the billed months between the months of start and end.
+func (p CostPolicy) months(start, end time.Time) int {
+	n := monthsBetween(start, end)
+	if p.EndMonthInclusive && !monthStart(end).Before(monthStart(start)) {
+		n++
+	}
+	return n
+}
+
+// dayBoundary returns the instant at which billing stops for an end date or for now.
+// An inclusive end runs to the end of its month. An exclusive one stops at the date,
+// or at the start of the current day for now.
+func (p CostPolicy) dayBoundary(t time.Time, isNow bool) time.Time {
+	if p.EndMonthInclusive {
+		return monthStart(t).AddDate(0, 1, 0)
+	}
+	if isNow {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+	return t
+}
+
+// proratedCost sums rate over the months of [start, end), where each partial month
+// counts as the share of its days covered.
+func proratedCost(start, end time.Time, rate func(month time.Time) float64) float64 {
+	var total float64
+	for m := monthStart(start); m.Before(end); m = m.AddDate(0, 1, 0) {
+		next := m.AddDate(0, 1, 0)
+		covered := minTime(next, end).Sub(maxTime(m, start))
+		if covered > 0 {
+			total += rate(m) * covered.Hours() / next.Sub(m).Hours()
+		}
+	}
+	return total
+}
+
+// monthsBetween counts the months from start up to, but not including, the end month.
+func monthsBetween(start, end time.Time) int {
+	start = monthStart(start)
+	end = monthStart(end)
+
+	if end.Before(start) {
+		return 0
+	}
+
+	return (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+}
+
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+)
+
+func TestMonthsBetween(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       int
+	}{
+		{"same month", month(2025, time.March), month(2025, time.March), 0},
+		{"two months", month(2025, time.January), month(2025, time.March), 2},
+		{"across year", month(2024, time.November), month(2025, time.February), 3},
+		{"days are ignored", time.Date(2025, time.January, 31, 23, 0, 0, 0, time.UTC), time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), 1},
+		{"end before start", month(2025, time.May), month(2025, time.April), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := monthsBetween(tt.start, tt.end); got != tt.want {
+				t.Errorf("monthsBetween(%v, %v) = %d, want %d", tt.start, tt.end, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCostPolicy_EndMonth(t *testing.T) {
+	now := month(2025, time.October)
+	july := month(2025, time.July)
+	september := month(2025, time.September)
+
+	tests := []struct {
+		name      string
+		sub       domain.Subscription
+		from, to  *time.Time
+		exclusive int
+		inclusive int
+	}{
+		{"single month", domain.Subscription{Price: 100, StartDate: july, EndDate: &july}, nil, nil, 0, 100},
+		{"closed range", domain.Subscription{Price: 100, StartDate: july, EndDate: &september}, nil, nil, 200, 300},
+		{"open until now", domain.Subscription{Price: 100, StartDate: july}, nil, nil, 300, 400},
+		{"period end", domain.Subscription{Price: 100, StartDate: july}, nil, &september, 200, 300},
+		{"period start after end", domain.Subscription{Price: 100, StartDate: july, EndDate: &july}, &september, nil, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (CostPolicy{}).SubscriptionCost(tt.sub, nil, tt.from, tt.to, now); got != tt.exclusive {
+				t.Errorf("exclusive cost = %d, want %d", got, tt.exclusive)
+			}
+			if got := (CostPolicy{EndMonthInclusive: true}).SubscriptionCost(tt.sub, nil, tt.from, tt.to, now); got != tt.inclusive {
+				t.Errorf("inclusive cost = %d, want %d", got, tt.inclusive)
+			}
+		})
+	}
+}
+
+// randomSubscription returns a subscription within 2020..2029, open in a third of the cases.
+func randomSubscription(r *rand.Rand) domain.Subscription {
+	start := month(2020+r.IntN(10), time.Month(1+r.IntN(12)))
+	sub := domain.Subscription{Price: 1 + r.IntN(1000), StartDate: start}
+	if r.IntN(3) > 0 {
+		end := start.AddDate(0, r.IntN(36), 0)
+		sub.EndDate = &end
+	}
+	return sub
+}
+
+func randomMonth(r *rand.Rand) time.Time {
+	return month(2019+r.IntN(12), time.Month(1+r.IntN(12)))
+}
+
+func TestCostPolicy_Properties(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	now := month(2026, time.March)
+
+	policies := []CostPolicy{
+		{},
+		{EndMonthInclusive: true},
+		{Proration: ProrationDay},
+		{EndMonthInclusive: true, Proration: ProrationDay},
+	}
+	for _, policy := range policies {
+		for range 5000 {
+			sub := randomSubscription(r)
+			a, b := randomMonth(r), randomMonth(r)
+			if b.Before(a) {
+				a, b = b, a
+			}
+			split := a.AddDate(0, r.IntN(monthsBetween(a, b)+1), 0)
+			total := policy.SubscriptionCost(sub, nil, &a, &b, now)
+
+			// Splitting a period into adjacent parts keeps the total. With an exclusive end
+			// the parts share the split month as a boundary, with an inclusive one they don't.
+			var left, right int
+			if policy.EndMonthInclusive {
+				if split.Equal(b) {
+					left, right = total, 0
+				} else {
+					next := split.AddDate(0, 1, 0)
+					left = policy.SubscriptionCost(sub, nil, &a, &split, now)
+					right = policy.SubscriptionCost(sub, nil, &next, &b, now)
+				}
+			} else {
+				left = policy.SubscriptionCost(sub, nil, &a, &split, now)
+				right = policy.SubscriptionCost(sub, nil, &split, &b, now)
+			}
+			if left+right != total {
+				t.Fatalf("%+v: cost(%v..%v) = %d, but parts split at %v sum to %d + %d",
+					policy, a, b, total, split, left, right)
+			}
+
+			// A period never costs more than the subscription over its whole life
+			// and never more than the full price for every month of the period.
+			whole := policy.SubscriptionCost(sub, nil, nil, nil, now)
+			if total < 0 || total > whole {
+				t.Fatalf("%+v: cost(%v..%v) = %d outside [0, %d]", policy, a, b, total, whole)
+			}
+			if limit := sub.Price * policy.months(a, b); total > limit {
+				t.Fatalf("%+v: cost(%v..%v) = %d exceeds %d", policy, a, b, total, limit)
+			}
+
+			// With month-aligned dates and now, day proration bills exactly whole months.
+			monthly := CostPolicy{EndMonthInclusive: policy.EndMonthInclusive}
+			if want := monthly.SubscriptionCost(sub, nil, &a, &b, now); total != want {
+				t.Fatalf("%+v: cost(%v..%v) = %d, month proration gives %d", policy, a, b, total, want)
+			}
+
+			// Widening the period never lowers the cost.
+			earlier, later := a.AddDate(0, -1, 0), b.AddDate(0, 1, 0)
+			if wider := policy.SubscriptionCost(sub, nil, &earlier, &later, now); wider < total {
+				t.Fatalf("%+v: widening %v..%v lowered the cost from %d to %d", policy, a, b, total, wider)
+			}
+		}
+	}
+}
+
+func TestCostPolicy_DayProration(t *testing.T) {
+	// October 2025 has 31 days, 17 of which have fully passed on the 18th.
+	now := time.Date(2025, time.October, 18, 15, 30, 0, 0, time.UTC)
+	september := month(2025, time.September)
+	october := month(2025, time.October)
+
+	tests := []struct {
+		name   string
+		policy CostPolicy
+		sub    domain.Subscription
+		want   int
+	}{
+		{"passed days of the current month", CostPolicy{Proration: ProrationDay}, domain.Subscription{Price: 310, StartDate: october}, 170},
+		{"previous and passed days", CostPolicy{Proration: ProrationDay}, domain.Subscription{Price: 310, StartDate: september}, 310 + 170},
+		{"inclusive bills the current month", CostPolicy{Proration: ProrationDay, EndMonthInclusive: true}, domain.Subscription{Price: 310, StartDate: october}, 310},
+		{"month proration skips the current month", CostPolicy{}, domain.Subscription{Price: 310, StartDate: october}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.SubscriptionCost(tt.sub, nil, nil, nil, now); got != tt.want {
+				t.Errorf("SubscriptionCost() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCostPolicy_DayDates(t *testing.T) {
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	// 17 of January's 31 days, all of February and 14 of March's 31 days make two months.
+	sub := domain.Subscription{Price: 100, StartDate: day(time.January, 15), EndDate: ptr(day(time.March, 15))}
+
+	tests := []struct {
+		name     string
+		policy   CostPolicy
+		from, to *time.Time
+		want     int
+	}{
+		{name: "day proration bills partial months", policy: CostPolicy{Proration: ProrationDay}, want: 200},
+		{name: "month proration bills the start month whole", policy: CostPolicy{}, want: 200},
+		{name: "month proration with inclusive end", policy: CostPolicy{EndMonthInclusive: true}, want: 300},
+		{
+			name: "day period inside the subscription", policy: CostPolicy{Proration: ProrationDay},
+			from: ptr(day(time.February, 1)), to: ptr(day(time.February, 15)), want: 50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.SubscriptionCost(sub, nil, tt.from, tt.to, now); got != tt.want {
+				t.Errorf("SubscriptionCost() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCostPolicy_Location(t *testing.T) {
+	// 22:00 UTC on September 30 is already October 1 in Moscow (UTC+3).
+	now := time.Date(2025, time.September, 30, 22, 0, 0, 0, time.UTC)
+	moscow, err := LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := domain.Subscription{Price: 300, StartDate: month(2025, time.September)}
+
+	tests := []struct {
+		name   string
+		policy CostPolicy
+		want   int
+	}{
+		{"utc month", CostPolicy{}, 0},
+		{"local month", CostPolicy{Location: moscow}, 300},
+		{"utc days", CostPolicy{Proration: ProrationDay}, 290},
+		{"local days", CostPolicy{Proration: ProrationDay, Location: moscow}, 300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.SubscriptionCost(sub, nil, nil, nil, now); got != tt.want {
+				t.Errorf("SubscriptionCost() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCostPolicy_Override(t *testing.T) {
+	base := CostPolicy{EndMonthInclusive: true, Proration: ProrationMonth}
+	inclusive, day := false, "day"
+
+	if got := base.Override(dto.CostRequest{}); got != base {
+		t.Errorf("Override() without fields = %+v, want %+v", got, base)
+	}
+	got := base.Override(dto.CostRequest{EndMonthInclusive: &inclusive, Proration: &day})
+	if got != (CostPolicy{Proration: ProrationDay}) {
+		t.Errorf("Override() = %+v", got)
+	}
+	if err := base.Override(dto.CostRequest{Proration: ptr("week")}).Validate(); !errors.Is(err, domain.ErrInvalidCostPolicy) {
+		t.Errorf("Validate() error = %v, want ErrInvalidCostPolicy", err)
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	"tz/internal/domain"
+)
+
+// parseDate parses a date given either as MM-YYYY, meaning the first day of that month,
+// or as ISO 8601 YYYY-MM-DD. The result is midnight UTC.
+//
+// Only the exact digit counts are accepted and year 0000 is rejected, so every accepted
+// input is the canonical output of formatDate in the matching format.
+func parseDate(dateStr string) (time.Time, error) {
+	switch {
+	case len(dateStr) == 7 && dateStr[2] == '-' && isDigits(dateStr[:2]) && isDigits(dateStr[3:]):
+		month, _ := strconv.Atoi(dateStr[:2])
+		year, _ := strconv.Atoi(dateStr[3:])
+		if month < 1 || month > 12 || year < 1 {
+			return time.Time{}, fmt.Errorf("%w: %v", domain.ErrInvalidDate, dateStr)
+		}
+		return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), nil
+
+	case len(dateStr) == 10 && dateStr[4] == '-' && dateStr[7] == '-' &&
+		isDigits(dateStr[:4]) && isDigits(dateStr[5:7]) && isDigits(dateStr[8:]):
+		t, err := time.Parse(time.DateOnly, dateStr)
+		if err != nil || t.Year() < 1 {
+			return time.Time{}, fmt.Errorf("%w: %v", domain.ErrInvalidDate, dateStr)
+		}
+		return t, nil
+
+	default:
+		return time.Time{}, fmt.Errorf("%w: %v", domain.ErrInvalidDateFormat, dateStr)
+	}
+}
+
+// formatDate renders t in the given format. DateFormatMonth drops the day.
+func formatDate(t time.Time, format domain.DateFormat) string {
+	if format == domain.DateFormatDay {
+		return t.Format(time.DateOnly)
+	}
+	return fmt.Sprintf("%02d-%04d", t.Month(), t.Year())
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
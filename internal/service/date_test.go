@@ -0,0 +1,136 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"tz/internal/domain"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr error
+	}{
+		{in: "01-2025", want: month(2025, time.January)},
+		{in: "12-2024", want: month(2024, time.December)},
+		{in: "06-0001", want: month(1, time.June)},
+		{in: "00-2025", wantErr: domain.ErrInvalidDate},
+		{in: "13-2025", wantErr: domain.ErrInvalidDate},
+		{in: "01-0000", wantErr: domain.ErrInvalidDate},
+		{in: "7-2025", wantErr: domain.ErrInvalidDateFormat},
+		{in: "1-+2025", wantErr: domain.ErrInvalidDateFormat},
+		{in: "01--2025", wantErr: domain.ErrInvalidDateFormat},
+		{in: "01-0", wantErr: domain.ErrInvalidDateFormat},
+		{in: "01-20250", wantErr: domain.ErrInvalidDateFormat},
+		{in: "ab-2025", wantErr: domain.ErrInvalidDateFormat},
+		{in: "2025-01", wantErr: domain.ErrInvalidDateFormat},
+		{in: "2025-07-15", want: time.Date(2025, time.July, 15, 0, 0, 0, 0, time.UTC)},
+		{in: "2024-02-29", want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{in: "2025-02-29", wantErr: domain.ErrInvalidDate},
+		{in: "2025-13-01", wantErr: domain.ErrInvalidDate},
+		{in: "0000-01-01", wantErr: domain.ErrInvalidDate},
+		{in: "2025-7-15", wantErr: domain.ErrInvalidDateFormat},
+		{in: "2025-07-15T00:00:00Z", wantErr: domain.ErrInvalidDateFormat},
+		{in: "+025-07-15", wantErr: domain.ErrInvalidDateFormat},
+		{in: "01/2025", wantErr: domain.ErrInvalidDateFormat},
+		{in: " 01-2025", wantErr: domain.ErrInvalidDateFormat},
+		{in: "", wantErr: domain.ErrInvalidDateFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseDate(tt.in)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("parseDate(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDate(%q) error = %v", tt.in, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseDate(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// FuzzParseDate checks that every accepted string is a canonical MM-YYYY or YYYY-MM-DD
+// date: it formats back to itself and points at midnight UTC, the first of the month for MM-YYYY.
+func FuzzParseDate(f *testing.F) {
+	for _, seed := range []string{"01-2025", "12-9999", "00-2025", "1-+2025", "01-0000", "-1-2025", "０1-2025", "2025-07-15", "2025-02-29", "0000-01-01", ""} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		got, err := parseDate(s)
+		if err != nil {
+			if !errors.Is(err, domain.ErrInvalidDate) && !errors.Is(err, domain.ErrInvalidDateFormat) {
+				t.Fatalf("parseDate(%q) returned an unclassified error: %v", s, err)
+			}
+			return
+		}
+		if got.Year() < 1 {
+			t.Fatalf("parseDate(%q) accepted year %d", s, got.Year())
+		}
+		format := domain.DateFormatDay
+		if len(s) == len("01-2025") {
+			format = domain.DateFormatMonth
+			if !got.Equal(monthStart(got)) {
+				t.Fatalf("parseDate(%q) = %v, want the start of a month", s, got)
+			}
+		}
+		if got.Location() != time.UTC || got.Hour() != 0 || got.Minute() != 0 || got.Second() != 0 || got.Nanosecond() != 0 {
+			t.Fatalf("parseDate(%q) = %v, want midnight UTC", s, got)
+		}
+		if back := formatDate(got, format); back != s {
+			t.Fatalf("formatDate(parseDate(%q)) = %q", s, back)
+		}
+	})
+}
+
+// FuzzDateRoundTrip checks that every day of years 1..9999 survives formatting and parsing
+// in the day format, and that the month format keeps the month.
+func FuzzDateRoundTrip(f *testing.F) {
+	f.Add(2025, 7, 15)
+	f.Add(1, 1, 1)
+	f.Add(9999, 12, 31)
+
+	f.Fuzz(func(t *testing.T, year, m, day int) {
+		if year < 1 || year > 9999 || m < 1 || m > 12 || day < 1 || day > 31 {
+			t.Skip()
+		}
+		want := time.Date(year, time.Month(m), day, 0, 0, 0, 0, time.UTC)
+		if want.Month() != time.Month(m) {
+			t.Skip()
+		}
+
+		got, err := parseDate(formatDate(want, domain.DateFormatDay))
+		if err != nil {
+			t.Fatalf("parseDate(%q) error = %v", formatDate(want, domain.DateFormatDay), err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("day round trip of %v gave %v", want, got)
+		}
+
+		got, err = parseDate(formatDate(want, domain.DateFormatMonth))
+		if err != nil {
+			t.Fatalf("parseDate(%q) error = %v", formatDate(want, domain.DateFormatMonth), err)
+		}
+		if !got.Equal(monthStart(want)) {
+			t.Fatalf("month round trip of %v gave %v", want, got)
+		}
+	})
+}
+
+func TestFormatDate(t *testing.T) {
+	d := time.Date(2025, time.July, 5, 0, 0, 0, 0, time.UTC)
+	if got := formatDate(d, domain.DateFormatMonth); got != "07-2025" {
+		t.Errorf("formatDate(month) = %q", got)
+	}
+	if got := formatDate(d, domain.DateFormatDay); got != "2025-07-05" {
+		t.Errorf("formatDate(day) = %q", got)
+	}
+}
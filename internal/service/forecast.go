@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	contextkeys "tz/internal/contextkey"
+	"tz/internal/domain"
+	"tz/internal/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// defaultForecastMonths is the forecast length when the request does not set one.
+const defaultForecastMonths = 12
+
+// Forecast projects the charges of the matched subscriptions for each month, starting
+// with the current month of the request time zone. Open subscriptions continue for the
+// whole forecast, billing periods and scheduled price changes are applied.
+func (s *SubscriptionService) Forecast(ctx context.Context, req dto.ForecastRequest) (dto.ForecastOutput, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.Forecast")
+	defer span.End()
+
+	log := s.loggerWith(ctx)
+
+	var userID *uuid.UUID
+	if req.UserID != nil {
+		uid, err := uuid.Parse(*req.UserID)
+		if err != nil {
+			log.Warn("Invalid user_id in forecast", zap.String("user_id", *req.UserID), zap.Error(err))
+			return dto.ForecastOutput{}, fmt.Errorf("invalid user_id in filter: %w", err)
+		}
+		userID = &uid
+	}
+
+	loc, err := s.location(ctx, req.TimeZone, userID)
+	if err != nil {
+		log.Warn("Failed to resolve time zone", zap.Error(err))
+		return dto.ForecastOutput{}, err
+	}
+
+	months := req.Months
+	if months == 0 {
+		months = defaultForecastMonths
+	}
+
+	serviceID, serviceName, err := s.serviceFilter(ctx, req.ServiceName)
+	if err != nil {
+		log.Error("Failed to resolve service filter", zap.Error(err))
+		return dto.ForecastOutput{}, err
+	}
+
+	filter := domain.CostRequest{UserID: userID, ServiceID: serviceID, ServiceName: serviceName}
+	subs, err := s.repo.SubscriptionsCost(ctx, filter)
+	if err != nil {
+		log.Error("Error getting subscriptions for forecast", zap.Error(err))
+		return dto.ForecastOutput{}, fmt.Errorf("error getting subscriptions for forecast: %w", err)
+	}
+	changes, err := s.priceChanges(ctx, filter)
+	if err != nil {
+		log.Error("Error getting price changes for forecast", zap.Error(err))
+		return dto.ForecastOutput{}, fmt.Errorf("error getting price changes for forecast: %w", err)
+	}
+
+	out := dto.ForecastOutput{Months: make([]dto.ForecastMonth, months), TimeZone: loc.String()}
+	current := monthStart(wallClock(s.now().In(loc)))
+	for i := range months {
+		month := current.AddDate(0, i, 0)
+		total := 0
+		for _, sub := range subs {
+			total += s.cost.Charge(sub, changes[sub.ID], month)
+		}
+		out.Months[i] = dto.ForecastMonth{Month: formatDate(month, domain.DateFormatMonth), Total: total}
+		out.Total += total
+	}
+
+	log.Info("Forecast calculated", zap.Int("months", months), zap.Int("subscriptions", len(subs)))
+	return out, nil
+}
+
+// priceChanges returns the price changes of the subscriptions matching the filter by
+// subscription, sorted by effective date as Charge and SubscriptionCost expect.
+func (s *SubscriptionService) priceChanges(ctx context.Context, filter domain.CostRequest) (map[uuid.UUID][]domain.PriceChange, error) {
+	scheduled, err := s.repo.ScheduledPriceChanges(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	changes := make(map[uuid.UUID][]domain.PriceChange)
+	for _, change := range scheduled {
+		changes[change.SubscriptionID] = append(changes[change.SubscriptionID], change)
+	}
+	return changes, nil
+}
+
+func (s *SubscriptionService) CreatePriceChange(ctx context.Context, subscriptionID uuid.UUID, req dto.CreatePriceChangeRequest) (dto.PriceChangeOutput, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.CreatePriceChange")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("subscription_id", subscriptionID.String()))
+
+	effectiveFrom, err := parseDate(req.EffectiveFrom)
+	if err != nil {
+		log.Warn("Invalid effective_from", zap.String("date", req.EffectiveFrom))
+		return dto.PriceChangeOutput{}, fmt.Errorf("%w: %s", err, req.EffectiveFrom)
+	}
+
+	sub, err := s.repo.SubscriptionByID(ctx, subscriptionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return dto.PriceChangeOutput{}, fmt.Errorf("subscription not found: %w", domain.ErrNotFound)
+		}
+		log.Error("Failed to get subscription", zap.Error(err))
+		return dto.PriceChangeOutput{}, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	if monthStart(effectiveFrom).Before(monthStart(sub.StartDate)) {
+		log.Warn("Price change before subscription start")
+		return dto.PriceChangeOutput{}, fmt.Errorf("%w: effective_from %s is before start_date", domain.ErrInvalidDate, req.EffectiveFrom)
+	}
+
+	var change domain.PriceChange
+	err = inTx(ctx, s.tx, func(ctx context.Context) error {
+		var err error
+		change, err = s.repo.CreatePriceChange(ctx, domain.PriceChange{
+			ID:             uuid.New(),
+			SubscriptionID: subscriptionID,
+			Price:          req.Price,
+			EffectiveFrom:  effectiveFrom,
+		})
+		if err != nil {
+			return err
+		}
+		return s.emit(ctx, domain.EventPriceChanged, sub, &change)
+	})
+	if err != nil {
+		log.Error("Failed to create price change", zap.Error(err))
+		return dto.PriceChangeOutput{}, fmt.Errorf("failed to create price change: %w", err)
+	}
+
+	log.Info("Price change scheduled", zap.String("price_change_id", change.ID.String()), zap.Int("price", change.Price))
+	return priceChangeToDto(change, contextkeys.DateFormat(ctx)), nil
+}
+
+func (s *SubscriptionService) PriceChanges(ctx context.Context, subscriptionID uuid.UUID) ([]dto.PriceChangeOutput, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.PriceChanges")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("subscription_id", subscriptionID.String()))
+
+	if _, err := s.repo.SubscriptionByID(ctx, subscriptionID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("subscription not found: %w", domain.ErrNotFound)
+		}
+		log.Error("Failed to get subscription", zap.Error(err))
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	changes, err := s.repo.PriceChanges(ctx, subscriptionID)
+	if err != nil {
+		log.Error("Failed to get price changes", zap.Error(err))
+		return nil, fmt.Errorf("failed to get price changes: %w", err)
+	}
+
+	out := make([]dto.PriceChangeOutput, len(changes))
+	for i, change := range changes {
+		out[i] = priceChangeToDto(change, contextkeys.DateFormat(ctx))
+	}
+	return out, nil
+}
+
+func (s *SubscriptionService) DeletePriceChange(ctx context.Context, subscriptionID, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "SubscriptionService.DeletePriceChange")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("subscription_id", subscriptionID.String()), zap.String("price_change_id", id.String()))
+
+	if err := s.repo.DeletePriceChange(ctx, subscriptionID, id); err != nil {
+		if errors.Is(err, domain.ErrPriceChangeNotFound) {
+			return err
+		}
+		log.Error("Failed to delete price change", zap.Error(err))
+		return fmt.Errorf("failed to delete price change: %w", err)
+	}
+
+	log.Info("Price change deleted")
+	return nil
+}
+
+func priceChangeToDto(c domain.PriceChange, format domain.DateFormat) dto.PriceChangeOutput {
+	return dto.PriceChangeOutput{
+		ID:             c.ID.String(),
+		SubscriptionID: c.SubscriptionID.String(),
+		Price:          c.Price,
+		EffectiveFrom:  formatDate(c.EffectiveFrom, format),
+		CreatedAt:      c.CreatedAt.Format(time.RFC3339),
+	}
+}
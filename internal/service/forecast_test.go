@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCostPolicy_Charge(t *testing.T) {
+	end := month(2026, time.March)
+	monthly := domain.Subscription{Price: 100, StartDate: month(2025, time.November), EndDate: &end, BillingPeriod: 1}
+	yearly := domain.Subscription{Price: 1200, StartDate: month(2025, time.February), BillingPeriod: 12}
+	changes := []domain.PriceChange{
+		{Price: 150, EffectiveFrom: time.Date(2026, time.January, 20, 0, 0, 0, 0, time.UTC)},
+		{Price: 120, EffectiveFrom: month(2026, time.February)},
+	}
+
+	tests := []struct {
+		name    string
+		policy  CostPolicy
+		sub     domain.Subscription
+		changes []domain.PriceChange
+		month   time.Time
+		want    int
+	}{
+		{name: "before the start", sub: monthly, month: month(2025, time.October), want: 0},
+		{name: "start month", sub: monthly, month: month(2025, time.November), want: 100},
+		{name: "end month is excluded", sub: monthly, month: month(2026, time.March), want: 0},
+		{name: "end month is included", policy: CostPolicy{EndMonthInclusive: true}, sub: monthly, month: month(2026, time.March), want: 100},
+		{name: "after the end", policy: CostPolicy{EndMonthInclusive: true}, sub: monthly, month: month(2026, time.April), want: 0},
+		{name: "change applies from its month", sub: monthly, changes: changes, month: month(2026, time.January), want: 150},
+		{name: "latest change wins", sub: monthly, changes: changes, month: month(2026, time.February), want: 120},
+		{name: "change not yet effective", sub: monthly, changes: changes, month: month(2025, time.December), want: 100},
+		{name: "yearly renewal", sub: yearly, month: month(2026, time.February), want: 1200},
+		{name: "yearly between renewals", sub: yearly, month: month(2026, time.March), want: 0},
+		{name: "yearly renewal with change", sub: yearly, changes: changes, month: month(2027, time.February), want: 120},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Charge(tt.sub, tt.changes, tt.month); got != tt.want {
+				t.Errorf("Charge() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCostPolicy_BillingPeriod(t *testing.T) {
+	end := month(2026, time.January)
+	yearly := domain.Subscription{Price: 1200, StartDate: month(2025, time.January), EndDate: &end, BillingPeriod: 12}
+	quarterly := domain.Subscription{Price: 100, StartDate: month(2025, time.January), EndDate: &end, BillingPeriod: 3}
+
+	if got := (CostPolicy{}).SubscriptionCost(yearly, nil, nil, nil, end); got != 1200 {
+		t.Errorf("yearly cost = %d, want 1200", got)
+	}
+	if got := (CostPolicy{Proration: ProrationDay}).SubscriptionCost(yearly, nil, nil, nil, end); got != 1200 {
+		t.Errorf("yearly day cost = %d, want 1200", got)
+	}
+	if got := (CostPolicy{}).SubscriptionCost(quarterly, nil, nil, ptr(month(2025, time.March)), end); got != 67 {
+		t.Errorf("two months of a quarterly plan = %d, want 67", got)
+	}
+}
+
+func TestCostPolicy_PriceChanges(t *testing.T) {
+	end := month(2026, time.January)
+	sub := domain.Subscription{Price: 100, StartDate: month(2025, time.January), EndDate: &end, BillingPeriod: 1}
+	changes := []domain.PriceChange{{Price: 150, EffectiveFrom: month(2025, time.July)}}
+
+	if got := (CostPolicy{}).SubscriptionCost(sub, changes, nil, nil, end); got != 6*100+6*150 {
+		t.Errorf("cost = %d, want %d", got, 6*100+6*150)
+	}
+	if got := (CostPolicy{Proration: ProrationDay}).SubscriptionCost(sub, changes, nil, nil, end); got != 6*100+6*150 {
+		t.Errorf("day cost = %d, want %d", got, 6*100+6*150)
+	}
+	if got := (CostPolicy{}).SubscriptionCost(sub, changes, ptr(month(2025, time.June)), ptr(month(2025, time.September)), end); got != 100+2*150 {
+		t.Errorf("cost of 06-2025..09-2025 = %d, want %d", got, 100+2*150)
+	}
+}
+
+func TestSubscriptionsCost_PriceChanges(t *testing.T) {
+	sub := domain.Subscription{ID: uuid.New(), Price: 100, StartDate: month(2025, time.January), BillingPeriod: 1}
+	change := domain.PriceChange{SubscriptionID: sub.ID, Price: 150, EffectiveFrom: month(2025, time.March)}
+
+	svc, repo := newTestService(t)
+	repo.EXPECT().SubscriptionsCost(gomock.Any(), gomock.Any()).Return([]domain.Subscription{sub}, nil)
+	repo.EXPECT().ScheduledPriceChanges(gomock.Any(), gomock.Any()).Return([]domain.PriceChange{change}, nil)
+
+	got, err := svc.SubscriptionsCost(context.Background(), dto.CostRequest{From: ptr("01-2025"), To: ptr("05-2025")})
+	if err != nil || got.Total != 2*100+2*150 {
+		t.Errorf("SubscriptionsCost() = %d, %v, want %d", got.Total, err, 2*100+2*150)
+	}
+}
+
+func TestForecast(t *testing.T) {
+	// 22:00 UTC on December 31 is already January in Moscow (UTC+3).
+	now := time.Date(2025, time.December, 31, 22, 0, 0, 0, time.UTC)
+	end := month(2026, time.March)
+	monthly := domain.Subscription{ID: uuid.New(), Price: 100, StartDate: month(2025, time.June), EndDate: &end, BillingPeriod: 1}
+	yearly := domain.Subscription{ID: uuid.New(), Price: 1200, StartDate: month(2025, time.February), BillingPeriod: 12}
+	change := domain.PriceChange{SubscriptionID: yearly.ID, Price: 1500, EffectiveFrom: month(2026, time.January)}
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockSubscriptionRepositoryI(ctrl)
+	svc := NewSubscriptionService(repo, nil, nil, CostPolicy{}, zap.NewNop(), WithClock(func() time.Time { return now }))
+
+	repo.EXPECT().SubscriptionsCost(gomock.Any(), domain.CostRequest{ServiceName: ptr("Netflix")}).
+		Return([]domain.Subscription{monthly, yearly}, nil)
+	repo.EXPECT().ScheduledPriceChanges(gomock.Any(), domain.CostRequest{ServiceName: ptr("Netflix")}).
+		Return([]domain.PriceChange{change}, nil)
+
+	got, err := svc.Forecast(context.Background(), dto.ForecastRequest{ServiceName: ptr("Netflix"), Months: 3, TimeZone: ptr("Europe/Moscow")})
+	if err != nil {
+		t.Fatalf("Forecast() error = %v", err)
+	}
+
+	want := []dto.ForecastMonth{{Month: "01-2026", Total: 100}, {Month: "02-2026", Total: 100 + 1500}, {Month: "03-2026", Total: 0}}
+	if len(got.Months) != len(want) {
+		t.Fatalf("Forecast() months = %+v, want %+v", got.Months, want)
+	}
+	for i := range want {
+		if got.Months[i] != want[i] {
+			t.Errorf("month %d = %+v, want %+v", i, got.Months[i], want[i])
+		}
+	}
+	if got.Total != 1700 || got.TimeZone != "Europe/Moscow" {
+		t.Errorf("Forecast() total = %d in %s, want 1700 in Europe/Moscow", got.Total, got.TimeZone)
+	}
+}
+
+func TestForecast_DefaultMonths(t *testing.T) {
+	svc, repo := newTestService(t)
+	repo.EXPECT().SubscriptionsCost(gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().ScheduledPriceChanges(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	got, err := svc.Forecast(context.Background(), dto.ForecastRequest{})
+	if err != nil || len(got.Months) != defaultForecastMonths {
+		t.Errorf("Forecast() = %d months, %v, want %d", len(got.Months), err, defaultForecastMonths)
+	}
+}
+
+func TestCreatePriceChange(t *testing.T) {
+	sub := domain.Subscription{ID: uuid.New(), Price: 100, StartDate: month(2025, time.June), BillingPeriod: 1}
+
+	t.Run("scheduled", func(t *testing.T) {
+		svc, repo := newTestService(t)
+		repo.EXPECT().SubscriptionByID(gomock.Any(), sub.ID).Return(sub, nil)
+		repo.EXPECT().CreatePriceChange(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, c domain.PriceChange) (domain.PriceChange, error) {
+				if c.SubscriptionID != sub.ID || c.Price != 150 || !c.EffectiveFrom.Equal(month(2026, time.January)) {
+					t.Errorf("CreatePriceChange(%+v)", c)
+				}
+				return c, nil
+			})
+
+		out, err := svc.CreatePriceChange(context.Background(), sub.ID, dto.CreatePriceChangeRequest{Price: 150, EffectiveFrom: "01-2026"})
+		if err != nil || out.EffectiveFrom != "01-2026" {
+			t.Errorf("CreatePriceChange() = %+v, %v", out, err)
+		}
+	})
+
+	t.Run("before the start", func(t *testing.T) {
+		svc, repo := newTestService(t)
+		repo.EXPECT().SubscriptionByID(gomock.Any(), sub.ID).Return(sub, nil)
+
+		_, err := svc.CreatePriceChange(context.Background(), sub.ID, dto.CreatePriceChangeRequest{Price: 150, EffectiveFrom: "05-2025"})
+		if !errors.Is(err, domain.ErrInvalidDate) {
+			t.Errorf("CreatePriceChange() error = %v, want ErrInvalidDate", err)
+		}
+	})
+
+	t.Run("missing subscription", func(t *testing.T) {
+		svc, repo := newTestService(t)
+		repo.EXPECT().SubscriptionByID(gomock.Any(), sub.ID).Return(domain.Subscription{}, domain.ErrNotFound)
+
+		_, err := svc.CreatePriceChange(context.Background(), sub.ID, dto.CreatePriceChangeRequest{Price: 150, EffectiveFrom: "01-2026"})
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("CreatePriceChange() error = %v, want ErrNotFound", err)
+		}
+	})
+}
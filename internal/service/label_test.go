@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestCreateSubscription_Labels(t *testing.T) {
+	netflix := domain.Service{ID: uuid.New(), Name: "Netflix", Category: "streaming"}
+
+	tests := []struct {
+		name         string
+		req          dto.CreateSubscriptionRequest
+		wantCategory string
+		wantTags     []string
+		wantErr      error
+	}{
+		{
+			name:         "category of the catalog service",
+			req:          dto.CreateSubscriptionRequest{ServiceName: "Netflix", Tags: []string{" Work", "family", "work "}},
+			wantCategory: "streaming",
+			wantTags:     []string{"work", "family"},
+		},
+		{
+			name:         "category of the request",
+			req:          dto.CreateSubscriptionRequest{ServiceName: "Netflix", Category: ptr(" Video  Streaming ")},
+			wantCategory: "video streaming",
+		},
+		{
+			name:    "too many tags",
+			req:     dto.CreateSubscriptionRequest{ServiceName: "Netflix", Tags: manyTags(domain.MaxTags + 1)},
+			wantErr: domain.ErrInvalidLabel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo, catalog := mocks.NewMockSubscriptionRepositoryI(ctrl), mocks.NewMockCatalogRepositoryI(ctrl)
+			svc := NewSubscriptionService(repo, nil, nil, CostPolicy{}, zap.NewNop(), WithCatalog(catalog))
+			catalog.EXPECT().ResolveService(gomock.Any(), "Netflix").Return(netflix, nil)
+			if tt.wantErr == nil {
+				repo.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, sub domain.Subscription) (domain.Subscription, error) {
+						if sub.Category != tt.wantCategory || !slices.Equal(sub.Tags, tt.wantTags) {
+							t.Errorf("Category, Tags = %q, %q, want %q, %q", sub.Category, sub.Tags, tt.wantCategory, tt.wantTags)
+						}
+						return sub, nil
+					})
+			}
+
+			tt.req.UserID, tt.req.StartDate, tt.req.Price = uuid.NewString(), "07-2025", 799
+			_, err := svc.CreateSubscription(context.Background(), tt.req)
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) || tt.wantErr == nil && err != nil {
+				t.Errorf("CreateSubscription() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateSubscription_Tags(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("tags are normalized", func(t *testing.T) {
+		svc, repo := newTestService(t)
+		repo.EXPECT().SubscriptionByID(gomock.Any(), id).Return(domain.Subscription{ID: id, Tags: []string{"family"}}, nil)
+		repo.EXPECT().UpdateSubscription(gomock.Any(), id, domain.UpdateSubscription{
+			Category:   ptr("music"),
+			AddTags:    []string{"work"},
+			RemoveTags: []string{"family"},
+		}).Return(domain.Subscription{ID: id, Category: "music", Tags: []string{"work"}}, nil)
+
+		out, err := svc.UpdateSubscription(context.Background(), id, dto.UpdateSubscriptionRequest{
+			Category:   ptr(" Music"),
+			AddTags:    []string{"WORK", " work "},
+			RemoveTags: []string{"Family"},
+		})
+		if err != nil || out.Category != "music" || !slices.Equal(out.Tags, []string{"work"}) {
+			t.Errorf("UpdateSubscription() = %+v, %v", out, err)
+		}
+	})
+
+	t.Run("too many tags", func(t *testing.T) {
+		svc, repo := newTestService(t)
+		repo.EXPECT().SubscriptionByID(gomock.Any(), id).Return(domain.Subscription{ID: id, Tags: manyTags(domain.MaxTags)}, nil)
+
+		_, err := svc.UpdateSubscription(context.Background(), id, dto.UpdateSubscriptionRequest{AddTags: []string{"work"}})
+		if !errors.Is(err, domain.ErrInvalidLabel) {
+			t.Errorf("UpdateSubscription() error = %v, want ErrInvalidLabel", err)
+		}
+	})
+
+	t.Run("removed tags make room", func(t *testing.T) {
+		svc, repo := newTestService(t)
+		tags := manyTags(domain.MaxTags)
+		repo.EXPECT().SubscriptionByID(gomock.Any(), id).Return(domain.Subscription{ID: id, Tags: tags}, nil)
+		repo.EXPECT().UpdateSubscription(gomock.Any(), id, gomock.Any()).Return(domain.Subscription{ID: id}, nil)
+
+		_, err := svc.UpdateSubscription(context.Background(), id, dto.UpdateSubscriptionRequest{
+			AddTags:    []string{"work"},
+			RemoveTags: tags[:1],
+		})
+		if err != nil {
+			t.Errorf("UpdateSubscription() error = %v", err)
+		}
+	})
+}
+
+func TestSubscriptionsCost_GroupBy(t *testing.T) {
+	subs := []domain.Subscription{
+		{ID: uuid.New(), Price: 100, StartDate: month(2025, time.January), Category: "streaming", Tags: []string{"family", "work"}},
+		{ID: uuid.New(), Price: 50, StartDate: month(2025, time.January), Category: "music", Tags: []string{"work"}},
+		{ID: uuid.New(), Price: 10, StartDate: month(2025, time.January), Tags: []string{}},
+	}
+
+	tests := []struct {
+		name    string
+		groupBy *string
+		want    []dto.CostGroupOutput
+	}{
+		{name: "no groups"},
+		{name: "category", groupBy: ptr("category"), want: []dto.CostGroupOutput{{Key: "streaming", Total: 200}, {Key: "music", Total: 100}, {Key: "", Total: 20}}},
+		{name: "tag", groupBy: ptr("tag"), want: []dto.CostGroupOutput{{Key: "work", Total: 300}, {Key: "family", Total: 200}, {Key: "", Total: 20}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newTestService(t)
+			repo.EXPECT().
+				SubscriptionsCost(gomock.Any(), domain.CostRequest{Category: ptr("streaming"), Tags: []string{"work"}}).
+				Return(subs, nil)
+			repo.EXPECT().ScheduledPriceChanges(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+			got, err := svc.SubscriptionsCost(context.Background(), dto.CostRequest{
+				From:     ptr("01-2025"),
+				To:       ptr("03-2025"),
+				Category: ptr("Streaming "),
+				Tags:     []string{" Work", "work"},
+				GroupBy:  tt.groupBy,
+			})
+			if err != nil {
+				t.Fatalf("SubscriptionsCost() error = %v", err)
+			}
+			if got.Total != 320 || !slices.Equal(got.Groups, tt.want) {
+				t.Errorf("SubscriptionsCost() = %d, %+v, want 320, %+v", got.Total, got.Groups, tt.want)
+			}
+		})
+	}
+}
+
+func manyTags(n int) []string {
+	tags := make([]string, n)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("tag %02d", i)
+	}
+	return tags
+}
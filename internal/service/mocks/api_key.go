@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: api_key.go
+//
+// Generated by this command:
+//
+//	mockgen -source=api_key.go -destination=mocks/api_key.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	domain "tz/internal/domain"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPIKeyRepositoryI is a mock of APIKeyRepositoryI interface.
+type MockAPIKeyRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyRepositoryIMockRecorder is the mock recorder for MockAPIKeyRepositoryI.
+type MockAPIKeyRepositoryIMockRecorder struct {
+	mock *MockAPIKeyRepositoryI
+}
+
+// NewMockAPIKeyRepositoryI creates a new mock instance.
+func NewMockAPIKeyRepositoryI(ctrl *gomock.Controller) *MockAPIKeyRepositoryI {
+	mock := &MockAPIKeyRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepositoryI) EXPECT() *MockAPIKeyRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// APIKeyByHash mocks base method.
+func (m *MockAPIKeyRepositoryI) APIKeyByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIKeyByHash", ctx, hash)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APIKeyByHash indicates an expected call of APIKeyByHash.
+func (mr *MockAPIKeyRepositoryIMockRecorder) APIKeyByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIKeyByHash", reflect.TypeOf((*MockAPIKeyRepositoryI)(nil).APIKeyByHash), ctx, hash)
+}
+
+// APIKeys mocks base method.
+func (m *MockAPIKeyRepositoryI) APIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIKeys", ctx)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APIKeys indicates an expected call of APIKeys.
+func (mr *MockAPIKeyRepositoryIMockRecorder) APIKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIKeys", reflect.TypeOf((*MockAPIKeyRepositoryI)(nil).APIKeys), ctx)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeyRepositoryI) CreateAPIKey(ctx context.Context, key domain.APIKey) (domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, key)
+	ret0, _ := ret[0].(domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyRepositoryIMockRecorder) CreateAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeyRepositoryI)(nil).CreateAPIKey), ctx, key)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyRepositoryI) RevokeAPIKey(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyRepositoryIMockRecorder) RevokeAPIKey(ctx, id, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyRepositoryI)(nil).RevokeAPIKey), ctx, id, revokedAt)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockAPIKeyRepositoryI) TouchAPIKey(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", ctx, id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockAPIKeyRepositoryIMockRecorder) TouchAPIKey(ctx, id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockAPIKeyRepositoryI)(nil).TouchAPIKey), ctx, id, usedAt)
+}
@@ -0,0 +1,187 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: budget.go
+//
+// Generated by this command:
+//
+//	mockgen -source=budget.go -destination=mocks/budget.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "tz/internal/domain"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBudgetRepositoryI is a mock of BudgetRepositoryI interface.
+type MockBudgetRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockBudgetRepositoryIMockRecorder is the mock recorder for MockBudgetRepositoryI.
+type MockBudgetRepositoryIMockRecorder struct {
+	mock *MockBudgetRepositoryI
+}
+
+// NewMockBudgetRepositoryI creates a new mock instance.
+func NewMockBudgetRepositoryI(ctrl *gomock.Controller) *MockBudgetRepositoryI {
+	mock := &MockBudgetRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockBudgetRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudgetRepositoryI) EXPECT() *MockBudgetRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// Budget mocks base method.
+func (m *MockBudgetRepositoryI) Budget(ctx context.Context, id uuid.UUID) (domain.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Budget", ctx, id)
+	ret0, _ := ret[0].(domain.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Budget indicates an expected call of Budget.
+func (mr *MockBudgetRepositoryIMockRecorder) Budget(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Budget", reflect.TypeOf((*MockBudgetRepositoryI)(nil).Budget), ctx, id)
+}
+
+// BudgetAlerts mocks base method.
+func (m *MockBudgetRepositoryI) BudgetAlerts(ctx context.Context, budgetID uuid.UUID) ([]domain.BudgetAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BudgetAlerts", ctx, budgetID)
+	ret0, _ := ret[0].([]domain.BudgetAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BudgetAlerts indicates an expected call of BudgetAlerts.
+func (mr *MockBudgetRepositoryIMockRecorder) BudgetAlerts(ctx, budgetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BudgetAlerts", reflect.TypeOf((*MockBudgetRepositoryI)(nil).BudgetAlerts), ctx, budgetID)
+}
+
+// Budgets mocks base method.
+func (m *MockBudgetRepositoryI) Budgets(ctx context.Context, userID *uuid.UUID) ([]domain.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Budgets", ctx, userID)
+	ret0, _ := ret[0].([]domain.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Budgets indicates an expected call of Budgets.
+func (mr *MockBudgetRepositoryIMockRecorder) Budgets(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Budgets", reflect.TypeOf((*MockBudgetRepositoryI)(nil).Budgets), ctx, userID)
+}
+
+// CreateBudget mocks base method.
+func (m *MockBudgetRepositoryI) CreateBudget(ctx context.Context, b domain.Budget) (domain.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBudget", ctx, b)
+	ret0, _ := ret[0].(domain.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBudget indicates an expected call of CreateBudget.
+func (mr *MockBudgetRepositoryIMockRecorder) CreateBudget(ctx, b any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBudget", reflect.TypeOf((*MockBudgetRepositoryI)(nil).CreateBudget), ctx, b)
+}
+
+// CreateBudgetAlert mocks base method.
+func (m *MockBudgetRepositoryI) CreateBudgetAlert(ctx context.Context, alert domain.BudgetAlert) (domain.BudgetAlert, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBudgetAlert", ctx, alert)
+	ret0, _ := ret[0].(domain.BudgetAlert)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateBudgetAlert indicates an expected call of CreateBudgetAlert.
+func (mr *MockBudgetRepositoryIMockRecorder) CreateBudgetAlert(ctx, alert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBudgetAlert", reflect.TypeOf((*MockBudgetRepositoryI)(nil).CreateBudgetAlert), ctx, alert)
+}
+
+// DeleteBudget mocks base method.
+func (m *MockBudgetRepositoryI) DeleteBudget(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBudget", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBudget indicates an expected call of DeleteBudget.
+func (mr *MockBudgetRepositoryIMockRecorder) DeleteBudget(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBudget", reflect.TypeOf((*MockBudgetRepositoryI)(nil).DeleteBudget), ctx, id)
+}
+
+// UpdateBudget mocks base method.
+func (m *MockBudgetRepositoryI) UpdateBudget(ctx context.Context, id uuid.UUID, upd domain.UpdateBudget) (domain.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBudget", ctx, id, upd)
+	ret0, _ := ret[0].(domain.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBudget indicates an expected call of UpdateBudget.
+func (mr *MockBudgetRepositoryIMockRecorder) UpdateBudget(ctx, id, upd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBudget", reflect.TypeOf((*MockBudgetRepositoryI)(nil).UpdateBudget), ctx, id, upd)
+}
+
+// MockSpendI is a mock of SpendI interface.
+type MockSpendI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpendIMockRecorder
+	isgomock struct{}
+}
+
+// MockSpendIMockRecorder is the mock recorder for MockSpendI.
+type MockSpendIMockRecorder struct {
+	mock *MockSpendI
+}
+
+// NewMockSpendI creates a new mock instance.
+func NewMockSpendI(ctrl *gomock.Controller) *MockSpendI {
+	mock := &MockSpendI{ctrl: ctrl}
+	mock.recorder = &MockSpendIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSpendI) EXPECT() *MockSpendIMockRecorder {
+	return m.recorder
+}
+
+// Spend mocks base method.
+func (m *MockSpendI) Spend(ctx context.Context, userID uuid.UUID, serviceNames []string, period domain.BudgetPeriod) (domain.Spend, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Spend", ctx, userID, serviceNames, period)
+	ret0, _ := ret[0].(domain.Spend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Spend indicates an expected call of Spend.
+func (mr *MockSpendIMockRecorder) Spend(ctx, userID, serviceNames, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Spend", reflect.TypeOf((*MockSpendI)(nil).Spend), ctx, userID, serviceNames, period)
+}
@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: catalog.go
+//
+// Generated by this command:
+//
+//	mockgen -source=catalog.go -destination=mocks/catalog.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "tz/internal/domain"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCatalogRepositoryI is a mock of CatalogRepositoryI interface.
+type MockCatalogRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogRepositoryIMockRecorder is the mock recorder for MockCatalogRepositoryI.
+type MockCatalogRepositoryIMockRecorder struct {
+	mock *MockCatalogRepositoryI
+}
+
+// NewMockCatalogRepositoryI creates a new mock instance.
+func NewMockCatalogRepositoryI(ctrl *gomock.Controller) *MockCatalogRepositoryI {
+	mock := &MockCatalogRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockCatalogRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogRepositoryI) EXPECT() *MockCatalogRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// CreateService mocks base method.
+func (m *MockCatalogRepositoryI) CreateService(ctx context.Context, svc domain.Service) (domain.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateService", ctx, svc)
+	ret0, _ := ret[0].(domain.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateService indicates an expected call of CreateService.
+func (mr *MockCatalogRepositoryIMockRecorder) CreateService(ctx, svc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockCatalogRepositoryI)(nil).CreateService), ctx, svc)
+}
+
+// DeleteService mocks base method.
+func (m *MockCatalogRepositoryI) DeleteService(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteService", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteService indicates an expected call of DeleteService.
+func (mr *MockCatalogRepositoryIMockRecorder) DeleteService(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockCatalogRepositoryI)(nil).DeleteService), ctx, id)
+}
+
+// ResolveService mocks base method.
+func (m *MockCatalogRepositoryI) ResolveService(ctx context.Context, name string) (domain.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveService", ctx, name)
+	ret0, _ := ret[0].(domain.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveService indicates an expected call of ResolveService.
+func (mr *MockCatalogRepositoryIMockRecorder) ResolveService(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveService", reflect.TypeOf((*MockCatalogRepositoryI)(nil).ResolveService), ctx, name)
+}
+
+// Service mocks base method.
+func (m *MockCatalogRepositoryI) Service(ctx context.Context, id uuid.UUID) (domain.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Service", ctx, id)
+	ret0, _ := ret[0].(domain.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Service indicates an expected call of Service.
+func (mr *MockCatalogRepositoryIMockRecorder) Service(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Service", reflect.TypeOf((*MockCatalogRepositoryI)(nil).Service), ctx, id)
+}
+
+// Services mocks base method.
+func (m *MockCatalogRepositoryI) Services(ctx context.Context, filter domain.ServiceFilter) ([]domain.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Services", ctx, filter)
+	ret0, _ := ret[0].([]domain.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Services indicates an expected call of Services.
+func (mr *MockCatalogRepositoryIMockRecorder) Services(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Services", reflect.TypeOf((*MockCatalogRepositoryI)(nil).Services), ctx, filter)
+}
+
+// UpdateService mocks base method.
+func (m *MockCatalogRepositoryI) UpdateService(ctx context.Context, id uuid.UUID, upd domain.UpdateService) (domain.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateService", ctx, id, upd)
+	ret0, _ := ret[0].(domain.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateService indicates an expected call of UpdateService.
+func (mr *MockCatalogRepositoryIMockRecorder) UpdateService(ctx, id, upd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockCatalogRepositoryI)(nil).UpdateService), ctx, id, upd)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=outbox.go -destination=mocks/outbox.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "tz/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxI is a mock of OutboxI interface.
+type MockOutboxI struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxIMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxIMockRecorder is the mock recorder for MockOutboxI.
+type MockOutboxIMockRecorder struct {
+	mock *MockOutboxI
+}
+
+// NewMockOutboxI creates a new mock instance.
+func NewMockOutboxI(ctrl *gomock.Controller) *MockOutboxI {
+	mock := &MockOutboxI{ctrl: ctrl}
+	mock.recorder = &MockOutboxIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxI) EXPECT() *MockOutboxIMockRecorder {
+	return m.recorder
+}
+
+// CreateEvent mocks base method.
+func (m *MockOutboxI) CreateEvent(ctx context.Context, e domain.Event) (domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvent", ctx, e)
+	ret0, _ := ret[0].(domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEvent indicates an expected call of CreateEvent.
+func (mr *MockOutboxIMockRecorder) CreateEvent(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockOutboxI)(nil).CreateEvent), ctx, e)
+}
+
+// MockTransactorI is a mock of TransactorI interface.
+type MockTransactorI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorIMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactorIMockRecorder is the mock recorder for MockTransactorI.
+type MockTransactorIMockRecorder struct {
+	mock *MockTransactorI
+}
+
+// NewMockTransactorI creates a new mock instance.
+func NewMockTransactorI(ctrl *gomock.Controller) *MockTransactorI {
+	mock := &MockTransactorI{ctrl: ctrl}
+	mock.recorder = &MockTransactorIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactorI) EXPECT() *MockTransactorIMockRecorder {
+	return m.recorder
+}
+
+// InTx mocks base method.
+func (m *MockTransactorI) InTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockTransactorIMockRecorder) InTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockTransactorI)(nil).InTx), ctx, fn)
+}
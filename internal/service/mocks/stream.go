@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stream.go
+//
+// Generated by this command:
+//
+//	mockgen -source=stream.go -destination=mocks/stream.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "tz/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventLogI is a mock of EventLogI interface.
+type MockEventLogI struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventLogIMockRecorder
+	isgomock struct{}
+}
+
+// MockEventLogIMockRecorder is the mock recorder for MockEventLogI.
+type MockEventLogIMockRecorder struct {
+	mock *MockEventLogI
+}
+
+// NewMockEventLogI creates a new mock instance.
+func NewMockEventLogI(ctrl *gomock.Controller) *MockEventLogI {
+	mock := &MockEventLogI{ctrl: ctrl}
+	mock.recorder = &MockEventLogIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventLogI) EXPECT() *MockEventLogIMockRecorder {
+	return m.recorder
+}
+
+// EventsAfter mocks base method.
+func (m *MockEventLogI) EventsAfter(ctx context.Context, seq int64, limit int) ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventsAfter", ctx, seq, limit)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EventsAfter indicates an expected call of EventsAfter.
+func (mr *MockEventLogIMockRecorder) EventsAfter(ctx, seq, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsAfter", reflect.TypeOf((*MockEventLogI)(nil).EventsAfter), ctx, seq, limit)
+}
+
+// MockBrokerI is a mock of BrokerI interface.
+type MockBrokerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockBrokerIMockRecorder
+	isgomock struct{}
+}
+
+// MockBrokerIMockRecorder is the mock recorder for MockBrokerI.
+type MockBrokerIMockRecorder struct {
+	mock *MockBrokerI
+}
+
+// NewMockBrokerI creates a new mock instance.
+func NewMockBrokerI(ctrl *gomock.Controller) *MockBrokerI {
+	mock := &MockBrokerI{ctrl: ctrl}
+	mock.recorder = &MockBrokerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBrokerI) EXPECT() *MockBrokerIMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockBrokerI) Subscribe() (<-chan domain.Event, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe")
+	ret0, _ := ret[0].(<-chan domain.Event)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockBrokerIMockRecorder) Subscribe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockBrokerI)(nil).Subscribe))
+}
@@ -0,0 +1,304 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: subscription.go
+//
+// Generated by this command:
+//
+//	mockgen -source=subscription.go -destination=mocks/subscription.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	domain "tz/internal/domain"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionRepositoryI is a mock of SubscriptionRepositoryI interface.
+type MockSubscriptionRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionRepositoryIMockRecorder is the mock recorder for MockSubscriptionRepositoryI.
+type MockSubscriptionRepositoryIMockRecorder struct {
+	mock *MockSubscriptionRepositoryI
+}
+
+// NewMockSubscriptionRepositoryI creates a new mock instance.
+func NewMockSubscriptionRepositoryI(ctrl *gomock.Controller) *MockSubscriptionRepositoryI {
+	mock := &MockSubscriptionRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionRepositoryI) EXPECT() *MockSubscriptionRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// CreatePriceChange mocks base method.
+func (m *MockSubscriptionRepositoryI) CreatePriceChange(ctx context.Context, change domain.PriceChange) (domain.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePriceChange", ctx, change)
+	ret0, _ := ret[0].(domain.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePriceChange indicates an expected call of CreatePriceChange.
+func (mr *MockSubscriptionRepositoryIMockRecorder) CreatePriceChange(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePriceChange", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).CreatePriceChange), ctx, change)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockSubscriptionRepositoryI) CreateSubscription(ctx context.Context, sub domain.Subscription) (domain.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, sub)
+	ret0, _ := ret[0].(domain.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockSubscriptionRepositoryIMockRecorder) CreateSubscription(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).CreateSubscription), ctx, sub)
+}
+
+// DeletePriceChange mocks base method.
+func (m *MockSubscriptionRepositoryI) DeletePriceChange(ctx context.Context, subscriptionID, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePriceChange", ctx, subscriptionID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePriceChange indicates an expected call of DeletePriceChange.
+func (mr *MockSubscriptionRepositoryIMockRecorder) DeletePriceChange(ctx, subscriptionID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePriceChange", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).DeletePriceChange), ctx, subscriptionID, id)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockSubscriptionRepositoryI) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockSubscriptionRepositoryIMockRecorder) DeleteSubscription(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).DeleteSubscription), ctx, id)
+}
+
+// DeleteSubscriptions mocks base method.
+func (m *MockSubscriptionRepositoryI) DeleteSubscriptions(ctx context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscriptions", ctx, filter)
+	ret0, _ := ret[0].([]domain.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSubscriptions indicates an expected call of DeleteSubscriptions.
+func (mr *MockSubscriptionRepositoryIMockRecorder) DeleteSubscriptions(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscriptions", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).DeleteSubscriptions), ctx, filter)
+}
+
+// MarkExpiredSubscriptions mocks base method.
+func (m *MockSubscriptionRepositoryI) MarkExpiredSubscriptions(ctx context.Context, today, at time.Time) ([]domain.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkExpiredSubscriptions", ctx, today, at)
+	ret0, _ := ret[0].([]domain.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkExpiredSubscriptions indicates an expected call of MarkExpiredSubscriptions.
+func (mr *MockSubscriptionRepositoryIMockRecorder) MarkExpiredSubscriptions(ctx, today, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkExpiredSubscriptions", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).MarkExpiredSubscriptions), ctx, today, at)
+}
+
+// PriceChanges mocks base method.
+func (m *MockSubscriptionRepositoryI) PriceChanges(ctx context.Context, subscriptionID uuid.UUID) ([]domain.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PriceChanges", ctx, subscriptionID)
+	ret0, _ := ret[0].([]domain.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PriceChanges indicates an expected call of PriceChanges.
+func (mr *MockSubscriptionRepositoryIMockRecorder) PriceChanges(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PriceChanges", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).PriceChanges), ctx, subscriptionID)
+}
+
+// ScheduledPriceChanges mocks base method.
+func (m *MockSubscriptionRepositoryI) ScheduledPriceChanges(ctx context.Context, filter domain.CostRequest) ([]domain.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduledPriceChanges", ctx, filter)
+	ret0, _ := ret[0].([]domain.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduledPriceChanges indicates an expected call of ScheduledPriceChanges.
+func (mr *MockSubscriptionRepositoryIMockRecorder) ScheduledPriceChanges(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduledPriceChanges", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).ScheduledPriceChanges), ctx, filter)
+}
+
+// SubscriptionByID mocks base method.
+func (m *MockSubscriptionRepositoryI) SubscriptionByID(ctx context.Context, id uuid.UUID) (domain.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionByID", ctx, id)
+	ret0, _ := ret[0].(domain.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscriptionByID indicates an expected call of SubscriptionByID.
+func (mr *MockSubscriptionRepositoryIMockRecorder) SubscriptionByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionByID", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).SubscriptionByID), ctx, id)
+}
+
+// SubscriptionStats mocks base method.
+func (m *MockSubscriptionRepositoryI) SubscriptionStats(ctx context.Context, at time.Time) (domain.SubscriptionStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionStats", ctx, at)
+	ret0, _ := ret[0].(domain.SubscriptionStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscriptionStats indicates an expected call of SubscriptionStats.
+func (mr *MockSubscriptionRepositoryIMockRecorder) SubscriptionStats(ctx, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionStats", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).SubscriptionStats), ctx, at)
+}
+
+// Subscriptions mocks base method.
+func (m *MockSubscriptionRepositoryI) Subscriptions(ctx context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscriptions", ctx, filter)
+	ret0, _ := ret[0].([]domain.Subscription)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Subscriptions indicates an expected call of Subscriptions.
+func (mr *MockSubscriptionRepositoryIMockRecorder) Subscriptions(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscriptions", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).Subscriptions), ctx, filter)
+}
+
+// SubscriptionsCost mocks base method.
+func (m *MockSubscriptionRepositoryI) SubscriptionsCost(ctx context.Context, filter domain.CostRequest) ([]domain.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionsCost", ctx, filter)
+	ret0, _ := ret[0].([]domain.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscriptionsCost indicates an expected call of SubscriptionsCost.
+func (mr *MockSubscriptionRepositoryIMockRecorder) SubscriptionsCost(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionsCost", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).SubscriptionsCost), ctx, filter)
+}
+
+// SubscriptionsOfUsers mocks base method.
+func (m *MockSubscriptionRepositoryI) SubscriptionsOfUsers(ctx context.Context, userIDs []uuid.UUID) ([]domain.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionsOfUsers", ctx, userIDs)
+	ret0, _ := ret[0].([]domain.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscriptionsOfUsers indicates an expected call of SubscriptionsOfUsers.
+func (mr *MockSubscriptionRepositoryIMockRecorder) SubscriptionsOfUsers(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionsOfUsers", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).SubscriptionsOfUsers), ctx, userIDs)
+}
+
+// UpcomingSubscriptions mocks base method.
+func (m *MockSubscriptionRepositoryI) UpcomingSubscriptions(ctx context.Context, filter domain.UpcomingFilter) ([]domain.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpcomingSubscriptions", ctx, filter)
+	ret0, _ := ret[0].([]domain.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpcomingSubscriptions indicates an expected call of UpcomingSubscriptions.
+func (mr *MockSubscriptionRepositoryIMockRecorder) UpcomingSubscriptions(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpcomingSubscriptions", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).UpcomingSubscriptions), ctx, filter)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockSubscriptionRepositoryI) UpdateSubscription(ctx context.Context, id uuid.UUID, sub domain.UpdateSubscription) (domain.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", ctx, id, sub)
+	ret0, _ := ret[0].(domain.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockSubscriptionRepositoryIMockRecorder) UpdateSubscription(ctx, id, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockSubscriptionRepositoryI)(nil).UpdateSubscription), ctx, id, sub)
+}
+
+// MockMetricsI is a mock of MetricsI interface.
+type MockMetricsI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsIMockRecorder
+	isgomock struct{}
+}
+
+// MockMetricsIMockRecorder is the mock recorder for MockMetricsI.
+type MockMetricsIMockRecorder struct {
+	mock *MockMetricsI
+}
+
+// NewMockMetricsI creates a new mock instance.
+func NewMockMetricsI(ctrl *gomock.Controller) *MockMetricsI {
+	mock := &MockMetricsI{ctrl: ctrl}
+	mock.recorder = &MockMetricsIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricsI) EXPECT() *MockMetricsIMockRecorder {
+	return m.recorder
+}
+
+// ObserveCostCalculation mocks base method.
+func (m *MockMetricsI) ObserveCostCalculation(duration time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveCostCalculation", duration)
+}
+
+// ObserveCostCalculation indicates an expected call of ObserveCostCalculation.
+func (mr *MockMetricsIMockRecorder) ObserveCostCalculation(duration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveCostCalculation", reflect.TypeOf((*MockMetricsI)(nil).ObserveCostCalculation), duration)
+}
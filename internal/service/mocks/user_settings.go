@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: user_settings.go
+//
+// Generated by this command:
+//
+//	mockgen -source=user_settings.go -destination=mocks/user_settings.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "tz/internal/domain"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserSettingsRepositoryI is a mock of UserSettingsRepositoryI interface.
+type MockUserSettingsRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSettingsRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockUserSettingsRepositoryIMockRecorder is the mock recorder for MockUserSettingsRepositoryI.
+type MockUserSettingsRepositoryIMockRecorder struct {
+	mock *MockUserSettingsRepositoryI
+}
+
+// NewMockUserSettingsRepositoryI creates a new mock instance.
+func NewMockUserSettingsRepositoryI(ctrl *gomock.Controller) *MockUserSettingsRepositoryI {
+	mock := &MockUserSettingsRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockUserSettingsRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSettingsRepositoryI) EXPECT() *MockUserSettingsRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// SaveUserSettings mocks base method.
+func (m *MockUserSettingsRepositoryI) SaveUserSettings(ctx context.Context, settings domain.UserSettings) (domain.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserSettings", ctx, settings)
+	ret0, _ := ret[0].(domain.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveUserSettings indicates an expected call of SaveUserSettings.
+func (mr *MockUserSettingsRepositoryIMockRecorder) SaveUserSettings(ctx, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserSettings", reflect.TypeOf((*MockUserSettingsRepositoryI)(nil).SaveUserSettings), ctx, settings)
+}
+
+// UserSettings mocks base method.
+func (m *MockUserSettingsRepositoryI) UserSettings(ctx context.Context, userID uuid.UUID) (domain.UserSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSettings", ctx, userID)
+	ret0, _ := ret[0].(domain.UserSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserSettings indicates an expected call of UserSettings.
+func (mr *MockUserSettingsRepositoryIMockRecorder) UserSettings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSettings", reflect.TypeOf((*MockUserSettingsRepositoryI)(nil).UserSettings), ctx, userID)
+}
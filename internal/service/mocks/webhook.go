@@ -0,0 +1,306 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook.go -destination=mocks/webhook.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	domain "tz/internal/domain"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookRepositoryI is a mock of WebhookRepositoryI interface.
+type MockWebhookRepositoryI struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryIMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRepositoryIMockRecorder is the mock recorder for MockWebhookRepositoryI.
+type MockWebhookRepositoryIMockRecorder struct {
+	mock *MockWebhookRepositoryI
+}
+
+// NewMockWebhookRepositoryI creates a new mock instance.
+func NewMockWebhookRepositoryI(ctrl *gomock.Controller) *MockWebhookRepositoryI {
+	mock := &MockWebhookRepositoryI{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepositoryI) EXPECT() *MockWebhookRepositoryIMockRecorder {
+	return m.recorder
+}
+
+// CreateDelivery mocks base method.
+func (m *MockWebhookRepositoryI) CreateDelivery(ctx context.Context, d domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelivery", ctx, d)
+	ret0, _ := ret[0].(domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDelivery indicates an expected call of CreateDelivery.
+func (mr *MockWebhookRepositoryIMockRecorder) CreateDelivery(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelivery", reflect.TypeOf((*MockWebhookRepositoryI)(nil).CreateDelivery), ctx, d)
+}
+
+// CreateEvent mocks base method.
+func (m *MockWebhookRepositoryI) CreateEvent(ctx context.Context, e domain.Event) (domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvent", ctx, e)
+	ret0, _ := ret[0].(domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEvent indicates an expected call of CreateEvent.
+func (mr *MockWebhookRepositoryIMockRecorder) CreateEvent(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockWebhookRepositoryI)(nil).CreateEvent), ctx, e)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookRepositoryI) CreateWebhook(ctx context.Context, w domain.Webhook) (domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, w)
+	ret0, _ := ret[0].(domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookRepositoryIMockRecorder) CreateWebhook(ctx, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookRepositoryI)(nil).CreateWebhook), ctx, w)
+}
+
+// DeleteDispatchedEvents mocks base method.
+func (m *MockWebhookRepositoryI) DeleteDispatchedEvents(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDispatchedEvents", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDispatchedEvents indicates an expected call of DeleteDispatchedEvents.
+func (mr *MockWebhookRepositoryIMockRecorder) DeleteDispatchedEvents(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDispatchedEvents", reflect.TypeOf((*MockWebhookRepositoryI)(nil).DeleteDispatchedEvents), ctx, before)
+}
+
+// DeleteFinishedDeliveries mocks base method.
+func (m *MockWebhookRepositoryI) DeleteFinishedDeliveries(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFinishedDeliveries", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFinishedDeliveries indicates an expected call of DeleteFinishedDeliveries.
+func (mr *MockWebhookRepositoryIMockRecorder) DeleteFinishedDeliveries(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFinishedDeliveries", reflect.TypeOf((*MockWebhookRepositoryI)(nil).DeleteFinishedDeliveries), ctx, before)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookRepositoryI) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookRepositoryIMockRecorder) DeleteWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookRepositoryI)(nil).DeleteWebhook), ctx, id)
+}
+
+// Deliveries mocks base method.
+func (m *MockWebhookRepositoryI) Deliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliveries", ctx, filter)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deliveries indicates an expected call of Deliveries.
+func (mr *MockWebhookRepositoryIMockRecorder) Deliveries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliveries", reflect.TypeOf((*MockWebhookRepositoryI)(nil).Deliveries), ctx, filter)
+}
+
+// Delivery mocks base method.
+func (m *MockWebhookRepositoryI) Delivery(ctx context.Context, webhookID, id uuid.UUID) (domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delivery", ctx, webhookID, id)
+	ret0, _ := ret[0].(domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delivery indicates an expected call of Delivery.
+func (mr *MockWebhookRepositoryIMockRecorder) Delivery(ctx, webhookID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delivery", reflect.TypeOf((*MockWebhookRepositoryI)(nil).Delivery), ctx, webhookID, id)
+}
+
+// DueDeliveries mocks base method.
+func (m *MockWebhookRepositoryI) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DueDeliveries", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DueDeliveries indicates an expected call of DueDeliveries.
+func (mr *MockWebhookRepositoryIMockRecorder) DueDeliveries(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DueDeliveries", reflect.TypeOf((*MockWebhookRepositoryI)(nil).DueDeliveries), ctx, now, limit)
+}
+
+// MarkEventDispatched mocks base method.
+func (m *MockWebhookRepositoryI) MarkEventDispatched(ctx context.Context, id uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventDispatched", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventDispatched indicates an expected call of MarkEventDispatched.
+func (mr *MockWebhookRepositoryIMockRecorder) MarkEventDispatched(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventDispatched", reflect.TypeOf((*MockWebhookRepositoryI)(nil).MarkEventDispatched), ctx, id, at)
+}
+
+// UndispatchedEvents mocks base method.
+func (m *MockWebhookRepositoryI) UndispatchedEvents(ctx context.Context, limit int) ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndispatchedEvents", ctx, limit)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndispatchedEvents indicates an expected call of UndispatchedEvents.
+func (mr *MockWebhookRepositoryIMockRecorder) UndispatchedEvents(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndispatchedEvents", reflect.TypeOf((*MockWebhookRepositoryI)(nil).UndispatchedEvents), ctx, limit)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockWebhookRepositoryI) UpdateDelivery(ctx context.Context, d domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", ctx, d)
+	ret0, _ := ret[0].(domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockWebhookRepositoryIMockRecorder) UpdateDelivery(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockWebhookRepositoryI)(nil).UpdateDelivery), ctx, d)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookRepositoryI) UpdateWebhook(ctx context.Context, id uuid.UUID, upd domain.UpdateWebhook) (domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, id, upd)
+	ret0, _ := ret[0].(domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookRepositoryIMockRecorder) UpdateWebhook(ctx, id, upd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookRepositoryI)(nil).UpdateWebhook), ctx, id, upd)
+}
+
+// Webhook mocks base method.
+func (m *MockWebhookRepositoryI) Webhook(ctx context.Context, id uuid.UUID) (domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Webhook", ctx, id)
+	ret0, _ := ret[0].(domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Webhook indicates an expected call of Webhook.
+func (mr *MockWebhookRepositoryIMockRecorder) Webhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Webhook", reflect.TypeOf((*MockWebhookRepositoryI)(nil).Webhook), ctx, id)
+}
+
+// Webhooks mocks base method.
+func (m *MockWebhookRepositoryI) Webhooks(ctx context.Context) ([]domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Webhooks", ctx)
+	ret0, _ := ret[0].([]domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Webhooks indicates an expected call of Webhooks.
+func (mr *MockWebhookRepositoryIMockRecorder) Webhooks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Webhooks", reflect.TypeOf((*MockWebhookRepositoryI)(nil).Webhooks), ctx)
+}
+
+// MockSenderI is a mock of SenderI interface.
+type MockSenderI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSenderIMockRecorder
+	isgomock struct{}
+}
+
+// MockSenderIMockRecorder is the mock recorder for MockSenderI.
+type MockSenderIMockRecorder struct {
+	mock *MockSenderI
+}
+
+// NewMockSenderI creates a new mock instance.
+func NewMockSenderI(ctrl *gomock.Controller) *MockSenderI {
+	mock := &MockSenderI{ctrl: ctrl}
+	mock.recorder = &MockSenderIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSenderI) EXPECT() *MockSenderIMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockSenderI) Send(ctx context.Context, w domain.Webhook, d domain.WebhookDelivery) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, w, d)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockSenderIMockRecorder) Send(ctx, w, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockSenderI)(nil).Send), ctx, w, d)
+}
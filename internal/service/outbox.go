@@ -0,0 +1,79 @@
+package service
+
+//go:generate go tool mockgen -source=outbox.go -destination=mocks/outbox.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+
+	"github.com/google/uuid"
+)
+
+// OutboxI records the events of subscription changes.
+type OutboxI interface {
+	CreateEvent(ctx context.Context, e domain.Event) (domain.Event, error)
+}
+
+// TransactorI runs fn in a database transaction that the repositories join through ctx.
+type TransactorI interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// WithOutbox records subscription lifecycle events in outbox. Each change and its events
+// are stored in one transaction of tx. A nil tx runs them one after another, which is
+// what the memory driver offers.
+func WithOutbox(outbox OutboxI, tx TransactorI) Option {
+	return func(s *SubscriptionService) {
+		s.outbox = outbox
+		s.tx = tx
+	}
+}
+
+// inTx runs fn in a transaction of tx, or directly when tx is nil.
+func inTx(ctx context.Context, tx TransactorI, fn func(ctx context.Context) error) error {
+	if tx == nil {
+		return fn(ctx)
+	}
+	return tx.InTx(ctx, fn)
+}
+
+// emit writes an event about sub to the outbox. It does nothing without an outbox.
+func (s *SubscriptionService) emit(ctx context.Context, eventType domain.EventType, sub domain.Subscription, change *domain.PriceChange) error {
+	if s.outbox == nil {
+		return nil
+	}
+
+	id := uuid.New()
+	now := s.now().UTC()
+	event := dto.SubscriptionEvent{
+		ID:           id.String(),
+		Type:         string(eventType),
+		OccurredAt:   now.Format(time.RFC3339),
+		Subscription: subscriptionToDto(sub, domain.DateFormatDay),
+	}
+	if change != nil {
+		out := priceChangeToDto(*change, domain.DateFormatDay)
+		event.PriceChange = &out
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	_, err = s.outbox.CreateEvent(ctx, domain.Event{
+		ID:             id,
+		Type:           eventType,
+		SubscriptionID: sub.ID,
+		Payload:        payload,
+		CreatedAt:      now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record %s event: %w", eventType, err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+// newOutboxService returns a service whose events are recorded in the returned slice.
+// The transactor runs fn directly and counts the transactions.
+func newOutboxService(t *testing.T, outboxErr error) (*SubscriptionService, *mocks.MockSubscriptionRepositoryI, *[]domain.Event, *int) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockSubscriptionRepositoryI(ctrl)
+	outbox := mocks.NewMockOutboxI(ctrl)
+	tx := mocks.NewMockTransactorI(ctrl)
+
+	var (
+		events []domain.Event
+		txs    int
+	)
+	outbox.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, e domain.Event) (domain.Event, error) {
+			if outboxErr != nil {
+				return domain.Event{}, outboxErr
+			}
+			events = append(events, e)
+			return e, nil
+		})
+	tx.EXPECT().InTx(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			txs++
+			return fn(ctx)
+		})
+
+	now := time.Date(2025, time.November, 8, 10, 0, 0, 0, time.UTC)
+	svc := NewSubscriptionService(repo, nil, nil, CostPolicy{}, zap.NewNop(),
+		WithClock(func() time.Time { return now }), WithOutbox(outbox, tx))
+	return svc, repo, &events, &txs
+}
+
+func eventTypesOf(events []domain.Event) []domain.EventType {
+	types := make([]domain.EventType, len(events))
+	for i, e := range events {
+		types[i] = e.Type
+	}
+	return types
+}
+
+func TestOutboxEvents(t *testing.T) {
+	ctx := context.Background()
+	sub := domain.Subscription{ID: uuid.New(), UserID: uuid.New(), ServiceName: "Netflix", Price: 400, StartDate: month(2025, time.July), BillingPeriod: 1}
+
+	t.Run("create", func(t *testing.T) {
+		svc, repo, events, txs := newOutboxService(t, nil)
+		repo.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, s domain.Subscription) (domain.Subscription, error) { return s, nil })
+
+		out, err := svc.CreateSubscription(ctx, dto.CreateSubscriptionRequest{
+			ServiceName: "Netflix", Price: 400, UserID: sub.UserID.String(), StartDate: "07-2025",
+		})
+		if err != nil {
+			t.Fatalf("CreateSubscription() error = %v", err)
+		}
+		if *txs != 1 || !slices.Equal(eventTypesOf(*events), []domain.EventType{domain.EventCreated}) {
+			t.Fatalf("events = %v in %d transactions, want created in 1", eventTypesOf(*events), *txs)
+		}
+
+		var payload dto.SubscriptionEvent
+		if err := json.Unmarshal((*events)[0].Payload, &payload); err != nil {
+			t.Fatalf("payload is not JSON: %v", err)
+		}
+		if payload.ID != (*events)[0].ID.String() || payload.Type != "created" || payload.OccurredAt != "2025-11-08T10:00:00Z" ||
+			payload.Subscription.ID != out.ID || payload.Subscription.StartDate != "2025-07-01" {
+			t.Errorf("payload = %+v", payload)
+		}
+	})
+
+	t.Run("price update", func(t *testing.T) {
+		svc, repo, events, _ := newOutboxService(t, nil)
+		updated := sub
+		updated.Price = 500
+		repo.EXPECT().SubscriptionByID(gomock.Any(), sub.ID).Return(sub, nil)
+		repo.EXPECT().UpdateSubscription(gomock.Any(), sub.ID, gomock.Any()).Return(updated, nil)
+
+		if _, err := svc.UpdateSubscription(ctx, sub.ID, dto.UpdateSubscriptionRequest{Price: ptr(500)}); err != nil {
+			t.Fatalf("UpdateSubscription() error = %v", err)
+		}
+		if got := eventTypesOf(*events); !slices.Equal(got, []domain.EventType{domain.EventUpdated, domain.EventPriceChanged}) {
+			t.Errorf("events = %v, want updated and price_changed", got)
+		}
+	})
+
+	t.Run("same price", func(t *testing.T) {
+		svc, repo, events, _ := newOutboxService(t, nil)
+		repo.EXPECT().SubscriptionByID(gomock.Any(), sub.ID).Return(sub, nil)
+		repo.EXPECT().UpdateSubscription(gomock.Any(), sub.ID, gomock.Any()).Return(sub, nil)
+
+		if _, err := svc.UpdateSubscription(ctx, sub.ID, dto.UpdateSubscriptionRequest{Price: ptr(400)}); err != nil {
+			t.Fatalf("UpdateSubscription() error = %v", err)
+		}
+		if got := eventTypesOf(*events); !slices.Equal(got, []domain.EventType{domain.EventUpdated}) {
+			t.Errorf("events = %v, want updated", got)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		svc, repo, events, _ := newOutboxService(t, nil)
+		repo.EXPECT().SubscriptionByID(gomock.Any(), sub.ID).Return(sub, nil)
+		repo.EXPECT().DeleteSubscription(gomock.Any(), sub.ID).Return(nil)
+
+		if err := svc.DeleteSubscription(ctx, sub.ID); err != nil {
+			t.Fatalf("DeleteSubscription() error = %v", err)
+		}
+		if len(*events) != 1 || (*events)[0].Type != domain.EventDeleted || (*events)[0].SubscriptionID != sub.ID {
+			t.Errorf("events = %+v, want deleted of the subscription", *events)
+		}
+	})
+
+	t.Run("delete of unknown subscription", func(t *testing.T) {
+		svc, repo, events, _ := newOutboxService(t, nil)
+		repo.EXPECT().SubscriptionByID(gomock.Any(), sub.ID).Return(domain.Subscription{}, domain.ErrNotFound)
+
+		if err := svc.DeleteSubscription(ctx, sub.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("DeleteSubscription() error = %v, want ErrNotFound", err)
+		}
+		if len(*events) != 0 {
+			t.Errorf("events = %v, want none", eventTypesOf(*events))
+		}
+	})
+
+	t.Run("failed event fails the change", func(t *testing.T) {
+		svc, repo, _, _ := newOutboxService(t, errors.New("db is down"))
+		repo.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, s domain.Subscription) (domain.Subscription, error) { return s, nil })
+
+		_, err := svc.CreateSubscription(ctx, dto.CreateSubscriptionRequest{
+			ServiceName: "Netflix", Price: 400, UserID: sub.UserID.String(), StartDate: "07-2025",
+		})
+		if err == nil {
+			t.Error("CreateSubscription() error = nil, want the outbox error")
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Spend returns the cost of the subscriptions of a user in the current budget period,
+// read in the user's time zone. Only services in serviceNames count unless it is empty;
+// the names are resolved through the catalog, so aliases and other spellings match.
+//
+// Spend is billed by the configured cost policy with scheduled price changes applied,
+// so it matches /subscriptions/cost for the same period. Actual runs to now, Forecast
+// to the end of the period with open subscriptions continuing.
+func (s *SubscriptionService) Spend(ctx context.Context, userID uuid.UUID, serviceNames []string, period domain.BudgetPeriod) (domain.Spend, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.Spend")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("user_id", userID.String()))
+
+	loc, err := s.location(ctx, nil, &userID)
+	if err != nil {
+		log.Warn("Failed to resolve time zone", zap.Error(err))
+		return domain.Spend{}, err
+	}
+	policy := s.cost
+	policy.Location = loc
+	matches, err := s.serviceMatcher(ctx, serviceNames)
+	if err != nil {
+		log.Error("Failed to resolve budget services", zap.Error(err))
+		return domain.Spend{}, err
+	}
+
+	filter := domain.CostRequest{UserID: &userID}
+	subs, err := s.repo.SubscriptionsCost(ctx, filter)
+	if err != nil {
+		log.Error("Error getting subscriptions for spend", zap.Error(err))
+		return domain.Spend{}, fmt.Errorf("error getting subscriptions for spend: %w", err)
+	}
+	changes, err := s.priceChanges(ctx, filter)
+	if err != nil {
+		log.Error("Error getting price changes for spend", zap.Error(err))
+		return domain.Spend{}, fmt.Errorf("error getting price changes for spend: %w", err)
+	}
+
+	now := s.now()
+	start := period.Start(wallClock(now.In(loc)))
+	end := period.End(start)
+	endOfPeriod := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc)
+	// An inclusive policy bills the month of the period boundary, which belongs to the
+	// next period, so the period is cut at its last day instead.
+	to := end
+	if policy.EndMonthInclusive {
+		to = end.AddDate(0, 0, -1)
+	}
+
+	spend := domain.Spend{PeriodStart: start, PeriodEnd: end}
+	for _, sub := range subs {
+		if !matches(sub) {
+			continue
+		}
+		spend.Actual += policy.SubscriptionCost(sub, changes[sub.ID], &start, &to, now)
+		spend.Forecast += policy.SubscriptionCost(sub, changes[sub.ID], &start, &to, endOfPeriod)
+	}
+
+	return spend, nil
+}
@@ -0,0 +1,180 @@
+package service
+
+//go:generate go tool mockgen -source=stream.go -destination=mocks/stream.go -package=mocks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"tz/internal/domain"
+	"tz/internal/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// replayBatchSize limits the events read per query when a stream resumes.
+const replayBatchSize = 500
+
+// streamedEvents are the event types sent to event streams.
+var streamedEvents = []domain.EventType{domain.EventCreated, domain.EventUpdated, domain.EventDeleted}
+
+// EventLogI reads the outbox in sequence order.
+type EventLogI interface {
+	EventsAfter(ctx context.Context, seq int64, limit int) ([]domain.Event, error)
+}
+
+// BrokerI hands out the events written from now on. The channel is closed when the
+// subscriber falls behind or the broker stops.
+type BrokerI interface {
+	Subscribe() (<-chan domain.Event, func())
+}
+
+type StreamService struct {
+	events  EventLogI
+	broker  BrokerI
+	catalog CatalogRepositoryI
+	log     *zap.Logger
+}
+
+// NewStreamService resolves service_name filters through catalog; a nil catalog matches
+// the names as given.
+func NewStreamService(events EventLogI, broker BrokerI, catalog CatalogRepositoryI, log *zap.Logger) *StreamService {
+	return &StreamService{events: events, broker: broker, catalog: catalog, log: log}
+}
+
+// StreamEvents returns the created, updated and deleted events matching filter as they
+// happen. With lastEventID, the ID of the last event a client received, the events
+// after it are read from the log first. The channel is closed when ctx is done, when the
+// client falls behind or the log cannot be read; the client then resumes with the ID of
+// the last event it got.
+func (s *StreamService) StreamEvents(ctx context.Context, filter dto.EventStreamFilter, lastEventID string) (<-chan dto.StreamEvent, error) {
+	ctx, span := startSpan(ctx, "StreamService.StreamEvents")
+	defer span.End()
+
+	log := s.loggerWith(ctx)
+
+	var userID *uuid.UUID
+	if filter.UserID != nil {
+		uid, err := uuid.Parse(*filter.UserID)
+		if err != nil {
+			log.Warn("Invalid user_id in event stream filter", zap.String("user_id", *filter.UserID))
+			return nil, fmt.Errorf("%w: invalid user_id", domain.ErrInvalidEventStream)
+		}
+		userID = &uid
+	}
+
+	serviceID, serviceName, err := resolveServiceFilter(ctx, s.catalog, filter.ServiceName)
+	if err != nil {
+		log.Error("Failed to resolve service filter", zap.Error(err))
+		return nil, err
+	}
+
+	var after *int64
+	if lastEventID != "" {
+		seq, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || seq < 0 {
+			log.Warn("Invalid Last-Event-ID", zap.String("last_event_id", lastEventID))
+			return nil, fmt.Errorf("%w: invalid Last-Event-ID %q", domain.ErrInvalidEventStream, lastEventID)
+		}
+		after = &seq
+	}
+
+	// Subscribe before reading the log, so that no event falls between the two. Events
+	// read from both are sent once.
+	live, unsubscribe := s.broker.Subscribe()
+	out := make(chan dto.StreamEvent)
+	match := eventMatcher(userID, serviceID, serviceName, log)
+
+	go func() {
+		defer close(out)
+		defer unsubscribe()
+
+		send := func(e domain.Event) bool {
+			if !match(e) {
+				return true
+			}
+			select {
+			case out <- dto.StreamEvent{ID: e.Seq, Type: string(e.Type), Data: e.Payload}:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var replayed int64
+		if after != nil {
+			for seq := *after; ; {
+				events, err := s.events.EventsAfter(ctx, seq, replayBatchSize)
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Error("Failed to replay events", zap.Int64("after", seq), zap.Error(err))
+					}
+					return
+				}
+				for _, e := range events {
+					if !send(e) {
+						return
+					}
+					seq, replayed = e.Seq, e.Seq
+				}
+				if len(events) < replayBatchSize {
+					break
+				}
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-live:
+				if !ok {
+					log.Info("Event stream closed by the broker")
+					return
+				}
+				if e.Seq <= replayed {
+					continue
+				}
+				if !send(e) {
+					return
+				}
+			}
+		}
+	}()
+
+	log.Info("Event stream opened")
+	return out, nil
+}
+
+func (s *StreamService) loggerWith(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	return s.log.With(append(contextFields(ctx), fields...)...)
+}
+
+// eventMatcher returns whether an event is streamed: its type is one of streamedEvents
+// and its subscription belongs to userID and to serviceID or serviceName when they are set.
+func eventMatcher(userID, serviceID *uuid.UUID, serviceName *string, log *zap.Logger) func(domain.Event) bool {
+	return func(e domain.Event) bool {
+		if !slices.Contains(streamedEvents, e.Type) {
+			return false
+		}
+		if userID == nil && serviceID == nil && serviceName == nil {
+			return true
+		}
+
+		var payload dto.SubscriptionEvent
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			log.Warn("Skipping an event with an unreadable payload", zap.Int64("seq", e.Seq), zap.Error(err))
+			return false
+		}
+		if userID != nil && payload.Subscription.UserID != userID.String() {
+			return false
+		}
+		if serviceID != nil {
+			return payload.Subscription.ServiceID != nil && *payload.Subscription.ServiceID == serviceID.String()
+		}
+		return serviceName == nil || payload.Subscription.ServiceName == *serviceName
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func streamEvent(t *testing.T, seq int64, typ domain.EventType, userID uuid.UUID, serviceName string) domain.Event {
+	t.Helper()
+
+	payload, err := json.Marshal(dto.SubscriptionEvent{
+		Type:         string(typ),
+		Subscription: dto.SubscriptionOutput{UserID: userID.String(), ServiceName: serviceName},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return domain.Event{Seq: seq, ID: uuid.New(), Type: typ, Payload: payload}
+}
+
+// collect reads the stream until it is closed or fails after a second.
+func collect(t *testing.T, events <-chan dto.StreamEvent) []int64 {
+	t.Helper()
+
+	var ids []int64
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return ids
+			}
+			ids = append(ids, e.ID)
+		case <-timeout:
+			t.Fatalf("stream not closed, received %v", ids)
+		}
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+
+	t.Run("replays missed events and skips them live", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		log := mocks.NewMockEventLogI(ctrl)
+		broker := mocks.NewMockBrokerI(ctrl)
+
+		live := make(chan domain.Event, 3)
+		live <- streamEvent(t, 11, domain.EventUpdated, alice, "Netflix")
+		live <- streamEvent(t, 12, domain.EventDeleted, alice, "Netflix")
+		close(live)
+		broker.EXPECT().Subscribe().Return(live, func() {})
+		log.EXPECT().EventsAfter(gomock.Any(), int64(9), replayBatchSize).Return([]domain.Event{
+			streamEvent(t, 10, domain.EventCreated, alice, "Netflix"),
+			streamEvent(t, 11, domain.EventUpdated, alice, "Netflix"),
+		}, nil)
+
+		events, err := NewStreamService(log, broker, nil, zap.NewNop()).StreamEvents(context.Background(), dto.EventStreamFilter{}, "9")
+		if err != nil {
+			t.Fatalf("StreamEvents() error = %v", err)
+		}
+		if got := collect(t, events); !slices.Equal(got, []int64{10, 11, 12}) {
+			t.Errorf("received %v, want [10 11 12]", got)
+		}
+	})
+
+	t.Run("filters by user and service", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		broker := mocks.NewMockBrokerI(ctrl)
+
+		live := make(chan domain.Event, 5)
+		live <- streamEvent(t, 1, domain.EventCreated, alice, "Netflix")
+		live <- streamEvent(t, 2, domain.EventCreated, bob, "Netflix")
+		live <- streamEvent(t, 3, domain.EventCreated, alice, "Spotify")
+		live <- streamEvent(t, 4, domain.EventPriceChanged, alice, "Netflix")
+		live <- streamEvent(t, 5, domain.EventDeleted, alice, "Netflix")
+		close(live)
+		unsubscribed := make(chan struct{})
+		broker.EXPECT().Subscribe().Return(live, func() { close(unsubscribed) })
+
+		user := alice.String()
+		events, err := NewStreamService(mocks.NewMockEventLogI(ctrl), broker, nil, zap.NewNop()).
+			StreamEvents(context.Background(), dto.EventStreamFilter{UserID: &user, ServiceName: ptr("Netflix")}, "")
+		if err != nil {
+			t.Fatalf("StreamEvents() error = %v", err)
+		}
+		if got := collect(t, events); !slices.Equal(got, []int64{1, 5}) {
+			t.Errorf("received %v, want [1 5]", got)
+		}
+		<-unsubscribed
+	})
+
+	t.Run("filters by the catalog service of the name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		broker := mocks.NewMockBrokerI(ctrl)
+		catalog := mocks.NewMockCatalogRepositoryI(ctrl)
+		netflix := domain.Service{ID: uuid.New(), Name: "Netflix"}
+		catalog.EXPECT().ResolveService(gomock.Any(), "netflix").Return(netflix, nil)
+
+		linked := streamEvent(t, 2, domain.EventCreated, alice, "Netflix")
+		payload, err := json.Marshal(dto.SubscriptionEvent{
+			Type:         string(domain.EventCreated),
+			Subscription: dto.SubscriptionOutput{UserID: alice.String(), ServiceID: ptr(netflix.ID.String()), ServiceName: "Netflix"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		linked.Payload = payload
+
+		live := make(chan domain.Event, 2)
+		live <- streamEvent(t, 1, domain.EventCreated, alice, "netflix")
+		live <- linked
+		close(live)
+		broker.EXPECT().Subscribe().Return(live, func() {})
+
+		events, err := NewStreamService(mocks.NewMockEventLogI(ctrl), broker, catalog, zap.NewNop()).
+			StreamEvents(context.Background(), dto.EventStreamFilter{ServiceName: ptr(" netflix")}, "")
+		if err != nil {
+			t.Fatalf("StreamEvents() error = %v", err)
+		}
+		if got := collect(t, events); !slices.Equal(got, []int64{2}) {
+			t.Errorf("received %v, want [2]", got)
+		}
+	})
+
+	t.Run("closes when the context is done", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		broker := mocks.NewMockBrokerI(ctrl)
+		broker.EXPECT().Subscribe().Return(make(chan domain.Event), func() {})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := NewStreamService(mocks.NewMockEventLogI(ctrl), broker, nil, zap.NewNop()).StreamEvents(ctx, dto.EventStreamFilter{}, "")
+		if err != nil {
+			t.Fatalf("StreamEvents() error = %v", err)
+		}
+		cancel()
+		if got := collect(t, events); len(got) != 0 {
+			t.Errorf("received %v, want none", got)
+		}
+	})
+
+	t.Run("closes when the replay fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		log := mocks.NewMockEventLogI(ctrl)
+		broker := mocks.NewMockBrokerI(ctrl)
+		broker.EXPECT().Subscribe().Return(make(chan domain.Event), func() {})
+		log.EXPECT().EventsAfter(gomock.Any(), int64(0), replayBatchSize).Return(nil, errors.New("db down"))
+
+		events, err := NewStreamService(log, broker, nil, zap.NewNop()).StreamEvents(context.Background(), dto.EventStreamFilter{}, "0")
+		if err != nil {
+			t.Fatalf("StreamEvents() error = %v", err)
+		}
+		if got := collect(t, events); len(got) != 0 {
+			t.Errorf("received %v, want none", got)
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		filter      dto.EventStreamFilter
+		lastEventID string
+	}{
+		"invalid user_id":        {filter: dto.EventStreamFilter{UserID: ptr("42")}},
+		"invalid Last-Event-ID":  {lastEventID: "abc"},
+		"negative Last-Event-ID": {lastEventID: "-1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			svc := NewStreamService(mocks.NewMockEventLogI(ctrl), mocks.NewMockBrokerI(ctrl), nil, zap.NewNop())
+			if _, err := svc.StreamEvents(context.Background(), tc.filter, tc.lastEventID); !errors.Is(err, domain.ErrInvalidEventStream) {
+				t.Errorf("StreamEvents() error = %v, want ErrInvalidEventStream", err)
+			}
+		})
+	}
+}
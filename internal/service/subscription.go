@@ -1,11 +1,13 @@
 package service
 
+//go:generate go tool mockgen -source=subscription.go -destination=mocks/subscription.go -package=mocks
+
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
-	"strings"
+	"slices"
 	"time"
 	contextkeys "tz/internal/contextkey"
 	"tz/internal/domain"
@@ -19,21 +21,79 @@ type SubscriptionRepositoryI interface {
 	CreateSubscription(ctx context.Context, sub domain.Subscription) (domain.Subscription, error)
 	SubscriptionByID(ctx context.Context, id uuid.UUID) (domain.Subscription, error)
 	Subscriptions(ctx context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, int, error)
+	SubscriptionsOfUsers(ctx context.Context, userIDs []uuid.UUID) ([]domain.Subscription, error)
 	SubscriptionsCost(ctx context.Context, filter domain.CostRequest) ([]domain.Subscription, error)
 	UpdateSubscription(ctx context.Context, id uuid.UUID, sub domain.UpdateSubscription) (domain.Subscription, error)
 	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	DeleteSubscriptions(ctx context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, error)
+	SubscriptionStats(ctx context.Context, at time.Time) (domain.SubscriptionStats, error)
+	UpcomingSubscriptions(ctx context.Context, filter domain.UpcomingFilter) ([]domain.Subscription, error)
+	MarkExpiredSubscriptions(ctx context.Context, today, at time.Time) ([]domain.Subscription, error)
+	CreatePriceChange(ctx context.Context, change domain.PriceChange) (domain.PriceChange, error)
+	PriceChanges(ctx context.Context, subscriptionID uuid.UUID) ([]domain.PriceChange, error)
+	ScheduledPriceChanges(ctx context.Context, filter domain.CostRequest) ([]domain.PriceChange, error)
+	DeletePriceChange(ctx context.Context, subscriptionID, id uuid.UUID) error
+}
+
+type MetricsI interface {
+	ObserveCostCalculation(duration time.Duration)
 }
 
+// Clock returns the current time.
+type Clock func() time.Time
+
 type SubscriptionService struct {
-	repo SubscriptionRepositoryI
-	log  *zap.Logger
+	repo    SubscriptionRepositoryI
+	users   UserSettingsRepositoryI
+	metrics MetricsI
+	cost    CostPolicy
+	now     Clock
+	log     *zap.Logger
+	// outbox is nil when lifecycle events are not recorded.
+	outbox OutboxI
+	tx     TransactorI
+	// catalog is nil when service names are stored as given.
+	catalog CatalogRepositoryI
 }
 
-func NewSubscriptionService(repo SubscriptionRepositoryI, log *zap.Logger) *SubscriptionService {
-	return &SubscriptionService{repo: repo, log: log}
+// Option configures optional SubscriptionService collaborators.
+type Option func(*SubscriptionService)
+
+// WithClock replaces time.Now, so that "now" in cost calculations is deterministic.
+func WithClock(clock Clock) Option {
+	return func(s *SubscriptionService) {
+		s.now = clock
+	}
+}
+
+// NewSubscriptionService builds the service. A nil metrics disables instrumentation.
+// A nil users repository disables per-user time zones.
+func NewSubscriptionService(
+	repo SubscriptionRepositoryI,
+	users UserSettingsRepositoryI,
+	metrics MetricsI,
+	cost CostPolicy,
+	log *zap.Logger,
+	opts ...Option,
+) *SubscriptionService {
+	if metrics == nil {
+		metrics = nopMetrics{}
+	}
+	s := &SubscriptionService{repo: repo, users: users, metrics: metrics, cost: cost, now: time.Now, log: log}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveCostCalculation(time.Duration) {}
+
 func (s *SubscriptionService) CreateSubscription(ctx context.Context, req dto.CreateSubscriptionRequest) (dto.SubscriptionOutput, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.CreateSubscription")
+	defer span.End()
+
 	log := s.loggerWith(ctx, zap.String("user_id", req.UserID), zap.String("service_name", req.ServiceName))
 
 	startDate, err := parseDate(req.StartDate)
@@ -62,27 +122,74 @@ func (s *SubscriptionService) CreateSubscription(ctx context.Context, req dto.Cr
 		return dto.SubscriptionOutput{}, fmt.Errorf("invalid user_id: %w", err)
 	}
 
+	billingPeriod := 1
+	if req.BillingPeriodMonths != nil {
+		billingPeriod = *req.BillingPeriodMonths
+	}
+
+	svc, err := s.resolveService(ctx, req.ServiceID, req.ServiceName)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidService) {
+			log.Warn("Invalid service", zap.Error(err))
+			return dto.SubscriptionOutput{}, err
+		}
+		log.Error("Failed to resolve service", zap.Error(err))
+		return dto.SubscriptionOutput{}, fmt.Errorf("failed to resolve service: %w", err)
+	}
+	price := req.Price
+	if price == 0 {
+		price = svc.DefaultPrice
+	}
+	if price == 0 {
+		log.Warn("Missing price", zap.String("service", svc.Name))
+		return dto.SubscriptionOutput{}, fmt.Errorf("%w: price is required, %q has no default price", domain.ErrInvalidService, svc.Name)
+	}
+
+	category := domain.NormalizeLabel(svc.Category)
+	if req.Category != nil {
+		category = domain.NormalizeLabel(*req.Category)
+	}
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		log.Warn("Invalid tags", zap.Error(err))
+		return dto.SubscriptionOutput{}, err
+	}
+
 	id := uuid.New()
 	subscription := domain.Subscription{
-		ID:          id,
-		ServiceName: req.ServiceName,
-		Price:       req.Price,
-		StartDate:   startDate,
-		EndDate:     endDate,
-		UserID:      userID,
+		ID:            id,
+		ServiceID:     serviceIDOf(svc),
+		ServiceName:   svc.Name,
+		Category:      category,
+		Tags:          tags,
+		Price:         price,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		UserID:        userID,
+		BillingPeriod: billingPeriod,
 	}
 
-	subscriptionDB, err := s.repo.CreateSubscription(ctx, subscription)
+	var subscriptionDB domain.Subscription
+	err = inTx(ctx, s.tx, func(ctx context.Context) error {
+		var err error
+		if subscriptionDB, err = s.repo.CreateSubscription(ctx, subscription); err != nil {
+			return err
+		}
+		return s.emit(ctx, domain.EventCreated, subscriptionDB, nil)
+	})
 	if err != nil {
 		log.Error("Failed to create subscription", zap.Error(err))
 		return dto.SubscriptionOutput{}, fmt.Errorf("failed to create subscription: %w", err)
 	}
 
 	log.Info("Subscription created", zap.String("subscription_id", subscriptionDB.ID.String()), zap.String("created_at", subscriptionDB.CreatedAt.Format(time.DateOnly)))
-	return subscriptionToDto(subscriptionDB), nil
+	return subscriptionToDto(subscriptionDB, contextkeys.DateFormat(ctx)), nil
 }
 
 func (s *SubscriptionService) SubscriptionByID(ctx context.Context, id uuid.UUID) (dto.SubscriptionOutput, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.SubscriptionByID")
+	defer span.End()
+
 	log := s.loggerWith(ctx, zap.String("subscription_id", id.String()))
 
 	subscriptionDB, err := s.repo.SubscriptionByID(ctx, id)
@@ -95,10 +202,13 @@ func (s *SubscriptionService) SubscriptionByID(ctx context.Context, id uuid.UUID
 		return dto.SubscriptionOutput{}, fmt.Errorf("failed to get subscription: %w", err)
 	}
 
-	return subscriptionToDto(subscriptionDB), nil
+	return subscriptionToDto(subscriptionDB, contextkeys.DateFormat(ctx)), nil
 }
 
 func (s *SubscriptionService) Subscriptions(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionsOutput, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.Subscriptions")
+	defer span.End()
+
 	log := s.loggerWith(ctx)
 	var userID *uuid.UUID
 	if filter.UserID != nil {
@@ -110,9 +220,23 @@ func (s *SubscriptionService) Subscriptions(ctx context.Context, filter dto.Subs
 		userID = &uid
 	}
 
+	tags, err := normalizeTags(filter.Tags)
+	if err != nil {
+		log.Warn("Invalid tags in filter", zap.Error(err))
+		return dto.SubscriptionsOutput{}, err
+	}
+	serviceID, serviceName, err := s.serviceFilter(ctx, filter.ServiceName)
+	if err != nil {
+		log.Error("Failed to resolve service filter", zap.Error(err))
+		return dto.SubscriptionsOutput{}, err
+	}
+
 	subscriptionsDB, total, err := s.repo.Subscriptions(ctx, domain.SubscriptionFilter{
 		UserID:      userID,
-		ServiceName: filter.ServiceName,
+		ServiceID:   serviceID,
+		ServiceName: serviceName,
+		Category:    normalizeCategory(filter.Category),
+		Tags:        tags,
 		Limit:       filter.PageSize,
 		Offset:      (filter.Page - 1) * filter.PageSize,
 	})
@@ -124,32 +248,75 @@ func (s *SubscriptionService) Subscriptions(ctx context.Context, filter dto.Subs
 
 	subscriptions := make([]dto.SubscriptionOutput, len(subscriptionsDB))
 	for i, subscription := range subscriptionsDB {
-		subscriptions[i] = subscriptionToDto(subscription)
+		subscriptions[i] = subscriptionToDto(subscription, contextkeys.DateFormat(ctx))
 	}
 
 	log.Info("Subscriptions fetched", zap.Int("count", len(subscriptions)), zap.Int("total", total))
 	return dto.MakeSubscriptionsOutput(subscriptions, total, filter.Page, filter.PageSize), nil
 }
 
-func (s *SubscriptionService) SubscriptionsCost(ctx context.Context, filter dto.CostRequest) (int, error) {
+// SubscriptionsOfUsers returns the subscriptions of each of the users, oldest first, in one
+// query. Users without subscriptions have no entry.
+func (s *SubscriptionService) SubscriptionsOfUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]dto.SubscriptionOutput, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.SubscriptionsOfUsers")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.Int("users", len(userIDs)))
+
+	subscriptionsDB, err := s.repo.SubscriptionsOfUsers(ctx, userIDs)
+	if err != nil {
+		log.Error("Failed to fetch subscriptions of users", zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch subscriptions of users: %w", err)
+	}
+
+	format := contextkeys.DateFormat(ctx)
+	subscriptions := make(map[uuid.UUID][]dto.SubscriptionOutput)
+	for _, subscription := range subscriptionsDB {
+		subscriptions[subscription.UserID] = append(subscriptions[subscription.UserID], subscriptionToDto(subscription, format))
+	}
+
+	log.Debug("Subscriptions of users fetched", zap.Int("count", len(subscriptionsDB)))
+	return subscriptions, nil
+}
+
+func (s *SubscriptionService) SubscriptionsCost(ctx context.Context, filter dto.CostRequest) (dto.CostOutput, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.SubscriptionsCost")
+	defer span.End()
+
 	log := s.loggerWith(ctx)
+	defer func(start time.Time) {
+		s.metrics.ObserveCostCalculation(time.Since(start))
+	}(time.Now())
+
+	policy := s.cost.Override(filter)
+	if err := policy.Validate(); err != nil {
+		log.Warn("Invalid cost policy", zap.Error(err))
+		return dto.CostOutput{}, err
+	}
 
 	var userID *uuid.UUID
 	if filter.UserID != nil {
 		uid, err := uuid.Parse(*filter.UserID)
 		if err != nil {
 			log.Warn("Invalid user_id in filter", zap.String("user_id", *filter.UserID), zap.Error(err))
-			return 0, fmt.Errorf("invalid user_id in filter: %w", err)
+			return dto.CostOutput{}, fmt.Errorf("invalid user_id in filter: %w", err)
 		}
 		userID = &uid
 	}
 
+	loc, err := s.location(ctx, filter.TimeZone, userID)
+	if err != nil {
+		log.Warn("Failed to resolve time zone", zap.Error(err))
+		return dto.CostOutput{}, err
+	}
+	policy.Location = loc
+
 	var startDate, endDate *time.Time
 	if filter.From != nil {
 		sd, err := parseDate(*filter.From)
 		if err != nil {
 			log.Warn("Invalid start_date in update", zap.Error(err))
-			return 0, fmt.Errorf("invalid start_date: %w", err)
+			return dto.CostOutput{}, fmt.Errorf("invalid start_date: %w", err)
 		}
 		startDate = &sd
 	}
@@ -157,51 +324,109 @@ func (s *SubscriptionService) SubscriptionsCost(ctx context.Context, filter dto.
 		ed, err := parseDate(*filter.To)
 		if err != nil {
 			log.Warn("Invalid end_date in update", zap.Error(err))
-			return 0, fmt.Errorf("invalid end_date: %w", err)
+			return dto.CostOutput{}, fmt.Errorf("invalid end_date: %w", err)
 		}
 		endDate = &ed
 	}
 
-	subs, err := s.repo.SubscriptionsCost(ctx, domain.CostRequest{
-		ServiceName: filter.ServiceName,
+	tags, err := normalizeTags(filter.Tags)
+	if err != nil {
+		log.Warn("Invalid tags in filter", zap.Error(err))
+		return dto.CostOutput{}, err
+	}
+	serviceID, serviceName, err := s.serviceFilter(ctx, filter.ServiceName)
+	if err != nil {
+		log.Error("Failed to resolve service filter", zap.Error(err))
+		return dto.CostOutput{}, err
+	}
+
+	costFilter := domain.CostRequest{
+		ServiceID:   serviceID,
+		ServiceName: serviceName,
 		UserID:      userID,
-	})
+		Category:    normalizeCategory(filter.Category),
+		Tags:        tags,
+	}
+	subs, err := s.repo.SubscriptionsCost(ctx, costFilter)
 	if err != nil {
 		log.Error("Error getting subscriptions cost", zap.Error(err))
-		return 0, fmt.Errorf("error getting subscriptions cost: %w", err)
+		return dto.CostOutput{}, fmt.Errorf("error getting subscriptions cost: %w", err)
+	}
+	changes, err := s.priceChanges(ctx, costFilter)
+	if err != nil {
+		log.Error("Error getting price changes for cost", zap.Error(err))
+		return dto.CostOutput{}, fmt.Errorf("error getting price changes for cost: %w", err)
 	}
 
 	total := 0
-	now := time.Now()
+	groups := make(map[string]int)
+	now := s.now()
 	for _, sub := range subs {
-		// Определяем реальный период активности подписки
-		actualStart := sub.StartDate
-		actualEnd := now
-		if sub.EndDate != nil {
-			actualEnd = *sub.EndDate
+		cost := policy.SubscriptionCost(sub, changes[sub.ID], startDate, endDate, now)
+		total += cost
+		if filter.GroupBy != nil {
+			for _, key := range groupKeys(sub, *filter.GroupBy) {
+				groups[key] += cost
+			}
 		}
+	}
 
-		calcStart := actualStart
-		if startDate != nil {
-			calcStart = maxTime(actualStart, *startDate)
-		}
+	out := dto.CostOutput{Total: total, Policy: policy.toDto()}
+	if filter.GroupBy != nil {
+		out.Groups = costGroups(groups)
+	}
+	return out, nil
+}
 
-		calcEnd := actualEnd
-		if endDate != nil {
-			calcEnd = minTime(actualEnd, *endDate)
-		}
+// groupKeys returns the cost groups of a subscription: its category, or each of its
+// tags. A subscription without any counts in the group "".
+func groupKeys(sub domain.Subscription, groupBy string) []string {
+	switch {
+	case groupBy == "category":
+		return []string{sub.Category}
+	case len(sub.Tags) == 0:
+		return []string{""}
+	default:
+		return sub.Tags
+	}
+}
 
-		if calcEnd.Before(calcStart) {
-			continue
-		}
+// costGroups orders the cost groups by total, largest first, then by key.
+func costGroups(groups map[string]int) []dto.CostGroupOutput {
+	out := make([]dto.CostGroupOutput, 0, len(groups))
+	for key, total := range groups {
+		out = append(out, dto.CostGroupOutput{Key: key, Total: total})
+	}
+	slices.SortFunc(out, func(a, b dto.CostGroupOutput) int {
+		return cmp.Or(cmp.Compare(b.Total, a.Total), cmp.Compare(a.Key, b.Key))
+	})
+	return out
+}
+
+// location resolves the time zone of a cost request: the tz parameter, then the saved
+// zone of the filtered user, then the configured default.
+func (s *SubscriptionService) location(ctx context.Context, tz *string, userID *uuid.UUID) (*time.Location, error) {
+	if tz != nil {
+		return LoadLocation(*tz)
+	}
 
-		months := monthsBetween(calcStart, calcEnd)
-		total += sub.Price * months
+	if userID != nil && s.users != nil {
+		settings, err := s.users.UserSettings(ctx, *userID)
+		switch {
+		case err == nil:
+			return LoadLocation(settings.TimeZone)
+		case !errors.Is(err, domain.ErrUserSettingsNotFound):
+			return nil, fmt.Errorf("failed to get user settings: %w", err)
+		}
 	}
 
-	return total, nil
+	return s.cost.location(), nil
 }
+
 func (s *SubscriptionService) UpdateSubscription(ctx context.Context, id uuid.UUID, req dto.UpdateSubscriptionRequest) (dto.SubscriptionOutput, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.UpdateSubscription")
+	defer span.End()
+
 	log := s.loggerWith(ctx, zap.String("subscription_id", id.String()))
 
 	var startDate, endDate *time.Time
@@ -227,29 +452,103 @@ func (s *SubscriptionService) UpdateSubscription(ctx context.Context, id uuid.UU
 		return dto.SubscriptionOutput{}, fmt.Errorf("end_date must be after start_date. End  date: %s, Start date: %s", *endDate, *startDate)
 	}
 
-	subscriptionDB, err := s.repo.UpdateSubscription(ctx, id, domain.UpdateSubscription{
-		Price:       req.Price,
-		ServiceName: req.ServiceName,
-		StartDate:   startDate,
-		EndDate:     endDate,
+	upd := domain.UpdateSubscription{
+		Price:         req.Price,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		BillingPeriod: req.BillingPeriodMonths,
+	}
+	if req.ServiceID != nil || req.ServiceName != nil {
+		var name string
+		if req.ServiceName != nil {
+			name = *req.ServiceName
+		}
+		svc, err := s.resolveService(ctx, req.ServiceID, name)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidService) {
+				log.Warn("Invalid service in update", zap.Error(err))
+				return dto.SubscriptionOutput{}, err
+			}
+			log.Error("Failed to resolve service", zap.Error(err))
+			return dto.SubscriptionOutput{}, fmt.Errorf("failed to resolve service: %w", err)
+		}
+		upd.ServiceName, upd.ServiceID = &svc.Name, serviceIDOf(svc)
+	}
+	upd.Category = normalizeCategory(req.Category)
+	var err error
+	if upd.AddTags, err = normalizeTags(req.AddTags); err != nil {
+		log.Warn("Invalid tags in update", zap.Error(err))
+		return dto.SubscriptionOutput{}, err
+	}
+	if upd.RemoveTags, err = normalizeTags(req.RemoveTags); err != nil {
+		log.Warn("Invalid tags in update", zap.Error(err))
+		return dto.SubscriptionOutput{}, err
+	}
+
+	var subscriptionDB domain.Subscription
+	err = inTx(ctx, s.tx, func(ctx context.Context) error {
+		var before domain.Subscription
+		if (s.outbox != nil && req.Price != nil) || len(upd.AddTags) > 0 {
+			var err error
+			if before, err = s.repo.SubscriptionByID(ctx, id); err != nil {
+				return err
+			}
+		}
+		if len(upd.AddTags) > 0 && len(tagsAfter(before.Tags, upd)) > domain.MaxTags {
+			return fmt.Errorf("%w: a subscription has at most %d tags", domain.ErrInvalidLabel, domain.MaxTags)
+		}
+
+		var err error
+		subscriptionDB, err = s.repo.UpdateSubscription(ctx, id, upd)
+		if err != nil {
+			return err
+		}
+
+		if err := s.emit(ctx, domain.EventUpdated, subscriptionDB, nil); err != nil {
+			return err
+		}
+		if req.Price != nil && before.Price != subscriptionDB.Price {
+			return s.emit(ctx, domain.EventPriceChanged, subscriptionDB, nil)
+		}
+		return nil
 	})
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			log.Warn("Subscription not found")
 			return dto.SubscriptionOutput{}, fmt.Errorf("subscription not found: %w", domain.ErrNotFound)
 		}
+		if errors.Is(err, domain.ErrInvalidLabel) {
+			log.Warn("Too many tags in update", zap.Error(err))
+			return dto.SubscriptionOutput{}, err
+		}
 		log.Error("Failed to update subscription", zap.Error(err))
 		return dto.SubscriptionOutput{}, fmt.Errorf("failed to update subscription: %w", err)
 	}
 
 	log.Info("Subscription updated")
-	return subscriptionToDto(subscriptionDB), nil
+	return subscriptionToDto(subscriptionDB, contextkeys.DateFormat(ctx)), nil
 }
 
 func (s *SubscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "SubscriptionService.DeleteSubscription")
+	defer span.End()
+
 	log := s.loggerWith(ctx, zap.String("subscription_id", id.String()))
 
-	if err := s.repo.DeleteSubscription(ctx, id); err != nil {
+	err := inTx(ctx, s.tx, func(ctx context.Context) error {
+		var sub domain.Subscription
+		if s.outbox != nil {
+			var err error
+			if sub, err = s.repo.SubscriptionByID(ctx, id); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.DeleteSubscription(ctx, id); err != nil {
+			return err
+		}
+		return s.emit(ctx, domain.EventDeleted, sub, nil)
+	})
+	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			log.Warn("Subscription not found")
 			return fmt.Errorf("subscription not found: %w", domain.ErrNotFound)
@@ -262,74 +561,165 @@ func (s *SubscriptionService) DeleteSubscription(ctx context.Context, id uuid.UU
 	return nil
 }
 
-func (s *SubscriptionService) loggerWith(ctx context.Context, fields ...zap.Field) *zap.Logger {
-	requestID := ctx.Value(contextkeys.RequestIDKey)
-	return s.log.With(append([]zap.Field{zap.String("request_id", requestID.(string))}, fields...)...)
-}
+// PurgeSubscriptions deletes every subscription matching the filter and returns how many were removed.
+func (s *SubscriptionService) PurgeSubscriptions(ctx context.Context, req dto.PurgeRequest) (int, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.PurgeSubscriptions")
+	defer span.End()
 
-func subscriptionToDto(s domain.Subscription) dto.SubscriptionOutput {
-	var endDate *string
-	if s.EndDate != nil {
-		ed := parseTimeToString(*s.EndDate)
-		endDate = &ed
+	log := s.loggerWith(ctx)
+
+	var userID *uuid.UUID
+	if req.UserID != nil {
+		uid, err := uuid.Parse(*req.UserID)
+		if err != nil {
+			log.Warn("Invalid user_id in purge", zap.String("user_id", *req.UserID), zap.Error(err))
+			return 0, fmt.Errorf("invalid user_id in filter: %w", err)
+		}
+		userID = &uid
 	}
-	return dto.SubscriptionOutput{
-		ID:          s.ID.String(),
-		ServiceName: s.ServiceName,
-		Price:       s.Price,
-		StartDate:   parseTimeToString(s.StartDate),
-		EndDate:     endDate,
-		UserID:      s.UserID.String(),
-		CreatedAt:   s.CreatedAt.Format(time.DateTime),
-		UpdatedAt:   s.UpdatedAt.Format(time.DateTime),
+
+	serviceID, serviceName, err := s.serviceFilter(ctx, req.ServiceName)
+	if err != nil {
+		log.Error("Failed to resolve service filter", zap.Error(err))
+		return 0, err
 	}
-}
 
-func maxTime(a, b time.Time) time.Time {
-	if a.After(b) {
-		return a
+	var deleted []domain.Subscription
+	err = inTx(ctx, s.tx, func(ctx context.Context) error {
+		var err error
+		deleted, err = s.repo.DeleteSubscriptions(ctx, domain.SubscriptionFilter{
+			UserID:      userID,
+			ServiceID:   serviceID,
+			ServiceName: serviceName,
+		})
+		if err != nil {
+			return err
+		}
+		for _, sub := range deleted {
+			if err := s.emit(ctx, domain.EventDeleted, sub, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to purge subscriptions", zap.Error(err))
+		return 0, fmt.Errorf("failed to purge subscriptions: %w", err)
 	}
-	return b
+
+	log.Info("Subscriptions purged", zap.Int("count", len(deleted)))
+	return len(deleted), nil
 }
 
-func minTime(a, b time.Time) time.Time {
-	if a.Before(b) {
-		return a
+// MarkExpiredSubscriptions marks the subscriptions whose end date is today or earlier,
+// in the configured cost time zone, and returns how many were newly marked.
+func (s *SubscriptionService) MarkExpiredSubscriptions(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.MarkExpiredSubscriptions")
+	defer span.End()
+
+	now := s.now()
+	wall := wallClock(now.In(s.cost.location()))
+	today := time.Date(wall.Year(), wall.Month(), wall.Day(), 0, 0, 0, 0, time.UTC)
+
+	var marked []domain.Subscription
+	err := inTx(ctx, s.tx, func(ctx context.Context) error {
+		var err error
+		if marked, err = s.repo.MarkExpiredSubscriptions(ctx, today, now.UTC()); err != nil {
+			return err
+		}
+		for _, sub := range marked {
+			if err := s.emit(ctx, domain.EventExpired, sub, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.loggerWith(ctx).Error("Failed to mark expired subscriptions", zap.Error(err))
+		return 0, fmt.Errorf("failed to mark expired subscriptions: %w", err)
 	}
-	return b
+
+	s.loggerWith(ctx).Info("Expired subscriptions marked", zap.Int("marked", len(marked)))
+	return len(marked), nil
 }
 
-func monthsBetween(start, end time.Time) int {
-	start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
-	end = time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, end.Location())
+func (s *SubscriptionService) SubscriptionStats(ctx context.Context) (domain.SubscriptionStats, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.SubscriptionStats")
+	defer span.End()
 
-	if end.Before(start) {
-		return 0
+	stats, err := s.repo.SubscriptionStats(ctx, s.now())
+	if err != nil {
+		return domain.SubscriptionStats{}, fmt.Errorf("failed to get subscription stats: %w", err)
 	}
-
-	return (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	return stats, nil
 }
 
-func parseDate(dateStr string) (time.Time, error) {
-	parts := strings.Split(dateStr, "-")
-	if len(parts) != 2 {
-		return time.Time{}, fmt.Errorf("%w: %v", domain.ErrInvalidDateFormat, dateStr)
+// normalizeCategory normalizes an optional category, see domain.NormalizeLabel.
+func normalizeCategory(category *string) *string {
+	if category == nil {
+		return nil
 	}
-	month, err := strconv.Atoi(parts[0])
+	normalized := domain.NormalizeLabel(*category)
+	return &normalized
+}
+
+// normalizeTags normalizes the tags of a request, see domain.NormalizeLabels, and
+// rejects more than domain.MaxTags.
+func normalizeTags(tags []string) ([]string, error) {
+	tags, err := domain.NormalizeLabels(tags)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("%w: %v", domain.ErrInvalidDate, dateStr)
+		return nil, err
 	}
-	year, err := strconv.Atoi(parts[1])
-	if err != nil {
-		return time.Time{}, fmt.Errorf("%w: %v", domain.ErrInvalidDate, dateStr)
+	if len(tags) > domain.MaxTags {
+		return nil, fmt.Errorf("%w: a subscription has at most %d tags", domain.ErrInvalidLabel, domain.MaxTags)
 	}
-	if month < 1 || month > 12 {
-		return time.Time{}, fmt.Errorf("%w: %v", domain.ErrInvalidDate, dateStr)
+	return tags, nil
+}
+
+// tagsAfter returns the tags a subscription has after upd.
+func tagsAfter(tags []string, upd domain.UpdateSubscription) []string {
+	after := slices.Clone(tags)
+	for _, tag := range upd.AddTags {
+		if !slices.Contains(after, tag) {
+			after = append(after, tag)
+		}
 	}
+	return slices.DeleteFunc(after, func(tag string) bool { return slices.Contains(upd.RemoveTags, tag) })
+}
 
-	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), nil
+func (s *SubscriptionService) loggerWith(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	return s.log.With(append(contextFields(ctx), fields...)...)
 }
 
-func parseTimeToString(t time.Time) string {
-	return fmt.Sprintf("%02d-%d", t.Month(), t.Year())
+func subscriptionToDto(s domain.Subscription, format domain.DateFormat) dto.SubscriptionOutput {
+	var endDate *string
+	if s.EndDate != nil {
+		ed := formatDate(*s.EndDate, format)
+		endDate = &ed
+	}
+	var expiredAt *string
+	if s.ExpiredAt != nil {
+		ea := s.ExpiredAt.Format(time.DateTime)
+		expiredAt = &ea
+	}
+	var serviceID *string
+	if s.ServiceID != nil {
+		id := s.ServiceID.String()
+		serviceID = &id
+	}
+	return dto.SubscriptionOutput{
+		ID:                  s.ID.String(),
+		ServiceID:           serviceID,
+		ServiceName:         s.ServiceName,
+		Category:            s.Category,
+		Tags:                s.Tags,
+		Price:               s.Price,
+		StartDate:           formatDate(s.StartDate, format),
+		EndDate:             endDate,
+		UserID:              s.UserID.String(),
+		BillingPeriodMonths: s.BillingPeriod,
+		ExpiredAt:           expiredAt,
+		CreatedAt:           s.CreatedAt.Format(time.DateTime),
+		UpdatedAt:           s.UpdatedAt.Format(time.DateTime),
+	}
 }
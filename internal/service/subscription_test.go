@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	contextkeys "tz/internal/contextkey"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func newTestService(t *testing.T) (*SubscriptionService, *mocks.MockSubscriptionRepositoryI) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockSubscriptionRepositoryI(ctrl)
+	return NewSubscriptionService(repo, nil, nil, CostPolicy{}, zap.NewNop()), repo
+}
+
+func TestSubscriptionsCost(t *testing.T) {
+	userID := uuid.New()
+	closed := func(price int, start, end time.Time) domain.Subscription {
+		return domain.Subscription{ID: uuid.New(), UserID: userID, Price: price, StartDate: start, EndDate: &end}
+	}
+	open := func(price int, start time.Time) domain.Subscription {
+		return domain.Subscription{ID: uuid.New(), UserID: userID, Price: price, StartDate: start}
+	}
+
+	tests := []struct {
+		name     string
+		subs     []domain.Subscription
+		from, to *string
+		want     int
+	}{
+		{
+			name: "no period uses the whole subscription",
+			subs: []domain.Subscription{closed(100, month(2025, time.January), month(2025, time.June))},
+			want: 500,
+		},
+		{
+			name: "period clips the start",
+			subs: []domain.Subscription{closed(100, month(2025, time.January), month(2025, time.June))},
+			from: ptr("03-2025"),
+			want: 300,
+		},
+		{
+			name: "period clips the end",
+			subs: []domain.Subscription{closed(100, month(2025, time.January), month(2025, time.June))},
+			to:   ptr("04-2025"),
+			want: 300,
+		},
+		{
+			name: "period inside the subscription",
+			subs: []domain.Subscription{closed(100, month(2025, time.January), month(2025, time.June))},
+			from: ptr("02-2025"),
+			to:   ptr("04-2025"),
+			want: 200,
+		},
+		{
+			name: "subscription inside the period",
+			subs: []domain.Subscription{closed(100, month(2025, time.March), month(2025, time.May))},
+			from: ptr("01-2025"),
+			to:   ptr("12-2025"),
+			want: 200,
+		},
+		{
+			name: "period before the subscription",
+			subs: []domain.Subscription{closed(100, month(2025, time.June), month(2025, time.December))},
+			from: ptr("01-2025"),
+			to:   ptr("03-2025"),
+			want: 0,
+		},
+		{
+			name: "period after the subscription",
+			subs: []domain.Subscription{closed(100, month(2025, time.January), month(2025, time.March))},
+			from: ptr("06-2025"),
+			to:   ptr("12-2025"),
+			want: 0,
+		},
+		{
+			name: "open subscription ends at the period end",
+			subs: []domain.Subscription{open(100, month(2024, time.October))},
+			to:   ptr("01-2025"),
+			want: 300,
+		},
+		{
+			name: "several subscriptions are summed",
+			subs: []domain.Subscription{
+				closed(100, month(2025, time.January), month(2025, time.April)),
+				open(50, month(2025, time.February)),
+			},
+			from: ptr("01-2025"),
+			to:   ptr("05-2025"),
+			want: 300 + 150,
+		},
+		{
+			name: "no subscriptions",
+			from: ptr("01-2025"),
+			to:   ptr("05-2025"),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newTestService(t)
+			repo.EXPECT().
+				SubscriptionsCost(gomock.Any(), domain.CostRequest{UserID: &userID, ServiceName: ptr("Netflix")}).
+				Return(tt.subs, nil)
+			repo.EXPECT().
+				ScheduledPriceChanges(gomock.Any(), domain.CostRequest{UserID: &userID, ServiceName: ptr("Netflix")}).
+				Return(nil, nil)
+
+			got, err := svc.SubscriptionsCost(context.Background(), dto.CostRequest{
+				UserID:      ptr(userID.String()),
+				ServiceName: ptr("Netflix"),
+				From:        tt.from,
+				To:          tt.to,
+			})
+			if err != nil {
+				t.Fatalf("SubscriptionsCost() error = %v", err)
+			}
+			if got.Total != tt.want {
+				t.Errorf("SubscriptionsCost() = %d, want %d", got.Total, tt.want)
+			}
+			if got.Policy != (dto.CostPolicyOutput{Proration: "month", TimeZone: "UTC"}) {
+				t.Errorf("SubscriptionsCost() policy = %+v", got.Policy)
+			}
+		})
+	}
+}
+
+func TestSubscriptionsCost_Errors(t *testing.T) {
+	repoErr := errors.New("connection refused")
+
+	tests := []struct {
+		name    string
+		req     dto.CostRequest
+		repoErr error
+		wantErr error
+	}{
+		{name: "invalid user id", req: dto.CostRequest{UserID: ptr("not-a-uuid")}},
+		{name: "invalid from", req: dto.CostRequest{From: ptr("13-2025")}, wantErr: domain.ErrInvalidDate},
+		{name: "invalid to", req: dto.CostRequest{To: ptr("2025")}, wantErr: domain.ErrInvalidDateFormat},
+		{name: "invalid proration", req: dto.CostRequest{Proration: ptr("week")}, wantErr: domain.ErrInvalidCostPolicy},
+		{name: "repository failure", repoErr: repoErr, wantErr: repoErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newTestService(t)
+			if tt.repoErr != nil {
+				repo.EXPECT().SubscriptionsCost(gomock.Any(), gomock.Any()).Return(nil, tt.repoErr)
+			}
+
+			_, err := svc.SubscriptionsCost(context.Background(), tt.req)
+			if err == nil {
+				t.Fatal("SubscriptionsCost() error = nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("SubscriptionsCost() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateSubscription(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("dates are parsed to month starts", func(t *testing.T) {
+		svc, repo := newTestService(t)
+		repo.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, sub domain.Subscription) (domain.Subscription, error) {
+				if !sub.StartDate.Equal(month(2025, time.July)) {
+					t.Errorf("StartDate = %v", sub.StartDate)
+				}
+				if sub.EndDate == nil || !sub.EndDate.Equal(month(2025, time.December)) {
+					t.Errorf("EndDate = %v", sub.EndDate)
+				}
+				if sub.BillingPeriod != 1 {
+					t.Errorf("BillingPeriod = %d, want the monthly default", sub.BillingPeriod)
+				}
+				if sub.UserID != userID || sub.ID == uuid.Nil {
+					t.Errorf("ids are not set: %+v", sub)
+				}
+				return sub, nil
+			})
+
+		out, err := svc.CreateSubscription(context.Background(), dto.CreateSubscriptionRequest{
+			ServiceName: "Yandex Plus",
+			Price:       400,
+			UserID:      userID.String(),
+			StartDate:   "07-2025",
+			EndDate:     ptr("12-2025"),
+		})
+		if err != nil {
+			t.Fatalf("CreateSubscription() error = %v", err)
+		}
+		if out.StartDate != "07-2025" || out.EndDate == nil || *out.EndDate != "12-2025" {
+			t.Errorf("CreateSubscription() = %+v", out)
+		}
+	})
+
+	t.Run("day dates keep the day", func(t *testing.T) {
+		svc, repo := newTestService(t)
+		repo.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, sub domain.Subscription) (domain.Subscription, error) {
+				if !sub.StartDate.Equal(time.Date(2025, time.July, 15, 0, 0, 0, 0, time.UTC)) {
+					t.Errorf("StartDate = %v", sub.StartDate)
+				}
+				return sub, nil
+			}).Times(2)
+
+		req := dto.CreateSubscriptionRequest{ServiceName: "Yandex Plus", Price: 400, UserID: userID.String(), StartDate: "2025-07-15"}
+		out, err := svc.CreateSubscription(contextkeys.WithDateFormat(context.Background(), domain.DateFormatDay), req)
+		if err != nil || out.StartDate != "2025-07-15" {
+			t.Errorf("CreateSubscription() in day format = %+v, %v", out, err)
+		}
+		out, err = svc.CreateSubscription(context.Background(), req)
+		if err != nil || out.StartDate != "07-2025" {
+			t.Errorf("CreateSubscription() in month format = %+v, %v", out, err)
+		}
+	})
+
+	invalid := []struct {
+		name string
+		req  dto.CreateSubscriptionRequest
+	}{
+		{"bad start date", dto.CreateSubscriptionRequest{UserID: userID.String(), StartDate: "2025"}},
+		{"bad end date", dto.CreateSubscriptionRequest{UserID: userID.String(), StartDate: "07-2025", EndDate: ptr("13-2025")}},
+		{"end before start", dto.CreateSubscriptionRequest{UserID: userID.String(), StartDate: "07-2025", EndDate: ptr("06-2025")}},
+		{"end day before start day", dto.CreateSubscriptionRequest{UserID: userID.String(), StartDate: "2025-07-15", EndDate: ptr("2025-07-14")}},
+		{"bad user id", dto.CreateSubscriptionRequest{UserID: "42", StartDate: "07-2025"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestService(t)
+			if _, err := svc.CreateSubscription(context.Background(), tt.req); err == nil {
+				t.Error("CreateSubscription() error = nil")
+			}
+		})
+	}
+}
+
+func TestSubscriptions_Pagination(t *testing.T) {
+	svc, repo := newTestService(t)
+	repo.EXPECT().
+		Subscriptions(gomock.Any(), domain.SubscriptionFilter{Limit: 10, Offset: 20}).
+		Return([]domain.Subscription{{ID: uuid.New(), StartDate: month(2025, time.January)}}, 31, nil)
+
+	out, err := svc.Subscriptions(context.Background(), dto.SubscriptionFilter{Page: 3, PageSize: 10})
+	if err != nil {
+		t.Fatalf("Subscriptions() error = %v", err)
+	}
+	if out.Total != 31 || !out.HasNextPage || !out.HasPrevPage || len(out.Subscriptions) != 1 {
+		t.Errorf("Subscriptions() = %+v", out)
+	}
+}
+
+func TestSubscriptionsOfUsers(t *testing.T) {
+	svc, repo := newTestService(t)
+	alice, bob := uuid.New(), uuid.New()
+	repo.EXPECT().SubscriptionsOfUsers(gomock.Any(), []uuid.UUID{alice, bob}).Return([]domain.Subscription{
+		{ID: uuid.New(), UserID: alice, StartDate: time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		{ID: uuid.New(), UserID: alice, StartDate: month(2025, time.March)},
+	}, nil)
+
+	ctx := contextkeys.WithDateFormat(context.Background(), domain.DateFormatDay)
+	out, err := svc.SubscriptionsOfUsers(ctx, []uuid.UUID{alice, bob})
+	if err != nil {
+		t.Fatalf("SubscriptionsOfUsers() error = %v", err)
+	}
+	if len(out) != 1 || len(out[alice]) != 2 || out[alice][0].StartDate != "2025-01-15" {
+		t.Errorf("SubscriptionsOfUsers() = %+v, want two subscriptions of alice in day format", out)
+	}
+}
+
+func TestNotFoundIsPropagated(t *testing.T) {
+	id := uuid.New()
+	svc, repo := newTestService(t)
+	repo.EXPECT().SubscriptionByID(gomock.Any(), id).Return(domain.Subscription{}, domain.ErrNotFound)
+	repo.EXPECT().UpdateSubscription(gomock.Any(), id, gomock.Any()).Return(domain.Subscription{}, domain.ErrNotFound)
+	repo.EXPECT().DeleteSubscription(gomock.Any(), id).Return(domain.ErrNotFound)
+
+	ctx := context.Background()
+	if _, err := svc.SubscriptionByID(ctx, id); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("SubscriptionByID() error = %v", err)
+	}
+	if _, err := svc.UpdateSubscription(ctx, id, dto.UpdateSubscriptionRequest{Price: ptr(1)}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("UpdateSubscription() error = %v", err)
+	}
+	if err := svc.DeleteSubscription(ctx, id); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("DeleteSubscription() error = %v", err)
+	}
+}
+
+func TestMarkExpiredSubscriptions(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	// 22:30 UTC on October 31st is already November 1st in Moscow.
+	now := time.Date(2025, time.October, 31, 22, 30, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockSubscriptionRepositoryI(ctrl)
+	svc := NewSubscriptionService(repo, nil, nil, CostPolicy{Location: moscow}, zap.NewNop(),
+		WithClock(func() time.Time { return now }))
+
+	repo.EXPECT().MarkExpiredSubscriptions(gomock.Any(), month(2025, time.November), now).Return(make([]domain.Subscription, 3), nil)
+	if marked, err := svc.MarkExpiredSubscriptions(context.Background()); err != nil || marked != 3 {
+		t.Errorf("MarkExpiredSubscriptions() = %d, %v, want 3", marked, err)
+	}
+
+	repo.EXPECT().MarkExpiredSubscriptions(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db is down"))
+	if _, err := svc.MarkExpiredSubscriptions(context.Background()); err == nil {
+		t.Error("MarkExpiredSubscriptions() error = nil, want the repository error")
+	}
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"fmt"
+	"time"
+	"tz/internal/domain"
+)
+
+// LoadLocation resolves an IANA time zone name. An empty name is UTC. "Local" is
+// rejected because it depends on the machine the service runs on.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, fmt.Errorf("%w: %q", domain.ErrInvalidTimeZone, name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", domain.ErrInvalidTimeZone, name)
+	}
+	return loc, nil
+}
+
+// wallClock returns the wall clock reading of t in its location as a UTC time.
+// Subscription dates are calendar dates stored at midnight UTC, so comparing them with
+// a wall clock reading compares calendar days of the caller's zone.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestLoadLocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "", want: "UTC"},
+		{name: "UTC", want: "UTC"},
+		{name: "Asia/Vladivostok", want: "Asia/Vladivostok"},
+		{name: "Local", wantErr: true},
+		{name: "Mars/Olympus", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := LoadLocation(tt.name)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidTimeZone) {
+					t.Fatalf("LoadLocation(%q) error = %v, want ErrInvalidTimeZone", tt.name, err)
+				}
+				return
+			}
+			if err != nil || loc.String() != tt.want {
+				t.Errorf("LoadLocation(%q) = %v, %v, want %s", tt.name, loc, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionsCost_TimeZone(t *testing.T) {
+	// 15:00 UTC on October 31 is already November 1 in Vladivostok (UTC+10).
+	now := time.Date(2025, time.October, 31, 15, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	sub := domain.Subscription{ID: uuid.New(), UserID: userID, Price: 100, StartDate: month(2025, time.September)}
+
+	tests := []struct {
+		name     string
+		tz       *string
+		saved    *string
+		want     int
+		wantZone string
+		wantErr  error
+	}{
+		{name: "default zone", want: 100, wantZone: "UTC"},
+		{name: "request zone", tz: ptr("Asia/Vladivostok"), want: 200, wantZone: "Asia/Vladivostok"},
+		{name: "user zone", saved: ptr("Asia/Vladivostok"), want: 200, wantZone: "Asia/Vladivostok"},
+		{name: "request overrides user", tz: ptr("UTC"), saved: ptr("Asia/Vladivostok"), want: 100, wantZone: "UTC"},
+		{name: "zone west of UTC", tz: ptr("America/New_York"), want: 100, wantZone: "America/New_York"},
+		{name: "invalid zone", tz: ptr("Mars/Olympus"), wantErr: domain.ErrInvalidTimeZone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockSubscriptionRepositoryI(ctrl)
+			users := mocks.NewMockUserSettingsRepositoryI(ctrl)
+			svc := NewSubscriptionService(repo, users, nil, CostPolicy{}, zap.NewNop(),
+				WithClock(func() time.Time { return now }))
+
+			if tt.tz == nil {
+				settings, err := domain.UserSettings{}, domain.ErrUserSettingsNotFound
+				if tt.saved != nil {
+					settings, err = domain.UserSettings{UserID: userID, TimeZone: *tt.saved}, nil
+				}
+				users.EXPECT().UserSettings(gomock.Any(), userID).Return(settings, err)
+			}
+			if tt.wantErr == nil {
+				repo.EXPECT().SubscriptionsCost(gomock.Any(), gomock.Any()).Return([]domain.Subscription{sub}, nil)
+				repo.EXPECT().ScheduledPriceChanges(gomock.Any(), gomock.Any()).Return(nil, nil)
+			}
+
+			got, err := svc.SubscriptionsCost(context.Background(), dto.CostRequest{UserID: ptr(userID.String()), TimeZone: tt.tz})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("SubscriptionsCost() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SubscriptionsCost() error = %v", err)
+			}
+			if got.Total != tt.want || got.Policy.TimeZone != tt.wantZone {
+				t.Errorf("SubscriptionsCost() = %d in %s, want %d in %s", got.Total, got.Policy.TimeZone, tt.want, tt.wantZone)
+			}
+		})
+	}
+}
+
+func TestSubscriptionsCost_UserSettingsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	users := mocks.NewMockUserSettingsRepositoryI(ctrl)
+	svc := NewSubscriptionService(mocks.NewMockSubscriptionRepositoryI(ctrl), users, nil, CostPolicy{}, zap.NewNop())
+
+	repoErr := errors.New("connection refused")
+	users.EXPECT().UserSettings(gomock.Any(), gomock.Any()).Return(domain.UserSettings{}, repoErr)
+
+	if _, err := svc.SubscriptionsCost(context.Background(), dto.CostRequest{UserID: ptr(uuid.NewString())}); !errors.Is(err, repoErr) {
+		t.Errorf("SubscriptionsCost() error = %v, want %v", err, repoErr)
+	}
+}
+
+func TestUpdateUserSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockUserSettingsRepositoryI(ctrl)
+	svc := NewUserSettingsService(repo, zap.NewNop())
+	userID := uuid.New()
+
+	repo.EXPECT().
+		SaveUserSettings(gomock.Any(), domain.UserSettings{UserID: userID, TimeZone: "Europe/Moscow"}).
+		DoAndReturn(func(_ context.Context, s domain.UserSettings) (domain.UserSettings, error) {
+			return s, nil
+		})
+
+	out, err := svc.UpdateUserSettings(context.Background(), userID, dto.UpdateUserSettingsRequest{TimeZone: "Europe/Moscow"})
+	if err != nil || out.TimeZone != "Europe/Moscow" || out.UserID != userID.String() {
+		t.Errorf("UpdateUserSettings() = %+v, %v", out, err)
+	}
+
+	if _, err := svc.UpdateUserSettings(context.Background(), userID, dto.UpdateUserSettingsRequest{TimeZone: "Moscow"}); !errors.Is(err, domain.ErrInvalidTimeZone) {
+		t.Errorf("UpdateUserSettings() error = %v, want ErrInvalidTimeZone", err)
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	contextkeys "tz/internal/contextkey"
+	"tz/internal/tracing"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindInternal))
+}
+
+// contextFields returns the request and trace identifiers found in ctx.
+// Contexts created outside HTTP requests simply carry fewer fields.
+func contextFields(ctx context.Context) []zap.Field {
+	var fields []zap.Field
+	if requestID, ok := contextkeys.RequestID(ctx); ok {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+	return append(fields, tracing.LogFields(ctx)...)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	contextkeys "tz/internal/contextkey"
+	"tz/internal/domain"
+	"tz/internal/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// defaultUpcomingMonths is the window length when the request does not set one.
+const defaultUpcomingMonths = 1
+
+// Upcoming lists the subscriptions that renew or reach their end date from today, in the
+// request time zone, for the next Within months. A renewal is a charge of Charge, dated
+// on the start day of the subscription or the last day of shorter months. Scheduled
+// price changes are applied to the expected charge.
+func (s *SubscriptionService) Upcoming(ctx context.Context, req dto.UpcomingRequest) (dto.UpcomingOutput, error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.Upcoming")
+	defer span.End()
+
+	log := s.loggerWith(ctx)
+
+	var userID *uuid.UUID
+	if req.UserID != nil {
+		uid, err := uuid.Parse(*req.UserID)
+		if err != nil {
+			log.Warn("Invalid user_id in upcoming", zap.String("user_id", *req.UserID), zap.Error(err))
+			return dto.UpcomingOutput{}, fmt.Errorf("invalid user_id in filter: %w", err)
+		}
+		userID = &uid
+	}
+
+	loc, err := s.location(ctx, req.TimeZone, userID)
+	if err != nil {
+		log.Warn("Failed to resolve time zone", zap.Error(err))
+		return dto.UpcomingOutput{}, err
+	}
+
+	within := req.Within
+	if within == 0 {
+		within = defaultUpcomingMonths
+	}
+
+	now := wallClock(s.now().In(loc))
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, within, 0)
+
+	serviceID, serviceName, err := s.serviceFilter(ctx, req.ServiceName)
+	if err != nil {
+		log.Error("Failed to resolve service filter", zap.Error(err))
+		return dto.UpcomingOutput{}, err
+	}
+
+	subs, err := s.repo.UpcomingSubscriptions(ctx, domain.UpcomingFilter{
+		UserID: userID, ServiceID: serviceID, ServiceName: serviceName, From: from, To: to,
+	})
+	if err != nil {
+		log.Error("Error getting upcoming subscriptions", zap.Error(err))
+		return dto.UpcomingOutput{}, fmt.Errorf("error getting upcoming subscriptions: %w", err)
+	}
+	changes, err := s.priceChanges(ctx, domain.CostRequest{UserID: userID, ServiceID: serviceID, ServiceName: serviceName})
+	if err != nil {
+		log.Error("Error getting price changes for upcoming", zap.Error(err))
+		return dto.UpcomingOutput{}, fmt.Errorf("error getting price changes for upcoming: %w", err)
+	}
+
+	type upcoming struct {
+		item dto.UpcomingSubscription
+		next time.Time
+	}
+	var found []upcoming
+	format := contextkeys.DateFormat(ctx)
+	for _, sub := range subs {
+		var u upcoming
+		for month := monthStart(from); month.Before(to); month = month.AddDate(0, 1, 0) {
+			charge := s.cost.Charge(sub, changes[sub.ID], month)
+			day := renewalDate(sub.StartDate, month)
+			if charge == 0 || day.Before(from) || !day.Before(to) {
+				continue
+			}
+			if u.item.Renewals == 0 {
+				next := formatDate(day, format)
+				u.item.NextRenewal = &next
+				u.next = day
+			}
+			u.item.Renewals++
+			u.item.ExpectedCharge += charge
+		}
+		if sub.EndDate != nil && !sub.EndDate.Before(from) && sub.EndDate.Before(to) {
+			ends := formatDate(*sub.EndDate, format)
+			u.item.EndsOn = &ends
+			if u.next.IsZero() || sub.EndDate.Before(u.next) {
+				u.next = *sub.EndDate
+			}
+		}
+		if u.next.IsZero() {
+			continue
+		}
+		u.item.Subscription = subscriptionToDto(sub, format)
+		found = append(found, u)
+	}
+	slices.SortStableFunc(found, func(a, b upcoming) int {
+		return cmp.Compare(a.next.Unix(), b.next.Unix())
+	})
+
+	out := dto.UpcomingOutput{
+		Subscriptions: make([]dto.UpcomingSubscription, len(found)),
+		From:          formatDate(from, domain.DateFormatDay),
+		To:            formatDate(to, domain.DateFormatDay),
+		TimeZone:      loc.String(),
+	}
+	for i, u := range found {
+		out.Subscriptions[i] = u.item
+		out.ExpectedTotal += u.item.ExpectedCharge
+	}
+
+	log.Info("Upcoming subscriptions listed", zap.Int("within", within), zap.Int("subscriptions", len(found)))
+	return out, nil
+}
+
+// renewalDate returns the day of month on which a subscription started at start renews,
+// clamped to the last day of month.
+func renewalDate(start, month time.Time) time.Time {
+	last := month.AddDate(0, 1, -1).Day()
+	return time.Date(month.Year(), month.Month(), min(start.Day(), last), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	contextkeys "tz/internal/contextkey"
+	"tz/internal/domain"
+	"tz/internal/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpcoming(t *testing.T) {
+	day := func(year int, m time.Month, d int) time.Time { return time.Date(year, m, d, 0, 0, 0, 0, time.UTC) }
+	ends := month(2025, time.December)
+	monthly := domain.Subscription{ID: uuid.New(), Price: 100, StartDate: day(2025, time.June, 15), BillingPeriod: 1}
+	clamped := domain.Subscription{ID: uuid.New(), Price: 10, StartDate: day(2025, time.January, 31), BillingPeriod: 1}
+	yearly := domain.Subscription{ID: uuid.New(), Price: 1200, StartDate: day(2025, time.January, 31), BillingPeriod: 12}
+	ending := domain.Subscription{ID: uuid.New(), Price: 50, StartDate: month(2025, time.January), EndDate: &ends, BillingPeriod: 1}
+	change := domain.PriceChange{SubscriptionID: yearly.ID, Price: 1500, EffectiveFrom: month(2026, time.January)}
+
+	svc, repo := newTestService(t)
+	svc.now = func() time.Time { return time.Date(2025, time.November, 20, 12, 0, 0, 0, time.UTC) }
+	repo.EXPECT().UpcomingSubscriptions(gomock.Any(), domain.UpcomingFilter{From: day(2025, time.November, 20), To: day(2026, time.February, 20)}).
+		Return([]domain.Subscription{monthly, clamped, yearly, ending}, nil)
+	repo.EXPECT().ScheduledPriceChanges(gomock.Any(), domain.CostRequest{}).Return([]domain.PriceChange{change}, nil)
+
+	ctx := contextkeys.WithDateFormat(context.Background(), domain.DateFormatDay)
+	got, err := svc.Upcoming(ctx, dto.UpcomingRequest{Within: 3})
+	if err != nil {
+		t.Fatalf("Upcoming() error = %v", err)
+	}
+
+	want := []struct {
+		id       uuid.UUID
+		next     string
+		renewals int
+		ends     string
+		charge   int
+	}{
+		{id: clamped.ID, next: "2025-11-30", renewals: 3, charge: 30},
+		{id: ending.ID, ends: "2025-12-01"},
+		{id: monthly.ID, next: "2025-12-15", renewals: 3, charge: 300},
+		{id: yearly.ID, next: "2026-01-31", renewals: 1, charge: 1500},
+	}
+	if len(got.Subscriptions) != len(want) {
+		t.Fatalf("Upcoming() = %+v, want %d subscriptions", got.Subscriptions, len(want))
+	}
+	for i, w := range want {
+		s := got.Subscriptions[i]
+		if s.Subscription.ID != w.id.String() || deref(s.NextRenewal) != w.next || s.Renewals != w.renewals || deref(s.EndsOn) != w.ends || s.ExpectedCharge != w.charge {
+			t.Errorf("subscription %d = %+v (next %q, ends %q), want %+v", i, s, deref(s.NextRenewal), deref(s.EndsOn), w)
+		}
+	}
+	if got.ExpectedTotal != 1830 || got.From != "2025-11-20" || got.To != "2026-02-20" {
+		t.Errorf("Upcoming() total %d from %s to %s, want 1830 from 2025-11-20 to 2026-02-20", got.ExpectedTotal, got.From, got.To)
+	}
+}
+
+func TestUpcoming_DefaultWindow(t *testing.T) {
+	svc, repo := newTestService(t)
+	repo.EXPECT().UpcomingSubscriptions(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, f domain.UpcomingFilter) ([]domain.Subscription, error) {
+			if !f.To.Equal(f.From.AddDate(0, defaultUpcomingMonths, 0)) {
+				t.Errorf("window = %s..%s", f.From, f.To)
+			}
+			return nil, nil
+		})
+	repo.EXPECT().ScheduledPriceChanges(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	if got, err := svc.Upcoming(context.Background(), dto.UpcomingRequest{}); err != nil || len(got.Subscriptions) != 0 {
+		t.Errorf("Upcoming() = %+v, %v", got, err)
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
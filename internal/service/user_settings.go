@@ -0,0 +1,79 @@
+package service
+
+//go:generate go tool mockgen -source=user_settings.go -destination=mocks/user_settings.go -package=mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type UserSettingsRepositoryI interface {
+	UserSettings(ctx context.Context, userID uuid.UUID) (domain.UserSettings, error)
+	SaveUserSettings(ctx context.Context, settings domain.UserSettings) (domain.UserSettings, error)
+}
+
+type UserSettingsService struct {
+	repo UserSettingsRepositoryI
+	log  *zap.Logger
+}
+
+func NewUserSettingsService(repo UserSettingsRepositoryI, log *zap.Logger) *UserSettingsService {
+	return &UserSettingsService{repo: repo, log: log}
+}
+
+func (s *UserSettingsService) UserSettings(ctx context.Context, userID uuid.UUID) (dto.UserSettingsOutput, error) {
+	ctx, span := startSpan(ctx, "UserSettingsService.UserSettings")
+	defer span.End()
+
+	settings, err := s.repo.UserSettings(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserSettingsNotFound) {
+			return dto.UserSettingsOutput{}, err
+		}
+		s.loggerWith(ctx, zap.String("user_id", userID.String())).Error("Failed to get user settings", zap.Error(err))
+		return dto.UserSettingsOutput{}, fmt.Errorf("failed to get user settings: %w", err)
+	}
+
+	return userSettingsToDto(settings), nil
+}
+
+func (s *UserSettingsService) UpdateUserSettings(ctx context.Context, userID uuid.UUID, req dto.UpdateUserSettingsRequest) (dto.UserSettingsOutput, error) {
+	ctx, span := startSpan(ctx, "UserSettingsService.UpdateUserSettings")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("user_id", userID.String()))
+
+	loc, err := LoadLocation(req.TimeZone)
+	if err != nil {
+		log.Warn("Invalid time zone", zap.String("time_zone", req.TimeZone))
+		return dto.UserSettingsOutput{}, err
+	}
+
+	settings, err := s.repo.SaveUserSettings(ctx, domain.UserSettings{UserID: userID, TimeZone: loc.String()})
+	if err != nil {
+		log.Error("Failed to save user settings", zap.Error(err))
+		return dto.UserSettingsOutput{}, fmt.Errorf("failed to save user settings: %w", err)
+	}
+
+	log.Info("User settings saved", zap.String("time_zone", settings.TimeZone))
+	return userSettingsToDto(settings), nil
+}
+
+func (s *UserSettingsService) loggerWith(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	return s.log.With(append(contextFields(ctx), fields...)...)
+}
+
+func userSettingsToDto(s domain.UserSettings) dto.UserSettingsOutput {
+	return dto.UserSettingsOutput{
+		UserID:    s.UserID.String(),
+		TimeZone:  s.TimeZone,
+		UpdatedAt: s.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,538 @@
+package service
+
+//go:generate go tool mockgen -source=webhook.go -destination=mocks/webhook.go -package=mocks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const defaultDeliveriesLimit = 50
+
+type WebhookRepositoryI interface {
+	OutboxI
+	CreateWebhook(ctx context.Context, w domain.Webhook) (domain.Webhook, error)
+	Webhook(ctx context.Context, id uuid.UUID) (domain.Webhook, error)
+	Webhooks(ctx context.Context) ([]domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, id uuid.UUID, upd domain.UpdateWebhook) (domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	UndispatchedEvents(ctx context.Context, limit int) ([]domain.Event, error)
+	MarkEventDispatched(ctx context.Context, id uuid.UUID, at time.Time) error
+	DeleteDispatchedEvents(ctx context.Context, before time.Time) (int, error)
+	CreateDelivery(ctx context.Context, d domain.WebhookDelivery) (domain.WebhookDelivery, error)
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, d domain.WebhookDelivery) (domain.WebhookDelivery, error)
+	DeleteFinishedDeliveries(ctx context.Context, before time.Time) (int, error)
+	Delivery(ctx context.Context, webhookID, id uuid.UUID) (domain.WebhookDelivery, error)
+	Deliveries(ctx context.Context, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error)
+}
+
+// SenderI posts a delivery to a webhook and returns the response status, 0 when no
+// response came. A status outside 2xx is an error.
+type SenderI interface {
+	Send(ctx context.Context, w domain.Webhook, d domain.WebhookDelivery) (int, error)
+}
+
+// RetryPolicy sets how failed deliveries are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts after which a delivery is dead.
+	MaxAttempts int
+	// BaseDelay follows the first failure and doubles after each further one up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// BatchSize limits the events dispatched and the deliveries attempted per query.
+	BatchSize int
+}
+
+// Backoff returns the delay after the given number of failed attempts.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
+type WebhookService struct {
+	repo   WebhookRepositoryI
+	tx     TransactorI
+	sender SenderI
+	retry  RetryPolicy
+	now    Clock
+	log    *zap.Logger
+}
+
+// NewWebhookService builds the service. A nil tx dispatches events without a transaction.
+func NewWebhookService(repo WebhookRepositoryI, tx TransactorI, sender SenderI, retry RetryPolicy, log *zap.Logger) *WebhookService {
+	return &WebhookService{repo: repo, tx: tx, sender: sender, retry: retry, now: time.Now, log: log}
+}
+
+// CreateWebhook registers a webhook. The response carries the secret, generated when the
+// request has none; later responses leave it out.
+func (s *WebhookService) CreateWebhook(ctx context.Context, req dto.CreateWebhookRequest) (dto.WebhookOutput, error) {
+	ctx, span := startSpan(ctx, "WebhookService.CreateWebhook")
+	defer span.End()
+
+	log := s.loggerWith(ctx)
+
+	if err := checkWebhookURL(req.URL); err != nil {
+		log.Warn("Invalid webhook url", zap.String("url", req.URL))
+		return dto.WebhookOutput{}, err
+	}
+	types, err := eventTypes(req.EventTypes)
+	if err != nil {
+		log.Warn("Invalid webhook event types", zap.Strings("event_types", req.EventTypes))
+		return dto.WebhookOutput{}, err
+	}
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = newWebhookSecret(); err != nil {
+			return dto.WebhookOutput{}, err
+		}
+	}
+
+	webhook, err := s.repo.CreateWebhook(ctx, domain.Webhook{
+		ID:         uuid.New(),
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: types,
+	})
+	if err != nil {
+		log.Error("Failed to create webhook", zap.Error(err))
+		return dto.WebhookOutput{}, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	log.Info("Webhook created", zap.String("webhook_id", webhook.ID.String()), zap.String("url", webhook.URL))
+	out := webhookToDto(webhook)
+	out.Secret = webhook.Secret
+	return out, nil
+}
+
+func (s *WebhookService) Webhook(ctx context.Context, id uuid.UUID) (dto.WebhookOutput, error) {
+	ctx, span := startSpan(ctx, "WebhookService.Webhook")
+	defer span.End()
+
+	webhook, err := s.repo.Webhook(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			return dto.WebhookOutput{}, err
+		}
+		s.loggerWith(ctx, zap.String("webhook_id", id.String())).Error("Failed to get webhook", zap.Error(err))
+		return dto.WebhookOutput{}, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return webhookToDto(webhook), nil
+}
+
+func (s *WebhookService) Webhooks(ctx context.Context) ([]dto.WebhookOutput, error) {
+	ctx, span := startSpan(ctx, "WebhookService.Webhooks")
+	defer span.End()
+
+	webhooks, err := s.repo.Webhooks(ctx)
+	if err != nil {
+		s.loggerWith(ctx).Error("Failed to get webhooks", zap.Error(err))
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	out := make([]dto.WebhookOutput, len(webhooks))
+	for i, w := range webhooks {
+		out[i] = webhookToDto(w)
+	}
+	return out, nil
+}
+
+// UpdateWebhook changes the given fields. The response carries the secret when the
+// request rotates it.
+func (s *WebhookService) UpdateWebhook(ctx context.Context, id uuid.UUID, req dto.UpdateWebhookRequest) (dto.WebhookOutput, error) {
+	ctx, span := startSpan(ctx, "WebhookService.UpdateWebhook")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("webhook_id", id.String()))
+
+	upd := domain.UpdateWebhook{URL: req.URL, Secret: req.Secret}
+	if req.URL != nil {
+		if err := checkWebhookURL(*req.URL); err != nil {
+			log.Warn("Invalid webhook url", zap.String("url", *req.URL))
+			return dto.WebhookOutput{}, err
+		}
+	}
+	if req.EventTypes != nil {
+		types, err := eventTypes(*req.EventTypes)
+		if err != nil {
+			log.Warn("Invalid webhook event types", zap.Strings("event_types", *req.EventTypes))
+			return dto.WebhookOutput{}, err
+		}
+		upd.EventTypes = &types
+	}
+
+	webhook, err := s.repo.UpdateWebhook(ctx, id, upd)
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			return dto.WebhookOutput{}, err
+		}
+		log.Error("Failed to update webhook", zap.Error(err))
+		return dto.WebhookOutput{}, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	log.Info("Webhook updated")
+	out := webhookToDto(webhook)
+	if req.Secret != nil {
+		out.Secret = webhook.Secret
+	}
+	return out, nil
+}
+
+// DeleteWebhook removes a webhook together with its deliveries.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "WebhookService.DeleteWebhook")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("webhook_id", id.String()))
+
+	if err := s.repo.DeleteWebhook(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			return err
+		}
+		log.Error("Failed to delete webhook", zap.Error(err))
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	log.Info("Webhook deleted")
+	return nil
+}
+
+// Deliveries returns the deliveries of a webhook, newest first.
+func (s *WebhookService) Deliveries(ctx context.Context, webhookID uuid.UUID, filter dto.DeliveryFilter) ([]dto.WebhookDeliveryOutput, error) {
+	ctx, span := startSpan(ctx, "WebhookService.Deliveries")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("webhook_id", webhookID.String()))
+
+	if _, err := s.repo.Webhook(ctx, webhookID); err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			return nil, err
+		}
+		log.Error("Failed to get webhook", zap.Error(err))
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	f := domain.DeliveryFilter{WebhookID: webhookID, Limit: defaultDeliveriesLimit}
+	if filter.Limit > 0 {
+		f.Limit = filter.Limit
+	}
+	if filter.Status != nil {
+		status := domain.DeliveryStatus(*filter.Status)
+		f.Status = &status
+	}
+
+	deliveries, err := s.repo.Deliveries(ctx, f)
+	if err != nil {
+		log.Error("Failed to get webhook deliveries", zap.Error(err))
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	out := make([]dto.WebhookDeliveryOutput, len(deliveries))
+	for i, d := range deliveries {
+		out[i] = deliveryToDto(d)
+	}
+	return out, nil
+}
+
+// RetryDelivery makes a pending or dead delivery due now with a fresh set of attempts.
+func (s *WebhookService) RetryDelivery(ctx context.Context, webhookID, id uuid.UUID) (dto.WebhookDeliveryOutput, error) {
+	ctx, span := startSpan(ctx, "WebhookService.RetryDelivery")
+	defer span.End()
+
+	log := s.loggerWith(ctx, zap.String("webhook_id", webhookID.String()), zap.String("delivery_id", id.String()))
+
+	delivery, err := s.repo.Delivery(ctx, webhookID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrDeliveryNotFound) {
+			return dto.WebhookDeliveryOutput{}, err
+		}
+		log.Error("Failed to get webhook delivery", zap.Error(err))
+		return dto.WebhookDeliveryOutput{}, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	if delivery.Status == domain.DeliveryDelivered {
+		return dto.WebhookDeliveryOutput{}, domain.ErrDeliveryDelivered
+	}
+
+	delivery.Status = domain.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = s.now().UTC()
+	if delivery, err = s.repo.UpdateDelivery(ctx, delivery); err != nil {
+		log.Error("Failed to requeue webhook delivery", zap.Error(err))
+		return dto.WebhookDeliveryOutput{}, fmt.Errorf("failed to requeue webhook delivery: %w", err)
+	}
+
+	log.Info("Webhook delivery requeued")
+	return deliveryToDto(delivery), nil
+}
+
+// DeliverWebhooks turns the outbox events into deliveries for the webhooks listening to
+// them, then attempts the due deliveries. A failed attempt is retried after the backoff
+// of the retry policy; the last allowed attempt moves the delivery to dead. It returns
+// the number of successful deliveries and the errors that stopped a step.
+func (s *WebhookService) DeliverWebhooks(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "WebhookService.DeliverWebhooks")
+	defer span.End()
+
+	log := s.loggerWith(ctx)
+
+	dispatched, err := s.dispatchEvents(ctx)
+	if err != nil {
+		log.Error("Failed to dispatch events", zap.Error(err))
+		return 0, fmt.Errorf("failed to dispatch events: %w", err)
+	}
+
+	webhooks, err := s.repo.Webhooks(ctx)
+	if err != nil {
+		log.Error("Failed to get webhooks", zap.Error(err))
+		return 0, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	byID := make(map[uuid.UUID]domain.Webhook, len(webhooks))
+	for _, w := range webhooks {
+		byID[w.ID] = w
+	}
+
+	due, err := s.repo.DueDeliveries(ctx, s.now().UTC(), s.retry.BatchSize)
+	if err != nil {
+		log.Error("Failed to get due webhook deliveries", zap.Error(err))
+		return 0, fmt.Errorf("failed to get due webhook deliveries: %w", err)
+	}
+
+	var (
+		delivered, failed, dead int
+		errs                    []error
+	)
+	for _, d := range due {
+		w, ok := byID[d.WebhookID]
+		if !ok {
+			continue
+		}
+		d, err := s.attempt(ctx, w, d)
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delivery %s: %w", d.ID, err))
+			continue
+		}
+		switch d.Status {
+		case domain.DeliveryDelivered:
+			delivered++
+		case domain.DeliveryDead:
+			dead++
+		default:
+			failed++
+		}
+	}
+
+	log.Info("Webhooks delivered",
+		zap.Int("events", dispatched), zap.Int("delivered", delivered), zap.Int("failed", failed), zap.Int("dead", dead))
+	return delivered, errors.Join(errs...)
+}
+
+// PurgeHistory deletes the delivered and dead deliveries last updated more than retention
+// ago, then the dispatched events older than retention that have no deliveries left. It
+// returns the number of deleted deliveries and events.
+func (s *WebhookService) PurgeHistory(ctx context.Context, retention time.Duration) (int, int, error) {
+	ctx, span := startSpan(ctx, "WebhookService.PurgeHistory")
+	defer span.End()
+
+	log := s.loggerWith(ctx)
+	before := s.now().UTC().Add(-retention)
+
+	deliveries, err := s.repo.DeleteFinishedDeliveries(ctx, before)
+	if err != nil {
+		log.Error("Failed to delete finished webhook deliveries", zap.Error(err))
+		return 0, 0, fmt.Errorf("failed to delete finished webhook deliveries: %w", err)
+	}
+	events, err := s.repo.DeleteDispatchedEvents(ctx, before)
+	if err != nil {
+		log.Error("Failed to delete dispatched events", zap.Error(err))
+		return deliveries, 0, fmt.Errorf("failed to delete dispatched events: %w", err)
+	}
+
+	log.Info("Webhook history purged", zap.Int("deliveries", deliveries), zap.Int("events", events))
+	return deliveries, events, nil
+}
+
+// dispatchEvents creates the deliveries of the undispatched events in batches and
+// returns the number of events dispatched.
+func (s *WebhookService) dispatchEvents(ctx context.Context) (int, error) {
+	dispatched := 0
+	for {
+		var batch int
+		err := inTx(ctx, s.tx, func(ctx context.Context) error {
+			events, err := s.repo.UndispatchedEvents(ctx, s.retry.BatchSize)
+			if err != nil || len(events) == 0 {
+				return err
+			}
+			webhooks, err := s.repo.Webhooks(ctx)
+			if err != nil {
+				return err
+			}
+
+			now := s.now().UTC()
+			for _, e := range events {
+				for _, w := range webhooks {
+					if !w.Accepts(e.Type) {
+						continue
+					}
+					_, err := s.repo.CreateDelivery(ctx, domain.WebhookDelivery{
+						ID:            uuid.New(),
+						WebhookID:     w.ID,
+						EventID:       e.ID,
+						EventType:     e.Type,
+						Payload:       e.Payload,
+						Status:        domain.DeliveryPending,
+						NextAttemptAt: now,
+					})
+					if err != nil {
+						return err
+					}
+				}
+				if err := s.repo.MarkEventDispatched(ctx, e.ID, now); err != nil {
+					return err
+				}
+			}
+			batch = len(events)
+			return nil
+		})
+		if err != nil {
+			return dispatched, err
+		}
+		dispatched += batch
+		if batch < s.retry.BatchSize {
+			return dispatched, nil
+		}
+	}
+}
+
+// attempt sends d once and stores the outcome.
+func (s *WebhookService) attempt(ctx context.Context, w domain.Webhook, d domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	log := s.loggerWith(ctx, zap.String("webhook_id", w.ID.String()), zap.String("delivery_id", d.ID.String()))
+
+	code, sendErr := s.sender.Send(ctx, w, d)
+	if ctx.Err() != nil {
+		return d, ctx.Err()
+	}
+
+	now := s.now().UTC()
+	d.Attempts++
+	d.LastStatusCode = nil
+	if code != 0 {
+		d.LastStatusCode = &code
+	}
+	switch {
+	case sendErr == nil:
+		d.Status = domain.DeliveryDelivered
+		d.DeliveredAt = &now
+		d.LastError = ""
+	case d.Attempts >= s.retry.MaxAttempts:
+		d.Status = domain.DeliveryDead
+		d.LastError = sendErr.Error()
+		log.Warn("Webhook delivery is dead", zap.Int("attempts", d.Attempts), zap.Error(sendErr))
+	default:
+		d.NextAttemptAt = now.Add(s.retry.Backoff(d.Attempts))
+		d.LastError = sendErr.Error()
+		log.Info("Webhook delivery failed, will retry", zap.Int("attempts", d.Attempts), zap.Time("next_attempt_at", d.NextAttemptAt), zap.Error(sendErr))
+	}
+
+	updated, err := s.repo.UpdateDelivery(ctx, d)
+	if err != nil {
+		log.Error("Failed to record webhook delivery attempt", zap.Error(err))
+		return d, fmt.Errorf("failed to record attempt: %w", err)
+	}
+	return updated, nil
+}
+
+func (s *WebhookService) loggerWith(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	return s.log.With(append(contextFields(ctx), fields...)...)
+}
+
+// checkWebhookURL accepts absolute http and https URLs.
+func checkWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", domain.ErrInvalidWebhook)
+	}
+	return nil
+}
+
+// eventTypes parses event type names, dropping repeated ones.
+func eventTypes(names []string) ([]domain.EventType, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: at least one event type is required", domain.ErrInvalidWebhook)
+	}
+	types := make([]domain.EventType, 0, len(names))
+	for _, name := range names {
+		t, err := domain.ParseEventType(name)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func webhookToDto(w domain.Webhook) dto.WebhookOutput {
+	types := make([]string, len(w.EventTypes))
+	for i, t := range w.EventTypes {
+		types[i] = string(t)
+	}
+	return dto.WebhookOutput{
+		ID:         w.ID.String(),
+		URL:        w.URL,
+		EventTypes: types,
+		CreatedAt:  w.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  w.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func deliveryToDto(d domain.WebhookDelivery) dto.WebhookDeliveryOutput {
+	out := dto.WebhookDeliveryOutput{
+		ID:             d.ID.String(),
+		WebhookID:      d.WebhookID.String(),
+		EventID:        d.EventID.String(),
+		EventType:      string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		Payload:        d.Payload,
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      d.UpdatedAt.Format(time.RFC3339),
+	}
+	if d.Status == domain.DeliveryPending {
+		next := d.NextAttemptAt.UTC().Format(time.RFC3339)
+		out.NextAttemptAt = &next
+	}
+	if d.DeliveredAt != nil {
+		at := d.DeliveredAt.UTC().Format(time.RFC3339)
+		out.DeliveredAt = &at
+	}
+	return out
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"tz/internal/domain"
+	"tz/internal/dto"
+	"tz/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+var testRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: 30 * time.Second, MaxDelay: time.Minute, BatchSize: 10}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 30 * time.Second, MaxDelay: 5 * time.Minute}
+	want := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	for i, w := range want {
+		if got := policy.Backoff(i + 1); got != w {
+			t.Errorf("Backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+func TestCreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewMockWebhookRepositoryI(gomock.NewController(t))
+	svc := NewWebhookService(repo, nil, nil, testRetry, zap.NewNop())
+
+	repo.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, w domain.Webhook) (domain.Webhook, error) {
+			if len(w.EventTypes) != 2 || w.EventTypes[0] != domain.EventCreated || w.EventTypes[1] != domain.EventDeleted {
+				t.Errorf("EventTypes = %v, want created and deleted once each", w.EventTypes)
+			}
+			return w, nil
+		})
+	out, err := svc.CreateWebhook(ctx, dto.CreateWebhookRequest{URL: "https://example.com/hook", EventTypes: []string{"created", "deleted", "created"}})
+	if err != nil || !strings.HasPrefix(out.Secret, "whsec_") {
+		t.Errorf("CreateWebhook() = %+v, %v, want a generated secret", out, err)
+	}
+
+	invalid := []dto.CreateWebhookRequest{
+		{URL: "ftp://example.com", EventTypes: []string{"created"}},
+		{URL: "/hook", EventTypes: []string{"created"}},
+		{URL: "https://example.com/hook"},
+		{URL: "https://example.com/hook", EventTypes: []string{"renewed"}},
+	}
+	for _, req := range invalid {
+		if _, err := svc.CreateWebhook(ctx, req); !errors.Is(err, domain.ErrInvalidWebhook) {
+			t.Errorf("CreateWebhook(%+v) error = %v, want ErrInvalidWebhook", req, err)
+		}
+	}
+}
+
+func TestDeliverWebhooks(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockWebhookRepositoryI(ctrl)
+	sender := mocks.NewMockSenderI(ctrl)
+	now := time.Date(2025, time.November, 8, 10, 0, 0, 0, time.UTC)
+	svc := NewWebhookService(repo, nil, sender, testRetry, zap.NewNop())
+	svc.now = func() time.Time { return now }
+
+	created := domain.Webhook{ID: uuid.New(), EventTypes: []domain.EventType{domain.EventCreated}}
+	deleted := domain.Webhook{ID: uuid.New(), EventTypes: []domain.EventType{domain.EventDeleted}}
+	event := domain.Event{ID: uuid.New(), Type: domain.EventCreated, Payload: []byte(`{}`)}
+
+	repo.EXPECT().UndispatchedEvents(gomock.Any(), 10).Return([]domain.Event{event}, nil)
+	repo.EXPECT().Webhooks(gomock.Any()).Return([]domain.Webhook{created, deleted}, nil).Times(2)
+	repo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, d domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+			if d.WebhookID != created.ID || d.EventID != event.ID || d.Status != domain.DeliveryPending || !d.NextAttemptAt.Equal(now) {
+				t.Errorf("CreateDelivery(%+v)", d)
+			}
+			return d, nil
+		})
+	repo.EXPECT().MarkEventDispatched(gomock.Any(), event.ID, now).Return(nil)
+
+	ok := domain.WebhookDelivery{ID: uuid.New(), WebhookID: created.ID, Status: domain.DeliveryPending}
+	failing := domain.WebhookDelivery{ID: uuid.New(), WebhookID: created.ID, Status: domain.DeliveryPending, Attempts: 1}
+	last := domain.WebhookDelivery{ID: uuid.New(), WebhookID: created.ID, Status: domain.DeliveryPending, Attempts: 2}
+	repo.EXPECT().DueDeliveries(gomock.Any(), now, 10).Return([]domain.WebhookDelivery{ok, failing, last}, nil)
+
+	sender.EXPECT().Send(gomock.Any(), created, ok).Return(200, nil)
+	sender.EXPECT().Send(gomock.Any(), created, failing).Return(500, errors.New("unexpected status 500"))
+	sender.EXPECT().Send(gomock.Any(), created, last).Return(0, errors.New("connection refused"))
+
+	stored := map[uuid.UUID]domain.WebhookDelivery{}
+	repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ context.Context, d domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+			stored[d.ID] = d
+			return d, nil
+		})
+
+	delivered, err := svc.DeliverWebhooks(ctx)
+	if err != nil || delivered != 1 {
+		t.Fatalf("DeliverWebhooks() = %d, %v, want 1", delivered, err)
+	}
+
+	if d := stored[ok.ID]; d.Status != domain.DeliveryDelivered || d.Attempts != 1 || d.DeliveredAt == nil || *d.LastStatusCode != 200 {
+		t.Errorf("delivered = %+v", d)
+	}
+	if d := stored[failing.ID]; d.Status != domain.DeliveryPending || d.Attempts != 2 ||
+		!d.NextAttemptAt.Equal(now.Add(time.Minute)) || *d.LastStatusCode != 500 || d.LastError != "unexpected status 500" {
+		t.Errorf("retried = %+v", d)
+	}
+	if d := stored[last.ID]; d.Status != domain.DeliveryDead || d.Attempts != 3 || d.LastStatusCode != nil || d.LastError != "connection refused" {
+		t.Errorf("dead = %+v", d)
+	}
+}
+
+func TestRetryDelivery(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewMockWebhookRepositoryI(gomock.NewController(t))
+	now := time.Date(2025, time.November, 8, 10, 0, 0, 0, time.UTC)
+	svc := NewWebhookService(repo, nil, nil, testRetry, zap.NewNop())
+	svc.now = func() time.Time { return now }
+
+	webhookID := uuid.New()
+	dead := domain.WebhookDelivery{ID: uuid.New(), WebhookID: webhookID, Status: domain.DeliveryDead, Attempts: 3}
+	delivered := domain.WebhookDelivery{ID: uuid.New(), WebhookID: webhookID, Status: domain.DeliveryDelivered, Attempts: 1}
+
+	repo.EXPECT().Delivery(gomock.Any(), webhookID, dead.ID).Return(dead, nil)
+	repo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, d domain.WebhookDelivery) (domain.WebhookDelivery, error) { return d, nil })
+	out, err := svc.RetryDelivery(ctx, webhookID, dead.ID)
+	if err != nil || out.Status != "pending" || out.Attempts != 0 || out.NextAttemptAt == nil || *out.NextAttemptAt != "2025-11-08T10:00:00Z" {
+		t.Errorf("RetryDelivery(dead) = %+v, %v", out, err)
+	}
+
+	repo.EXPECT().Delivery(gomock.Any(), webhookID, delivered.ID).Return(delivered, nil)
+	if _, err := svc.RetryDelivery(ctx, webhookID, delivered.ID); !errors.Is(err, domain.ErrDeliveryDelivered) {
+		t.Errorf("RetryDelivery(delivered) error = %v, want ErrDeliveryDelivered", err)
+	}
+
+	repo.EXPECT().Delivery(gomock.Any(), webhookID, gomock.Any()).Return(domain.WebhookDelivery{}, domain.ErrDeliveryNotFound)
+	if _, err := svc.RetryDelivery(ctx, webhookID, uuid.New()); !errors.Is(err, domain.ErrDeliveryNotFound) {
+		t.Errorf("RetryDelivery(unknown) error = %v, want ErrDeliveryNotFound", err)
+	}
+}
+
+func TestPurgeHistory(t *testing.T) {
+	repo := mocks.NewMockWebhookRepositoryI(gomock.NewController(t))
+	now := time.Date(2025, time.November, 8, 10, 0, 0, 0, time.UTC)
+	svc := NewWebhookService(repo, nil, nil, testRetry, zap.NewNop())
+	svc.now = func() time.Time { return now }
+
+	before := now.Add(-7 * 24 * time.Hour)
+	repo.EXPECT().DeleteFinishedDeliveries(gomock.Any(), before).Return(5, nil)
+	repo.EXPECT().DeleteDispatchedEvents(gomock.Any(), before).Return(3, nil)
+
+	deliveries, events, err := svc.PurgeHistory(context.Background(), 7*24*time.Hour)
+	if err != nil || deliveries != 5 || events != 3 {
+		t.Errorf("PurgeHistory() = %d, %d, %v, want 5, 3", deliveries, events, err)
+	}
+}
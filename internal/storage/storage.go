@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"tz/internal/config"
+	"tz/internal/db"
+	"tz/internal/repository"
+	"tz/internal/service"
+	"tz/internal/stream"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// Storage holds the repositories of the configured database driver.
+type Storage struct {
+	Driver        string
+	Subscriptions service.SubscriptionRepositoryI
+	APIKeys       service.APIKeyRepositoryI
+	UserSettings  service.UserSettingsRepositoryI
+	Budgets       service.BudgetRepositoryI
+	Catalog       service.CatalogRepositoryI
+	Webhooks      service.WebhookRepositoryI
+	// Events reads the outbox of Webhooks in sequence order.
+	Events stream.Log
+	// Transactor groups statements of the SQL repositories, nil for the memory driver.
+	Transactor service.TransactorI
+	// DB is the SQL connection pool, nil for the memory driver.
+	DB *sqlx.DB
+}
+
+// Open connects to the backend selected by cfg.Driver. Statements are traced for SQL drivers.
+func Open(cfg config.DatabaseConfig, log *zap.Logger) (*Storage, error) {
+	switch cfg.Driver {
+	case config.DriverMemory:
+		webhooks := repository.NewMemoryWebhookRepository()
+		subscriptions := repository.NewMemorySubscriptionRepository()
+		return &Storage{
+			Driver:        cfg.Driver,
+			Subscriptions: subscriptions,
+			APIKeys:       repository.NewMemoryAPIKeyRepository(),
+			UserSettings:  repository.NewMemoryUserSettingsRepository(),
+			Budgets:       repository.NewMemoryBudgetRepository(),
+			Catalog:       repository.NewMemoryCatalogRepository(subscriptions),
+			Webhooks:      webhooks,
+			Events:        webhooks,
+		}, nil
+	case config.DriverSQLite, config.DriverPostgres:
+		var (
+			database *sqlx.DB
+			err      error
+		)
+		if cfg.Driver == config.DriverSQLite {
+			database, err = db.NewSQLite(cfg, log)
+		} else {
+			database, err = db.New(cfg, log)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		query := repository.WithTracing(repository.WithTransactions(database), cfg.Driver)
+		webhooks := repository.NewWebhookRepository(query)
+		return &Storage{
+			Driver:        cfg.Driver,
+			Subscriptions: repository.NewSubscriptionRepository(query),
+			APIKeys:       repository.NewAPIKeyRepository(query),
+			UserSettings:  repository.NewUserSettingsRepository(query),
+			Budgets:       repository.NewBudgetRepository(query),
+			Catalog:       repository.NewCatalogRepository(query),
+			Webhooks:      webhooks,
+			Events:        webhooks,
+			Transactor:    repository.NewTransactor(database),
+			DB:            database,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", cfg.Driver)
+	}
+}
+
+// Ping checks the connection. The memory driver is always reachable.
+func (s *Storage) Ping(ctx context.Context) error {
+	if s.DB == nil {
+		return nil
+	}
+	return s.DB.PingContext(ctx)
+}
+
+func (s *Storage) Close() error {
+	if s.DB == nil {
+		return nil
+	}
+	return s.DB.Close()
+}
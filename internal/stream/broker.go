@@ -0,0 +1,196 @@
+// Package stream fans the events of the outbox log out to live subscribers.
+package stream
+
+import (
+	"context"
+	"sync"
+	"time"
+	"tz/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// Log is the ordered event log the broker reads.
+type Log interface {
+	EventsAfter(ctx context.Context, seq int64, limit int) ([]domain.Event, error)
+	LastEventSeq(ctx context.Context) (int64, error)
+}
+
+// Options tune the broker.
+type Options struct {
+	// PollInterval reads the log periodically for backends without notifications.
+	// Zero reads it only on Notify.
+	PollInterval time.Duration
+	// Buffer is the number of events a subscriber may lag behind before it is dropped.
+	Buffer int
+	// BatchSize limits the events read per query.
+	BatchSize int
+	// GapTimeout is how long a missing sequence number holds back the events after it.
+	// A number is taken when the event is inserted but shows up at commit, so a later
+	// event can be seen first; a rolled back transaction leaves the hole for good.
+	GapTimeout time.Duration
+}
+
+// Broker reads new events from the log when notified and sends them, in sequence order,
+// to every subscriber. A subscriber that does not keep up is dropped: its channel is
+// closed and it is expected to resume from the log.
+type Broker struct {
+	log    Log
+	opts   Options
+	logger *zap.Logger
+	now    func() time.Time
+	wake   chan struct{}
+
+	mu     sync.Mutex
+	subs   map[chan domain.Event]struct{}
+	closed bool
+}
+
+func NewBroker(log Log, opts Options, logger *zap.Logger) *Broker {
+	return &Broker{
+		log:    log,
+		opts:   opts,
+		logger: logger.Named("stream"),
+		now:    time.Now,
+		wake:   make(chan struct{}, 1),
+		subs:   make(map[chan domain.Event]struct{}),
+	}
+}
+
+// Notify tells the broker that new events may be in the log. It never blocks.
+func (b *Broker) Notify() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Subscribe returns a channel of the events read from now on and a function that ends
+// the subscription. The channel is closed when the subscriber is dropped, the
+// subscription ends or the broker stops.
+func (b *Broker) Subscribe() (<-chan domain.Event, func()) {
+	ch := make(chan domain.Event, b.opts.Buffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	b.subs[ch] = struct{}{}
+	return ch, func() { b.remove(ch) }
+}
+
+// Run reads the log until ctx is done, then closes all subscriptions.
+func (b *Broker) Run(ctx context.Context) {
+	defer b.closeAll()
+
+	var tick <-chan time.Time
+	if b.opts.PollInterval > 0 {
+		ticker := time.NewTicker(b.opts.PollInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var (
+		last     int64
+		started  bool
+		gapSince time.Time
+	)
+	for {
+		if !started {
+			seq, err := b.log.LastEventSeq(ctx)
+			if err == nil {
+				last, started = seq, true
+			} else if ctx.Err() == nil {
+				b.logger.Error("Failed to read the event log position", zap.Error(err))
+			}
+		}
+
+		// While a gap holds events back, read again when it times out.
+		var retry <-chan time.Time
+		if started {
+			var held bool
+			if last, gapSince, held = b.read(ctx, last, gapSince); held {
+				retry = time.After(b.opts.GapTimeout - b.now().Sub(gapSince))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.wake:
+		case <-tick:
+		case <-retry:
+		}
+	}
+}
+
+// read publishes the events after last and returns the new position. held is true
+// when a gap in the sequence holds back the remaining events.
+func (b *Broker) read(ctx context.Context, last int64, gapSince time.Time) (int64, time.Time, bool) {
+	for {
+		events, err := b.log.EventsAfter(ctx, last, b.opts.BatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				b.logger.Error("Failed to read events", zap.Int64("after", last), zap.Error(err))
+			}
+			return last, gapSince, false
+		}
+
+		for _, e := range events {
+			if e.Seq != last+1 {
+				if gapSince.IsZero() {
+					gapSince = b.now()
+				}
+				if b.now().Sub(gapSince) < b.opts.GapTimeout {
+					return last, gapSince, true
+				}
+				b.logger.Debug("Skipping missing events", zap.Int64("from", last+1), zap.Int64("to", e.Seq-1))
+			}
+			gapSince = time.Time{}
+			b.publish(e)
+			last = e.Seq
+		}
+		if len(events) < b.opts.BatchSize {
+			return last, gapSince, false
+		}
+	}
+}
+
+func (b *Broker) publish(e domain.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+			b.logger.Warn("Dropping a subscriber that fell behind", zap.Int64("seq", e.Seq))
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+func (b *Broker) remove(ch chan domain.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[ch]; ok {
+		delete(b.subs, ch)
+		close(ch)
+	}
+}
+
+func (b *Broker) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subs {
+		delete(b.subs, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,183 @@
+package stream
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+	"tz/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// fakeLog is an event log whose events become visible when committed, in any order.
+// ready is closed once the broker has read its starting position.
+type fakeLog struct {
+	mu        sync.Mutex
+	events    []domain.Event
+	ready     chan struct{}
+	readyOnce sync.Once
+}
+
+func newFakeLog() *fakeLog {
+	return &fakeLog{ready: make(chan struct{})}
+}
+
+func (l *fakeLog) commit(seqs ...int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, seq := range seqs {
+		l.events = append(l.events, domain.Event{Seq: seq, Type: domain.EventCreated})
+	}
+	slices.SortFunc(l.events, func(a, b domain.Event) int { return int(a.Seq - b.Seq) })
+}
+
+func (l *fakeLog) EventsAfter(ctx context.Context, seq int64, limit int) ([]domain.Event, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var events []domain.Event
+	for _, e := range l.events {
+		if e.Seq > seq && len(events) < limit {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (l *fakeLog) LastEventSeq(ctx context.Context) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	defer l.readyOnce.Do(func() { close(l.ready) })
+
+	if len(l.events) == 0 {
+		return 0, nil
+	}
+	return l.events[len(l.events)-1].Seq, nil
+}
+
+func startBroker(t *testing.T, log *fakeLog, opts Options) (*Broker, context.CancelFunc) {
+	t.Helper()
+
+	b := NewBroker(log, opts, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		b.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	<-log.ready
+	return b, cancel
+}
+
+// receive reads n events or fails after a second.
+func receive(t *testing.T, ch <-chan domain.Event, n int) []int64 {
+	t.Helper()
+
+	var seqs []int64
+	timeout := time.After(time.Second)
+	for len(seqs) < n {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				t.Fatalf("channel closed after %v", seqs)
+			}
+			seqs = append(seqs, e.Seq)
+		case <-timeout:
+			t.Fatalf("received %v, want %d events", seqs, n)
+		}
+	}
+	return seqs
+}
+
+func TestBroker(t *testing.T) {
+	log := newFakeLog()
+	log.commit(1, 2)
+	b, _ := startBroker(t, log, Options{Buffer: 10, BatchSize: 2, GapTimeout: time.Hour})
+
+	events, unsubscribe := b.Subscribe()
+	defer unsubscribe()
+
+	// Events before the start are history, only later ones are sent.
+	log.commit(3, 4, 5)
+	b.Notify()
+	if got := receive(t, events, 3); !slices.Equal(got, []int64{3, 4, 5}) {
+		t.Errorf("received %v, want [3 4 5]", got)
+	}
+
+	// 7 committed before 6 waits for it.
+	log.commit(7)
+	b.Notify()
+	time.Sleep(20 * time.Millisecond)
+	log.commit(6)
+	b.Notify()
+	if got := receive(t, events, 2); !slices.Equal(got, []int64{6, 7}) {
+		t.Errorf("received %v, want [6 7]", got)
+	}
+}
+
+func TestBroker_SkipsGapAfterTimeout(t *testing.T) {
+	log := newFakeLog()
+	b, _ := startBroker(t, log, Options{Buffer: 10, BatchSize: 10, GapTimeout: 30 * time.Millisecond})
+
+	events, unsubscribe := b.Subscribe()
+	defer unsubscribe()
+
+	// 1 was rolled back and never shows up.
+	log.commit(2, 3)
+	b.Notify()
+	if got := receive(t, events, 2); !slices.Equal(got, []int64{2, 3}) {
+		t.Errorf("received %v, want [2 3]", got)
+	}
+}
+
+func TestBroker_Polls(t *testing.T) {
+	log := newFakeLog()
+	b, _ := startBroker(t, log, Options{PollInterval: 10 * time.Millisecond, Buffer: 10, BatchSize: 10, GapTimeout: time.Hour})
+
+	events, unsubscribe := b.Subscribe()
+	defer unsubscribe()
+
+	log.commit(1)
+	if got := receive(t, events, 1); !slices.Equal(got, []int64{1}) {
+		t.Errorf("received %v, want [1]", got)
+	}
+}
+
+func TestBroker_DropsSlowSubscriber(t *testing.T) {
+	log := newFakeLog()
+	b, cancel := startBroker(t, log, Options{Buffer: 1, BatchSize: 10, GapTimeout: time.Hour})
+
+	slow, _ := b.Subscribe()
+	fast, unsubscribe := b.Subscribe()
+	defer unsubscribe()
+
+	log.commit(1)
+	b.Notify()
+	receive(t, fast, 1)
+	log.commit(2)
+	b.Notify()
+	receive(t, fast, 1)
+
+	if e, ok := <-slow; !ok || e.Seq != 1 {
+		t.Errorf("slow subscriber received %+v, %v, want event 1", e, ok)
+	}
+	if _, ok := <-slow; ok {
+		t.Error("slow subscriber was not dropped")
+	}
+
+	cancel()
+	if _, ok := <-fast; ok {
+		t.Error("subscription is open after the broker stopped")
+	}
+	late, _ := b.Subscribe()
+	if _, ok := <-late; ok {
+		t.Error("subscription after stop is open")
+	}
+}
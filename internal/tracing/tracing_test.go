@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+	"tz/internal/config"
+)
+
+func TestLogFields(t *testing.T) {
+	if fields := LogFields(context.Background()); len(fields) != 0 {
+		t.Fatalf("LogFields() without span = %v, want none", fields)
+	}
+
+	shutdown, err := New(context.Background(), config.TracingConfig{
+		Exporter:    ExporterStdout,
+		ServiceName: "test",
+		SampleRatio: 1,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer shutdown(context.Background())
+
+	ctx, span := Tracer().Start(context.Background(), "test")
+	defer span.End()
+
+	fields := LogFields(ctx)
+	if len(fields) != 2 || fields[0].Key != "trace_id" || fields[1].Key != "span_id" {
+		t.Fatalf("LogFields() = %v, want trace_id and span_id", fields)
+	}
+	if fields[0].String != span.SpanContext().TraceID().String() {
+		t.Errorf("trace_id = %s, want %s", fields[0].String, span.SpanContext().TraceID())
+	}
+}
+
+func TestNew_UnknownExporter(t *testing.T) {
+	if _, err := New(context.Background(), config.TracingConfig{Exporter: "jaeger"}); err == nil {
+		t.Fatal("New() error = nil, want unknown exporter error")
+	}
+}
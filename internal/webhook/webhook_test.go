@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+	"tz/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1_762_600_000, 0)
+	body := []byte(`{"type":"created"}`)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	signature := Sign("secret", now.Unix(), body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		want      error
+	}{
+		{name: "valid", secret: "secret", timestamp: ts, signature: signature, body: body, now: now},
+		{name: "within tolerance", secret: "secret", timestamp: ts, signature: signature, body: body, now: now.Add(4 * time.Minute)},
+		{name: "wrong secret", secret: "other", timestamp: ts, signature: signature, body: body, now: now, want: ErrInvalidSignature},
+		{name: "tampered body", secret: "secret", timestamp: ts, signature: signature, body: []byte(`{"type":"deleted"}`), now: now, want: ErrInvalidSignature},
+		{name: "other timestamp", secret: "secret", timestamp: strconv.FormatInt(now.Unix()+1, 10), signature: signature, body: body, now: now, want: ErrInvalidSignature},
+		{name: "stale", secret: "secret", timestamp: ts, signature: signature, body: body, now: now.Add(10 * time.Minute), want: ErrStaleTimestamp},
+		{name: "bad timestamp", secret: "secret", timestamp: "yesterday", signature: signature, body: body, now: now, want: ErrInvalidSignature},
+		{name: "missing prefix", secret: "secret", timestamp: ts, signature: signature[len("sha256="):], body: body, now: now, want: ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.timestamp, tt.signature, tt.body, tt.now, 5*time.Minute)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientSend(t *testing.T) {
+	var (
+		got    []byte
+		header http.Header
+		status = http.StatusNoContent
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := VerifyRequest(r, "secret", time.Minute)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		got, header = body, r.Header
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	client := NewClient(time.Second)
+	delivery := domain.WebhookDelivery{ID: uuid.New(), EventType: domain.EventCreated, Payload: []byte(`{"type":"created"}`)}
+
+	code, err := client.Send(context.Background(), domain.Webhook{URL: srv.URL, Secret: "secret"}, delivery)
+	if err != nil || code != http.StatusNoContent {
+		t.Fatalf("Send() = %d, %v, want 204", code, err)
+	}
+	if string(got) != `{"type":"created"}` || header.Get(HeaderEvent) != "created" || header.Get(HeaderDelivery) != delivery.ID.String() {
+		t.Errorf("received body %s with headers %v", got, header)
+	}
+
+	if code, err := client.Send(context.Background(), domain.Webhook{URL: srv.URL, Secret: "wrong"}, delivery); err == nil || code != http.StatusUnauthorized {
+		t.Errorf("Send() with wrong secret = %d, %v, want 401 and an error", code, err)
+	}
+
+	status = http.StatusInternalServerError
+	if code, err := client.Send(context.Background(), domain.Webhook{URL: srv.URL, Secret: "secret"}, delivery); err == nil || code != http.StatusInternalServerError {
+		t.Errorf("Send() to failing receiver = %d, %v, want 500 and an error", code, err)
+	}
+
+	srv.Close()
+	if code, err := client.Send(context.Background(), domain.Webhook{URL: srv.URL, Secret: "secret"}, delivery); err == nil || code != 0 {
+		t.Errorf("Send() to closed receiver = %d, %v, want 0 and an error", code, err)
+	}
+}